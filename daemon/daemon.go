@@ -6,21 +6,26 @@ import (
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/juju/errors"
 
 	"github.com/yext/revere/db"
 	"github.com/yext/revere/env"
+	"github.com/yext/revere/setting"
+	"github.com/yext/revere/target"
 )
 
 // Daemon represents the part of Revere that actually executes monitors and
 // triggers and dispatches alerts.
 type Daemon struct {
 	monitors map[db.MonitorID]*monitor
+	outages  *resourceOutages
 
 	lastMonitorsUpdate time.Time
 
-	stop    chan struct{}
-	stopper sync.Once
-	stopped chan struct{}
+	stop          chan struct{}
+	stopper       sync.Once
+	stopped       chan struct{}
+	outboxStopped chan struct{}
 
 	*env.Env
 }
@@ -28,16 +33,19 @@ type Daemon struct {
 // New initializes a new Daemon. To actually make the Daemon run, call Start.
 func New(env *env.Env) *Daemon {
 	return &Daemon{
-		monitors: make(map[db.MonitorID]*monitor),
-		stop:     make(chan struct{}),
-		stopped:  make(chan struct{}),
-		Env:      env,
+		monitors:      make(map[db.MonitorID]*monitor),
+		outages:       newResourceOutages(),
+		stop:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+		outboxStopped: make(chan struct{}),
+		Env:           env,
 	}
 }
 
 // Start starts running a Daemon.
 func (d *Daemon) Start() {
 	go d.run()
+	go d.runOutbox()
 }
 
 func (d *Daemon) run() {
@@ -51,6 +59,7 @@ func (d *Daemon) run() {
 	for {
 		select {
 		case <-t.C:
+			d.updateAlertStorms()
 			d.updateMonitors()
 		case <-d.stop:
 			return
@@ -99,7 +108,7 @@ func (d *Daemon) updateMonitors() {
 			"version": info.Version,
 		}).Info("Starting monitor.")
 
-		new, err := newMonitor(info.MonitorID, d.Env)
+		new, err := newMonitor(info.MonitorID, d.outages, d.Env)
 		if err != nil {
 			log.WithError(err).WithFields(log.Fields{
 				"monitor": info.MonitorID,
@@ -118,6 +127,50 @@ func (d *Daemon) updateMonitors() {
 	d.lastMonitorsUpdate = currentUpdateTime
 }
 
+// updateAlertStorms applies the current alert storm protection settings and
+// sends summaries of alerts suppressed by them.
+func (d *Daemon) updateAlertStorms() {
+	s, err := loadAlertStormSetting(d.DB)
+	if err != nil {
+		log.WithError(err).Error("Could not load alert storm settings. Keeping current ones.")
+	} else {
+		if s.Limits() {
+			target.SetAlertLimit(s.MaxAlerts, s.Window())
+		} else {
+			target.SetAlertLimit(0, 0)
+		}
+		if s.DetectsOutages() {
+			d.outages.configure(s.OutagePercent, s.OutageEmail)
+		} else {
+			d.outages.configure(0, "")
+		}
+	}
+
+	target.SendLimitSummaries(d.DB)
+}
+
+func loadAlertStormSetting(Db *db.DB) (*setting.AlertStormSetting, error) {
+	stormSetting := setting.AlertStormSetting{}
+	dbSettings, err := Db.LoadSettingsOfType(stormSetting.Type().Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(dbSettings) == 0 {
+		return &stormSetting, nil
+	}
+
+	s, err := setting.LoadFromDB(stormSetting.Type().Id(), dbSettings[0].Setting)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	stormSettings, ok := s.(*setting.AlertStormSetting)
+	if !ok {
+		return nil, errors.New("extracting alert storm settings")
+	}
+	return stormSettings, nil
+}
+
 // Stop gracefully stops a Daemon. It tries to allow any in-progress delivery of
 // alerts to finish before returning.
 func (d *Daemon) Stop() {
@@ -126,6 +179,7 @@ func (d *Daemon) Stop() {
 		// be stopped.
 		close(d.stop)
 		<-d.stopped
+		<-d.outboxStopped
 
 		for id, m := range d.monitors {
 			log.WithFields(log.Fields{
@@ -0,0 +1,193 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/env"
+	"github.com/yext/revere/target"
+)
+
+type escalationStepTemplate struct {
+	delay time.Duration
+	*triggerTemplate
+}
+
+// newEscalationStepTemplates loads the steps of the escalation policy a
+// trigger targets.
+func newEscalationStepTemplates(tx *db.Tx, policy *target.EscalationPolicy, env *env.Env) ([]*escalationStepTemplate, error) {
+	dbSteps, err := tx.LoadEscalationSteps(policy.PolicyID)
+	if err != nil {
+		return nil, errors.Maskf(err, "load escalation steps for policy %d", policy.PolicyID)
+	}
+
+	steps := make([]*escalationStepTemplate, 0, len(dbSteps))
+	for _, dbStep := range dbSteps {
+		triggerTemplate, err := newTriggerTemplate(dbStep.Trigger, env)
+		if err != nil {
+			return nil, errors.Maskf(err, "make escalation step %d", dbStep.TriggerID)
+		}
+		steps = append(steps, &escalationStepTemplate{
+			delay:           time.Duration(dbStep.DelayMilli) * time.Millisecond,
+			triggerTemplate: triggerTemplate,
+		})
+	}
+	return steps, nil
+}
+
+type escalationStep struct {
+	delay time.Duration
+	*trigger
+}
+
+// escalation notifies the steps of an escalation policy for one subprobe. The
+// policy's trigger decides the level, repeat period and whether to notify on
+// de-escalation, while how long the subprobe has been in its state decides
+// which steps are notified. Alerts to acknowledged subprobes are suppressed
+// before they get here, so escalation stops once someone acknowledges.
+type escalation struct {
+	*triggerTemplate
+	steps []*escalationStep
+
+	// incidentStart is when the subprobe last reached the policy's level,
+	// or zero if it's below it. It's saved so that restarts don't forget
+	// which steps to notify on de-escalation.
+	incidentStart time.Time
+
+	*env.Env
+}
+
+func newEscalation(template *triggerTemplate, stepTemplates []*escalationStepTemplate, lastAlerts, incidentStarts map[db.TriggerID]time.Time, Env *env.Env) *escalation {
+	e := &escalation{
+		triggerTemplate: template,
+		incidentStart:   incidentStarts[template.id],
+		Env:             Env,
+	}
+	for _, stepTemplate := range stepTemplates {
+		t := newTrigger(stepTemplate.triggerTemplate, Env)
+		t.lastAlert = lastAlerts[stepTemplate.id]
+		e.steps = append(e.steps, &escalationStep{delay: stepTemplate.delay, trigger: t})
+	}
+	return e
+}
+
+// alert notifies the steps of e that a calls for. Steps are notified once the
+// subprobe has been in its state for their delay, and are then repeated no
+// more often than the policy's period while it stays there.
+func (e *escalation) alert(a *target.Alert) {
+	var toAlert []*escalationStep
+	switch {
+	case a.Flapping:
+		if a.OldState < e.level && a.NewState < e.level {
+			return
+		}
+		toAlert = e.reached()
+		if len(toAlert) == 0 {
+			toAlert = e.due(0)
+		}
+	case a.NewState >= e.level:
+		if a.OldState < e.level || e.incidentStart.IsZero() {
+			e.setIncidentStart(a, a.EnteredState)
+		}
+		for _, step := range e.due(a.Recorded.Sub(a.EnteredState)) {
+			switch {
+			case step.lastAlert.Before(a.EnteredState):
+				toAlert = append(toAlert, step)
+			case a.OldState == a.NewState && time.Since(step.lastAlert) >= e.period:
+				toAlert = append(toAlert, step)
+			}
+		}
+	case a.OldState >= e.level:
+		if e.triggerOnExit {
+			toAlert = e.reached()
+		}
+		e.setIncidentStart(a, time.Time{})
+	}
+
+	e.send(a, toAlert)
+}
+
+// setIncidentStart sets and saves when the incident a is about started, or
+// clears it if start is zero.
+func (e *escalation) setIncidentStart(a *target.Alert, start time.Time) {
+	if start.Equal(e.incidentStart) {
+		return
+	}
+	e.incidentStart = start
+
+	var err error
+	if start.IsZero() {
+		err = e.DB.DeleteEscalationIncident(a.SubprobeID, e.id)
+	} else {
+		err = e.DB.SaveEscalationIncident(db.EscalationIncident{
+			TriggerID:     e.id,
+			SubprobeID:    a.SubprobeID,
+			IncidentStart: start,
+		})
+	}
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"monitor":  a.MonitorID,
+			"subprobe": a.SubprobeName,
+			"trigger":  e.id,
+		}).Error("Could not save escalation incident start.")
+	}
+}
+
+// due returns the steps whose delay is up after elapsed.
+func (e *escalation) due(elapsed time.Duration) []*escalationStep {
+	var due []*escalationStep
+	for _, step := range e.steps {
+		if step.delay <= elapsed {
+			due = append(due, step)
+		}
+	}
+	return due
+}
+
+// reached returns the steps notified since the subprobe reached the policy's
+// level.
+func (e *escalation) reached() []*escalationStep {
+	if e.incidentStart.IsZero() {
+		return nil
+	}
+
+	var reached []*escalationStep
+	for _, step := range e.steps {
+		if !step.lastAlert.Before(e.incidentStart) {
+			reached = append(reached, step)
+		}
+	}
+	return reached
+}
+
+// send sends a to the given steps, grouped by target type, with the rest of
+// the steps of each type as inactive.
+func (e *escalation) send(a *target.Alert, steps []*escalationStep) {
+	if len(steps) == 0 {
+		return
+	}
+
+	sending := make(map[db.TriggerID]bool)
+	toAlert := make(map[db.TargetType][]*trigger)
+	for _, step := range steps {
+		sending[step.id] = true
+		targetType := step.target.Type().ID()
+		toAlert[targetType] = append(toAlert[targetType], step.trigger)
+	}
+
+	inactive := make(map[db.TargetType][]*trigger)
+	for _, step := range e.steps {
+		if !sending[step.id] {
+			targetType := step.target.Type().ID()
+			inactive[targetType] = append(inactive[targetType], step.trigger)
+		}
+	}
+
+	for targetType, triggers := range toAlert {
+		send(a, triggers, inactive[targetType])
+	}
+}
@@ -0,0 +1,62 @@
+package daemon
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+)
+
+// escalatingMonitor returns a monitor whose subprobes escalate through policy
+// trigger 10 to steps 11, right away, and 12, after 10 minutes.
+func escalatingMonitor() *monitor {
+	return &monitor{triggers: []monitorTrigger{{
+		subprobes:       regexp.MustCompile(".*"),
+		triggerTemplate: &triggerTemplate{id: 10, level: state.Error, triggerOnExit: true},
+		steps: []*escalationStepTemplate{
+			{delay: 0, triggerTemplate: &triggerTemplate{id: 11}},
+			{delay: 10 * time.Minute, triggerTemplate: &triggerTemplate{id: 12}},
+		},
+	}}}
+}
+
+func reachedIDs(e *escalation) []db.TriggerID {
+	var ids []db.TriggerID
+	for _, step := range e.reached() {
+		ids = append(ids, step.id)
+	}
+	return ids
+}
+
+func TestEscalationIncidentRestored(t *testing.T) {
+	status := db.SubprobeStatus{SubprobeID: 1, Recorded: testStart.Add(5 * time.Minute), State: state.Error}
+	lastAlerts := map[db.TriggerID]time.Time{11: testStart.Add(time.Minute)}
+	incidentStarts := map[db.TriggerID]time.Time{10: testStart}
+
+	s := newSubprobe("a.b.c", status, escalatingMonitor(), lastAlerts, incidentStarts, nil)
+	if len(s.escalations) != 1 {
+		t.Fatalf("Expected one escalation, got %d\n", len(s.escalations))
+	}
+	e := s.escalations[0]
+	if !e.incidentStart.Equal(testStart) {
+		t.Errorf("Expected the incident start to be restored, got %s\n", e.incidentStart)
+	}
+
+	// The first step heard about the incident, so it hears about the
+	// recovery too.
+	if ids := reachedIDs(e); len(ids) != 1 || ids[0] != 11 {
+		t.Errorf("Expected step 11 to have been reached, got %v\n", ids)
+	}
+}
+
+func TestEscalationWithoutIncident(t *testing.T) {
+	status := db.SubprobeStatus{SubprobeID: 1, Recorded: testStart.Add(5 * time.Minute), State: state.Error}
+	lastAlerts := map[db.TriggerID]time.Time{11: testStart.Add(time.Minute)}
+
+	s := newSubprobe("a.b.c", status, escalatingMonitor(), lastAlerts, nil, nil)
+	if ids := reachedIDs(s.escalations[0]); len(ids) != 0 {
+		t.Errorf("Expected no steps reached without an incident, got %v\n", ids)
+	}
+}
@@ -0,0 +1,95 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/target"
+)
+
+// alertGroup holds the alerts a trigger that groups alerts is waiting to send
+// for a monitor's subprobes, along with each subprobe's instance of the
+// trigger.
+type alertGroup struct {
+	flushAt time.Time
+
+	// subprobes is the order subprobes first alerted in.
+	subprobes []db.SubprobeID
+	alerts    map[db.SubprobeID]*target.Alert
+	triggers  map[db.SubprobeID]*trigger
+}
+
+// group holds a for trigger t until t's group window, started by the first
+// alert held for it, ends. A later alert for the same subprobe replaces the
+// earlier one but keeps its old state, so the group shows where the subprobe
+// started and where it ended up.
+func (m *monitor) group(a *target.Alert, t *trigger) {
+	g := m.groups[t.id]
+	if g == nil {
+		g = &alertGroup{
+			flushAt:  time.Now().Add(t.groupWindow),
+			alerts:   make(map[db.SubprobeID]*target.Alert),
+			triggers: make(map[db.SubprobeID]*trigger),
+		}
+		m.groups[t.id] = g
+	}
+
+	if held, ok := g.alerts[a.SubprobeID]; ok {
+		merged := *a
+		merged.OldState = held.OldState
+		merged.Flapping = held.Flapping || a.Flapping
+		a = &merged
+	} else {
+		g.subprobes = append(g.subprobes, a.SubprobeID)
+	}
+	g.alerts[a.SubprobeID] = a
+	g.triggers[a.SubprobeID] = t
+}
+
+// nextGroupFlush returns when the next of m's groups is due to be sent, if
+// any are waiting.
+func (m *monitor) nextGroupFlush() (time.Time, bool) {
+	var next time.Time
+	for _, g := range m.groups {
+		if next.IsZero() || g.flushAt.Before(next) {
+			next = g.flushAt
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// flushGroups sends the groups that are due as of now. A zero now sends all of
+// them.
+func (m *monitor) flushGroups(now time.Time) {
+	for id, g := range m.groups {
+		if !now.IsZero() && now.Before(g.flushAt) {
+			continue
+		}
+		delete(m.groups, id)
+		g.send()
+	}
+}
+
+// send sends the group's alerts. A lone alert is sent as is; otherwise they go
+// out as one alert, and every subprobe's trigger counts as having alerted.
+func (g *alertGroup) send() {
+	first := g.subprobes[0]
+	if len(g.subprobes) == 1 {
+		send(g.alerts[first], []*trigger{g.triggers[first]}, nil)
+		return
+	}
+
+	grouped := *g.alerts[first]
+	grouped.Flapping = false
+	grouped.Grouped = make([]*target.Alert, 0, len(g.subprobes))
+	for _, id := range g.subprobes {
+		grouped.Grouped = append(grouped.Grouped, g.alerts[id])
+	}
+
+	send(&grouped, []*trigger{g.triggers[first]}, nil)
+
+	now := time.Now()
+	for _, id := range g.subprobes[1:] {
+		recordAlerted(g.alerts[id], []*trigger{g.triggers[id]}, now)
+	}
+}
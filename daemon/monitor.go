@@ -1,8 +1,10 @@
 package daemon
 
 import (
+	"fmt"
 	"regexp"
 	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/juju/errors"
@@ -10,7 +12,9 @@ import (
 	"github.com/yext/revere/db"
 	"github.com/yext/revere/env"
 	"github.com/yext/revere/probe"
+	"github.com/yext/revere/resource"
 	"github.com/yext/revere/state"
+	"github.com/yext/revere/target"
 )
 
 type monitor struct {
@@ -20,11 +24,37 @@ type monitor struct {
 	response    string
 	version     int32
 
+	// confirmReadings and confirmPeriod are how many consecutive readings
+	// and how long a subprobe must read worse than its state before moving
+	// to the worse state.
+	confirmReadings int
+	confirmPeriod   time.Duration
+
+	// flapChanges and flapWindow are how many state changes within how
+	// long mark a subprobe as flapping.
+	flapChanges int
+	flapWindow  time.Duration
+
 	probe    probe.Probe
 	triggers []monitorTrigger
 
+	// resource is what the probe reads from, if anything. While it's down,
+	// alerts from checks that couldn't reach it are suppressed.
+	resource      db.ResourceID
+	resourceLabel string
+	outages       *resourceOutages
+	inOutage      bool
+
+	// suppressedBy is the parent monitor whose state suppresses alerts from
+	// the monitor's current readings, if any.
+	suppressedBy db.MonitorID
+
 	subprobes map[string]*subprobe
 
+	// groups holds the alerts waiting to be sent together by triggers that
+	// group alerts.
+	groups map[db.TriggerID]*alertGroup
+
 	readingsSource chan []probe.Reading
 	stopper        sync.Once
 	stopped        chan struct{}
@@ -35,9 +65,12 @@ type monitor struct {
 type monitorTrigger struct {
 	subprobes *regexp.Regexp
 	*triggerTemplate
+
+	// steps are set if the trigger targets an escalation policy.
+	steps []*escalationStepTemplate
 }
 
-func newMonitor(id db.MonitorID, env *env.Env) (*monitor, error) {
+func newMonitor(id db.MonitorID, outages *resourceOutages, env *env.Env) (*monitor, error) {
 	tx, err := env.DB.Beginx()
 	if err != nil {
 		return nil, errors.Mask(err)
@@ -73,7 +106,7 @@ func newMonitor(id db.MonitorID, env *env.Env) (*monitor, error) {
 		[]monitorTrigger, 0, len(dbMonitorTriggers)+len(dbLabelTriggers))
 	for _, dbMonitorTrigger := range dbMonitorTriggers {
 		monitorTrigger, err := newMonitorTrigger(
-			dbMonitorTrigger.Subprobes, dbMonitorTrigger.Trigger, env)
+			tx, dbMonitorTrigger.Subprobes, dbMonitorTrigger.Trigger, env)
 		if err != nil {
 			log.WithError(err).WithFields(log.Fields{
 				"monitor": id,
@@ -85,7 +118,7 @@ func newMonitor(id db.MonitorID, env *env.Env) (*monitor, error) {
 	}
 	for _, dbLabelTrigger := range dbLabelTriggers {
 		monitorTrigger, err := newMonitorTrigger(
-			dbLabelTrigger.Subprobes, dbLabelTrigger.Trigger, env)
+			tx, dbLabelTrigger.Subprobes, dbLabelTrigger.Trigger, env)
 		if err != nil {
 			log.WithError(err).WithFields(log.Fields{
 				"monitor": id,
@@ -98,17 +131,35 @@ func newMonitor(id db.MonitorID, env *env.Env) (*monitor, error) {
 	}
 
 	monitor := &monitor{
-		id:             id,
-		name:           dbMonitor.Name,
-		description:    dbMonitor.Description,
-		response:       dbMonitor.Response,
-		version:        dbMonitor.Version,
-		probe:          probe,
-		triggers:       monitorTriggers,
-		subprobes:      make(map[string]*subprobe),
-		readingsSource: readingsChan,
-		stopped:        make(chan struct{}),
-		Env:            env,
+		id:              id,
+		name:            dbMonitor.Name,
+		description:     dbMonitor.Description,
+		response:        dbMonitor.Response,
+		version:         dbMonitor.Version,
+		confirmReadings: int(dbMonitor.ConfirmReadings),
+		confirmPeriod:   time.Duration(dbMonitor.ConfirmMilli) * time.Millisecond,
+		flapChanges:     int(dbMonitor.FlapChanges),
+		flapWindow:      time.Duration(dbMonitor.FlapMilli) * time.Millisecond,
+		probe:           probe,
+		triggers:        monitorTriggers,
+		resource:        probeResource(probe),
+		outages:         outages,
+		subprobes:       make(map[string]*subprobe),
+		groups:          make(map[db.TriggerID]*alertGroup),
+		readingsSource:  readingsChan,
+		stopped:         make(chan struct{}),
+		Env:             env,
+	}
+
+	if monitor.resource != 0 {
+		monitor.resourceLabel, err = resource.LoadLabel(tx, monitor.resource)
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"monitor":  id,
+				"resource": monitor.resource,
+			}).Error("Could not load resource. Outage notices will show its ID.")
+			monitor.resourceLabel = fmt.Sprintf("resource %d", monitor.resource)
+		}
 	}
 
 	dbSubprobeStatuses, err := tx.LoadSubprobeStatusesForMonitor(id)
@@ -121,14 +172,58 @@ func newMonitor(id db.MonitorID, env *env.Env) (*monitor, error) {
 		return monitor, nil
 	}
 
+	lastAlerts, err := tx.LoadTriggerLastAlertsForMonitor(id)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"monitor": id,
+		}).Error("Could not load trigger last alert times. Repeat alerts might be sent early.")
+	}
+
+	incidentStarts, err := tx.LoadEscalationIncidentsForMonitor(id)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"monitor": id,
+		}).Error("Could not load escalation incidents. Escalation steps might not hear of recoveries.")
+	}
+
+	var recentReadings map[db.SubprobeID][]*db.Reading
+	if monitor.detectsFlapping() {
+		recentReadings, err = tx.LoadReadingsForMonitorSince(id, time.Now().Add(-monitor.flapWindow))
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"monitor": id,
+			}).Error("Could not load recent readings. Flapping subprobes might stop flapping early.")
+		}
+	}
+
 	for name, status := range dbSubprobeStatuses {
-		monitor.subprobes[name] = newSubprobe(name, status, monitor)
+		monitor.subprobes[name] = newSubprobe(name, status, monitor,
+			lastAlerts[status.SubprobeID], incidentStarts[status.SubprobeID], recentReadings[status.SubprobeID])
 	}
 
 	return monitor, nil
 }
 
-func newMonitorTrigger(subprobes string, dbTrigger *db.Trigger, env *env.Env) (*monitorTrigger, error) {
+// probeResource returns the resource p reads from, if any.
+func probeResource(p probe.Probe) db.ResourceID {
+	if r, ok := p.(probe.ResourceReader); ok {
+		return r.Resource()
+	}
+	return 0
+}
+
+// confirms returns whether worse states must be confirmed before subprobes of
+// m move to them.
+func (m *monitor) confirms() bool {
+	return m.confirmReadings > 1 || m.confirmPeriod > 0
+}
+
+// detectsFlapping returns whether subprobes of m can be marked as flapping.
+func (m *monitor) detectsFlapping() bool {
+	return m.flapChanges > 0 && m.flapWindow > 0
+}
+
+func newMonitorTrigger(tx *db.Tx, subprobes string, dbTrigger *db.Trigger, env *env.Env) (*monitorTrigger, error) {
 	subprobesRegexp, err := regexp.Compile(subprobes)
 	if err != nil {
 		return nil, errors.Maskf(err, "compile regexp")
@@ -139,32 +234,66 @@ func newMonitorTrigger(subprobes string, dbTrigger *db.Trigger, env *env.Env) (*
 		return nil, errors.Maskf(err, "make trigger")
 	}
 
+	var steps []*escalationStepTemplate
+	if policy, ok := triggerTemplate.target.(*target.EscalationPolicy); ok {
+		steps, err = newEscalationStepTemplates(tx, policy, env)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	return &monitorTrigger{
 		subprobes:       subprobesRegexp,
 		triggerTemplate: triggerTemplate,
+		steps:           steps,
 	}, nil
 }
 
 func (m *monitor) start() {
+	if m.resource != 0 {
+		m.outages.add(m.resource, m.resourceLabel, m.id)
+	}
 	m.probe.Start()
 	go func() {
 		defer close(m.stopped)
 		for {
-			r, ok := <-m.readingsSource
-			if !ok {
-				return
+			var timer *time.Timer
+			var flush <-chan time.Time
+			if at, ok := m.nextGroupFlush(); ok {
+				timer = time.NewTimer(at.Sub(time.Now()))
+				flush = timer.C
+			}
+
+			select {
+			case r, ok := <-m.readingsSource:
+				if timer != nil {
+					timer.Stop()
+				}
+				if !ok {
+					// Send what's held rather than lose it.
+					m.flushGroups(time.Time{})
+					return
+				}
+				m.process(r)
+			case now := <-flush:
+				m.flushGroups(now)
 			}
-			m.process(r)
 		}
 	}()
 }
 
 func (m *monitor) process(readings []probe.Reading) {
 	m.logReadings(readings)
+	m.checkOutage(readings)
 
 	var silences []silence
+	var acks map[db.SubprobeID]db.SubprobeAck
 	if m.shouldLoadSilences(readings) {
 		silences = m.loadActiveSilences()
+		acks = m.loadAcks()
+		m.suppressedBy = m.loadSuppressingParent()
+	} else {
+		m.suppressedBy = 0
 	}
 
 	for _, r := range readings {
@@ -193,7 +322,33 @@ func (m *monitor) process(readings []probe.Reading) {
 			}
 		}
 
-		subprobe.process(r, isSilenced)
+		var ack *db.SubprobeAck
+		if a, ok := acks[subprobe.id]; ok {
+			ack = &a
+		}
+
+		subprobe.process(r, isSilenced, ack)
+	}
+}
+
+// checkOutage reports to the daemon whether readings show the monitor's probe
+// couldn't reach its resource, noting whether alerts should be suppressed
+// because the resource is down.
+func (m *monitor) checkOutage(readings []probe.Reading) {
+	if m.resource == 0 {
+		return
+	}
+
+	unreachable := probe.Unreachable(readings)
+	var details string
+	if unreachable {
+		details = readings[0].Details.Text()
+	}
+
+	var notice *outageNotice
+	m.inOutage, notice = m.outages.report(m.resource, m.id, unreachable, details, time.Now())
+	if notice != nil {
+		notice.send(m.DB)
 	}
 }
 
@@ -216,7 +371,7 @@ func (m *monitor) logReadings(readings []probe.Reading) {
 
 // shouldLoadSilences returns whether processing the given set of readings
 // against the current state of this monitor's subprobes might require checking
-// for silences.
+// for silences and acknowledgements.
 //
 // In the common case of all subprobes currently normal and all incoming
 // readings reading normal, no alerts will need to be sent, so it doesn't matter
@@ -264,10 +419,55 @@ func (m *monitor) loadActiveSilences() []silence {
 	return silences
 }
 
+// loadSuppressingParent returns the first monitor m depends on that has a
+// subprobe at or above the level of the dependency, or 0 if there is none.
+func (m *monitor) loadSuppressingParent() db.MonitorID {
+	active, err := m.DB.LoadActiveDependenciesForMonitor(m.id)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"monitor": m.id,
+		}).Error("Could not load active dependencies. Proceeding without them.")
+		return 0
+	}
+
+	patterns := make(map[string]*regexp.Regexp)
+	for _, a := range active {
+		subprobes, ok := patterns[a.Subprobes]
+		if !ok {
+			subprobes, err = regexp.Compile(a.Subprobes)
+			if err != nil {
+				log.WithError(err).WithFields(log.Fields{
+					"monitor": m.id,
+					"parent":  a.ParentID,
+				}).Error("Could not compile dependency subprobes. Ignoring.")
+			}
+			patterns[a.Subprobes] = subprobes
+		}
+		if subprobes != nil && subprobes.MatchString(a.SubprobeName) {
+			return a.ParentID
+		}
+	}
+	return 0
+}
+
 func (m *monitor) stop() {
 	m.stopper.Do(func() {
 		m.probe.Stop()
 		close(m.readingsSource)
 		<-m.stopped
+		if m.resource != 0 {
+			m.outages.remove(m.resource, m.id)
+		}
 	})
 }
+
+func (m *monitor) loadAcks() map[db.SubprobeID]db.SubprobeAck {
+	acks, err := m.DB.LoadSubprobeAcksForMonitor(m.id)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"monitor": m.id,
+		}).Error("Could not load acknowledgements. Proceeding without them.")
+		return nil
+	}
+	return acks
+}
@@ -0,0 +1,171 @@
+package daemon
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/target"
+)
+
+// minOutageMonitors is how many monitors must be unable to reach a resource
+// for it to count as down, so one broken monitor isn't taken for an outage.
+const minOutageMonitors = 2
+
+const noticeTimeFormat = "Mon Jan 2 2006 15:04:05 MST"
+
+// resourceOutages tracks which monitors can't reach the resources they read
+// from. When enough of a resource's monitors can't, the resource is down: one
+// notice is emailed about it, and alerts from its monitors that can't reach it
+// are suppressed until it's back.
+type resourceOutages struct {
+	sync.Mutex
+
+	// percent is the percentage of a resource's monitors that must be
+	// unable to reach it for it to be down. 0 turns outage detection off.
+	percent int
+	email   string
+
+	resources map[db.ResourceID]*resourceOutage
+}
+
+type resourceOutage struct {
+	label string
+
+	// unreachable holds whether each monitor reading from the resource
+	// couldn't reach it on its last check.
+	unreachable map[db.MonitorID]bool
+
+	down      bool
+	downSince time.Time
+}
+
+// outageNotice is an email to send about a resource going down or coming
+// back.
+type outageNotice struct {
+	to      string
+	subject string
+	text    string
+}
+
+func newResourceOutages() *resourceOutages {
+	return &resourceOutages{
+		resources: make(map[db.ResourceID]*resourceOutage),
+	}
+}
+
+// configure sets when resources count as down and who is told about it.
+func (o *resourceOutages) configure(percent int, email string) {
+	o.Lock()
+	defer o.Unlock()
+
+	o.percent = percent
+	o.email = email
+}
+
+// add starts tracking monitor m's checks of resource r, which notices call
+// label.
+func (o *resourceOutages) add(r db.ResourceID, label string, m db.MonitorID) {
+	o.Lock()
+	defer o.Unlock()
+
+	outage := o.resources[r]
+	if outage == nil {
+		outage = &resourceOutage{unreachable: make(map[db.MonitorID]bool)}
+		o.resources[r] = outage
+	}
+	outage.label = label
+	outage.unreachable[m] = false
+}
+
+// remove stops tracking monitor m's checks of resource r.
+func (o *resourceOutages) remove(r db.ResourceID, m db.MonitorID) {
+	o.Lock()
+	defer o.Unlock()
+
+	outage := o.resources[r]
+	if outage == nil {
+		return
+	}
+	delete(outage.unreachable, m)
+	if len(outage.unreachable) == 0 {
+		delete(o.resources, r)
+	}
+}
+
+// report records whether monitor m could reach resource r as of now. details
+// describes why it couldn't. It returns whether m's alerts should be
+// suppressed because r is down, and the notice to send if r just went down or
+// came back.
+func (o *resourceOutages) report(r db.ResourceID, m db.MonitorID, unreachable bool, details string, now time.Time) (bool, *outageNotice) {
+	o.Lock()
+	defer o.Unlock()
+
+	outage := o.resources[r]
+	if outage == nil {
+		return false, nil
+	}
+	outage.unreachable[m] = unreachable
+
+	var notice *outageNotice
+	down := o.isDown(outage)
+	switch {
+	case down && !outage.down:
+		outage.down = true
+		outage.downSince = now
+		notice = &outageNotice{
+			to:      o.email,
+			subject: fmt.Sprintf("%s is unreachable", outage.label),
+			text: fmt.Sprintf(
+				"%d of the %d monitors reading from %s can't query it as of %s.\n"+
+					"Their alerts are suppressed until it's back.\n\n%s",
+				outage.count(), len(outage.unreachable), outage.label,
+				now.UTC().Format(noticeTimeFormat), details),
+		}
+	case !down && outage.down:
+		outage.down = false
+		if o.email == "" {
+			break
+		}
+		notice = &outageNotice{
+			to:      o.email,
+			subject: fmt.Sprintf("%s is reachable again", outage.label),
+			text: fmt.Sprintf(
+				"Monitors reading from %s can query it again as of %s. It was down since %s.",
+				outage.label, now.UTC().Format(noticeTimeFormat),
+				outage.downSince.UTC().Format(noticeTimeFormat)),
+		}
+	}
+
+	return outage.down && unreachable, notice
+}
+
+func (o *resourceOutages) isDown(outage *resourceOutage) bool {
+	if o.percent <= 0 || o.email == "" {
+		return false
+	}
+	n := outage.count()
+	return n >= minOutageMonitors && n*100 >= o.percent*len(outage.unreachable)
+}
+
+// count returns how many monitors couldn't reach the resource.
+func (outage *resourceOutage) count() int {
+	n := 0
+	for _, unreachable := range outage.unreachable {
+		if unreachable {
+			n++
+		}
+	}
+	return n
+}
+
+// send emails the notice.
+func (n *outageNotice) send(Db *db.DB) {
+	if err := target.EmailNotice(Db, []string{n.to}, n.subject, n.text); err != nil {
+		log.WithError(err).WithField("subject", n.subject).
+			Error("Could not send resource outage notice.")
+	}
+}
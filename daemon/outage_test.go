@@ -0,0 +1,89 @@
+package daemon
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yext/revere/db"
+)
+
+const testResource db.ResourceID = 7
+
+func newTestOutages(monitors int) *resourceOutages {
+	o := newResourceOutages()
+	o.configure(50, "ops@example.com")
+	for m := 1; m <= monitors; m++ {
+		o.add(testResource, "Graphite graphite.example.com", db.MonitorID(m))
+	}
+	return o
+}
+
+func TestOutageThresholdCrossedThenCleared(t *testing.T) {
+	o := newTestOutages(4)
+
+	// One monitor failing could just be the monitor.
+	suppressed, notice := o.report(testResource, 1, true, "timeout", testStart)
+	if suppressed || notice != nil {
+		t.Fatalf("Expected one unreachable monitor not to be an outage\n")
+	}
+
+	suppressed, notice = o.report(testResource, 2, true, "timeout", testStart.Add(time.Minute))
+	if !suppressed {
+		t.Errorf("Expected alerts suppressed once half the monitors can't reach the resource\n")
+	}
+	if notice == nil {
+		t.Fatalf("Expected a notice that the resource is down\n")
+	}
+	if notice.to != "ops@example.com" || notice.subject != "Graphite graphite.example.com is unreachable" {
+		t.Errorf("Unexpected down notice: %+v\n", notice)
+	}
+	if !strings.Contains(notice.text, "2 of the 4 monitors") || !strings.Contains(notice.text, "timeout") {
+		t.Errorf("Expected the down notice to describe the outage, got %q\n", notice.text)
+	}
+
+	// Only one notice per outage, and monitors that can reach the
+	// resource still alert.
+	if suppressed, notice = o.report(testResource, 1, true, "timeout", testStart.Add(2*time.Minute)); !suppressed || notice != nil {
+		t.Errorf("Expected suppression without another notice, got %t, %+v\n", suppressed, notice)
+	}
+	if suppressed, notice = o.report(testResource, 3, false, "", testStart.Add(2*time.Minute)); suppressed || notice != nil {
+		t.Errorf("Expected a monitor reaching the resource not to be suppressed, got %t, %+v\n", suppressed, notice)
+	}
+
+	suppressed, notice = o.report(testResource, 2, false, "", testStart.Add(5*time.Minute))
+	if suppressed {
+		t.Errorf("Expected no suppression once the resource is back\n")
+	}
+	if notice == nil || notice.subject != "Graphite graphite.example.com is reachable again" {
+		t.Fatalf("Expected a notice that the resource is back, got %+v\n", notice)
+	}
+	if suppressed, _ = o.report(testResource, 1, true, "timeout", testStart.Add(6*time.Minute)); suppressed {
+		t.Errorf("Expected one unreachable monitor not to be suppressed after the outage\n")
+	}
+}
+
+func TestOutageNeedsPercentOfMonitors(t *testing.T) {
+	o := newTestOutages(5)
+
+	o.report(testResource, 1, true, "timeout", testStart)
+	if suppressed, notice := o.report(testResource, 2, true, "timeout", testStart); suppressed || notice != nil {
+		t.Errorf("Expected 2 of 5 monitors not to be an outage at 50%%\n")
+	}
+	if suppressed, notice := o.report(testResource, 3, true, "timeout", testStart); !suppressed || notice == nil {
+		t.Errorf("Expected 3 of 5 monitors to be an outage at 50%%\n")
+	}
+}
+
+func TestOutageDetectionOff(t *testing.T) {
+	o := newTestOutages(2)
+	o.configure(0, "")
+
+	o.report(testResource, 1, true, "timeout", testStart)
+	if suppressed, notice := o.report(testResource, 2, true, "timeout", testStart); suppressed || notice != nil {
+		t.Errorf("Expected no outages with detection off\n")
+	}
+	if suppressed, notice := o.report(testResource+1, 1, true, "timeout", testStart); suppressed || notice != nil {
+		t.Errorf("Expected no outages for untracked resources\n")
+	}
+}
@@ -0,0 +1,247 @@
+package daemon
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/target"
+)
+
+const (
+	// outboxPollPeriod is how often the outbox is checked for alerts due
+	// for another delivery attempt.
+	outboxPollPeriod = 10 * time.Second
+
+	// outboxBatchSize limits how many alerts are attempted per poll.
+	outboxBatchSize = 100
+
+	// outboxBaseBackoff is the wait after the first failed attempt. Each
+	// further failure doubles it, up to outboxMaxBackoff.
+	outboxBaseBackoff = 30 * time.Second
+	outboxMaxBackoff  = 30 * time.Minute
+
+	// outboxMaxAge is how long after an alert was queued delivery is given
+	// up on.
+	outboxMaxAge = 24 * time.Hour
+)
+
+// newOutboxAlert makes the outbox entry for sending a to the targets of
+// triggerIDs. The first attempt is made right away by whoever queues it, so
+// the entry is not due until that attempt would have been retried.
+func newOutboxAlert(a *target.Alert, targetType db.TargetType, triggerIDs []db.TriggerID, inactiveIDs []db.TriggerID, now time.Time) (*db.OutboxAlert, error) {
+	alert, err := target.EncodeAlert(a)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ids, err := encodeTriggerIDs(triggerIDs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	inactive, err := encodeTriggerIDs(inactiveIDs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return &db.OutboxAlert{
+		MonitorID:          a.MonitorID,
+		SubprobeID:         a.SubprobeID,
+		TargetType:         targetType,
+		Alert:              alert,
+		TriggerIDs:         ids,
+		InactiveTriggerIDs: inactive,
+		Created:            now,
+		NextAttempt:        now.Add(outboxBaseBackoff),
+	}, nil
+}
+
+func encodeTriggerIDs(ids []db.TriggerID) (types.JSONText, error) {
+	if ids == nil {
+		ids = []db.TriggerID{}
+	}
+	encoded, err := json.Marshal(ids)
+	return types.JSONText(encoded), err
+}
+
+func decodeTriggerIDs(encoded types.JSONText) ([]db.TriggerID, error) {
+	var ids []db.TriggerID
+	err := encoded.Unmarshal(&ids)
+	return ids, err
+}
+
+// outboxBackoff returns how long to wait before the next attempt after the
+// given number of failed attempts.
+func outboxBackoff(attempts int32) time.Duration {
+	backoff := outboxBaseBackoff
+	for i := int32(1); i < attempts && backoff < outboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > outboxMaxBackoff {
+		backoff = outboxMaxBackoff
+	}
+	return backoff
+}
+
+// outboxStore keeps the alerts waiting for delivery. *db.DB is one.
+type outboxStore interface {
+	UpdateOutboxAlert(a *db.OutboxAlert) error
+	DeleteOutboxAlert(id db.OutboxID) error
+}
+
+// recordDelivery saves the outcome of an attempt to deliver o. Delivered
+// alerts leave the outbox. Otherwise, only the triggers that failed are
+// retried, until the alert is too old and is marked as failed.
+func recordDelivery(Db outboxStore, o *db.OutboxAlert, errs []target.ErrorAndTriggerIDs, now time.Time) {
+	if len(errs) == 0 {
+		if err := Db.DeleteOutboxAlert(o.OutboxID); err != nil {
+			log.WithError(err).WithField("outbox", o.OutboxID).
+				Error("Could not remove delivered alert from outbox; it may be sent again.")
+		}
+		return
+	}
+
+	var failedIDs []db.TriggerID
+	var messages []string
+	for _, errAndIDs := range errs {
+		failedIDs = append(failedIDs, errAndIDs.IDs...)
+		messages = append(messages, errAndIDs.Err.Error())
+	}
+
+	ids, err := encodeTriggerIDs(failedIDs)
+	if err != nil {
+		log.WithError(err).WithField("outbox", o.OutboxID).
+			Error("Could not encode failed triggers.")
+		return
+	}
+
+	o.TriggerIDs = ids
+	o.Attempts++
+	o.LastError = strings.Join(messages, "; ")
+
+	fields := log.Fields{
+		"outbox":     o.OutboxID,
+		"monitor":    o.MonitorID,
+		"subprobe":   o.SubprobeID,
+		"targetType": o.TargetType,
+		"triggers":   failedIDs,
+		"attempts":   o.Attempts,
+	}
+	if now.Sub(o.Created) >= outboxMaxAge {
+		o.Failed = &now
+		log.WithFields(fields).WithField("error", o.LastError).
+			Error("Alert delivery failed permanently.")
+	} else {
+		o.NextAttempt = now.Add(outboxBackoff(o.Attempts))
+		log.WithFields(fields).WithField("error", o.LastError).
+			Warn("Alert delivery failed; will retry.")
+	}
+
+	if err := Db.UpdateOutboxAlert(o); err != nil {
+		log.WithError(err).WithFields(fields).Error("Could not update alert in outbox.")
+	}
+}
+
+func (d *Daemon) runOutbox() {
+	defer close(d.outboxStopped)
+
+	t := time.NewTicker(outboxPollPeriod)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			d.deliverOutbox()
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+func (d *Daemon) deliverOutbox() {
+	alerts, err := d.DB.LoadDueOutboxAlerts(time.Now(), outboxBatchSize)
+	if err != nil {
+		log.WithError(err).Error("Could not load alerts due for delivery.")
+		return
+	}
+
+	for _, o := range alerts {
+		select {
+		case <-d.stop:
+			return
+		default:
+		}
+		d.deliver(o)
+	}
+}
+
+// deliver retries delivery of an alert from the outbox to the targets of the
+// triggers it failed for, as they are currently configured. Triggers deleted
+// since are skipped.
+func (d *Daemon) deliver(o *db.OutboxAlert) {
+	a, err := target.DecodeAlert(o.Alert)
+	if err != nil {
+		// It will never decode, so give up on it right away.
+		recordDelivery(d.DB, o, []target.ErrorAndTriggerIDs{{
+			Err: errors.Maskf(err, "decode alert"),
+		}}, o.Created.Add(outboxMaxAge))
+		return
+	}
+
+	triggerIDs, err := decodeTriggerIDs(o.TriggerIDs)
+	if err != nil {
+		log.WithError(err).WithField("outbox", o.OutboxID).Error("Could not decode triggers.")
+		return
+	}
+	inactiveIDs, err := decodeTriggerIDs(o.InactiveTriggerIDs)
+	if err != nil {
+		log.WithError(err).WithField("outbox", o.OutboxID).Error("Could not decode triggers.")
+		return
+	}
+
+	triggers, err := d.DB.LoadTriggers(append(triggerIDs, inactiveIDs...))
+	if err != nil {
+		log.WithError(err).WithField("outbox", o.OutboxID).Error("Could not load triggers.")
+		return
+	}
+
+	var errs []target.ErrorAndTriggerIDs
+	var targetType target.Type
+	toAlert := make(map[db.TriggerID]target.Target)
+	for _, id := range triggerIDs {
+		t, found := triggers[id]
+		if !found || t.TargetType != o.TargetType {
+			continue
+		}
+		tgt, err := target.NewForTrigger(t)
+		if err != nil {
+			errs = append(errs, target.ErrorAndTriggerIDs{
+				Err: errors.Maskf(err, "make target"),
+				IDs: []db.TriggerID{id},
+			})
+			continue
+		}
+		toAlert[id] = tgt
+		targetType = tgt.Type()
+	}
+
+	var inactive []target.Target
+	for _, id := range inactiveIDs {
+		t, found := triggers[id]
+		if !found || t.TargetType != o.TargetType {
+			continue
+		}
+		if tgt, err := target.NewForTrigger(t); err == nil {
+			inactive = append(inactive, tgt)
+		}
+	}
+
+	if len(toAlert) > 0 {
+		errs = append(errs, targetType.Alert(d.DB, a, toAlert, inactive)...)
+	}
+	recordDelivery(d.DB, o, errs, time.Now())
+}
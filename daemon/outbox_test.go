@@ -0,0 +1,134 @@
+package daemon
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/target"
+)
+
+type fakeOutbox struct {
+	updated []db.OutboxAlert
+	deleted []db.OutboxID
+}
+
+func (f *fakeOutbox) UpdateOutboxAlert(a *db.OutboxAlert) error {
+	f.updated = append(f.updated, *a)
+	return nil
+}
+
+func (f *fakeOutbox) DeleteOutboxAlert(id db.OutboxID) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func testOutboxAlert(t *testing.T, triggerIDs ...db.TriggerID) *db.OutboxAlert {
+	o, err := newOutboxAlert(target.SampleAlert(), 1, triggerIDs, nil, testStart)
+	if err != nil {
+		t.Fatalf("Unexpected error making outbox alert: %v\n", err)
+	}
+	o.OutboxID = 9
+	return o
+}
+
+func failures(ids ...db.TriggerID) []target.ErrorAndTriggerIDs {
+	var errs []target.ErrorAndTriggerIDs
+	for _, id := range ids {
+		errs = append(errs, target.ErrorAndTriggerIDs{
+			Err: errors.New("connection refused"),
+			IDs: []db.TriggerID{id},
+		})
+	}
+	return errs
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	for _, c := range []struct {
+		attempts int32
+		expected time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{6, 16 * time.Minute},
+		{7, 30 * time.Minute},
+		{100, 30 * time.Minute},
+	} {
+		if backoff := outboxBackoff(c.attempts); backoff != c.expected {
+			t.Errorf("Expected backoff %s after %d attempts, got %s\n", c.expected, c.attempts, backoff)
+		}
+	}
+}
+
+func TestNewOutboxAlertDueAfterFirstBackoff(t *testing.T) {
+	o := testOutboxAlert(t, 1, 2)
+	if !o.NextAttempt.Equal(testStart.Add(outboxBaseBackoff)) {
+		t.Errorf("Expected the first retry after %s, got %s\n", outboxBaseBackoff, o.NextAttempt)
+	}
+	if ids, err := decodeTriggerIDs(o.TriggerIDs); err != nil || !reflect.DeepEqual(ids, []db.TriggerID{1, 2}) {
+		t.Errorf("Expected triggers [1 2], got %v, %v\n", ids, err)
+	}
+}
+
+func TestRecordDeliveryDelivered(t *testing.T) {
+	outbox := &fakeOutbox{}
+	recordDelivery(outbox, testOutboxAlert(t, 1), nil, testStart)
+
+	if !reflect.DeepEqual(outbox.deleted, []db.OutboxID{9}) || len(outbox.updated) != 0 {
+		t.Errorf("Expected the delivered alert to leave the outbox, got %+v\n", outbox)
+	}
+}
+
+func TestRecordDeliveryRetriesFailedTriggers(t *testing.T) {
+	outbox := &fakeOutbox{}
+	o := testOutboxAlert(t, 1, 2, 3)
+
+	now := testStart.Add(outboxBaseBackoff)
+	recordDelivery(outbox, o, failures(2, 3), now)
+	if len(outbox.updated) != 1 || len(outbox.deleted) != 0 {
+		t.Fatalf("Expected the alert to be updated, got %+v\n", outbox)
+	}
+	u := outbox.updated[0]
+	if ids, _ := decodeTriggerIDs(u.TriggerIDs); !reflect.DeepEqual(ids, []db.TriggerID{2, 3}) {
+		t.Errorf("Expected only the failed triggers to be retried, got %v\n", ids)
+	}
+	if u.Attempts != 1 || u.Failed != nil || !u.NextAttempt.Equal(now.Add(30*time.Second)) {
+		t.Errorf("Expected a retry in 30s, got %d attempts, next %s, failed %v\n",
+			u.Attempts, u.NextAttempt, u.Failed)
+	}
+	if u.LastError != "connection refused; connection refused" {
+		t.Errorf("Expected the errors to be recorded, got %q\n", u.LastError)
+	}
+
+	now = u.NextAttempt
+	recordDelivery(outbox, o, failures(3), now)
+	u = outbox.updated[1]
+	if ids, _ := decodeTriggerIDs(u.TriggerIDs); !reflect.DeepEqual(ids, []db.TriggerID{3}) {
+		t.Errorf("Expected only the failed trigger to be retried, got %v\n", ids)
+	}
+	if u.Attempts != 2 || !u.NextAttempt.Equal(now.Add(time.Minute)) {
+		t.Errorf("Expected the backoff to double, got %d attempts, next %s\n", u.Attempts, u.NextAttempt)
+	}
+}
+
+func TestRecordDeliveryFailsPermanently(t *testing.T) {
+	outbox := &fakeOutbox{}
+	o := testOutboxAlert(t, 1)
+
+	recordDelivery(outbox, o, failures(1), testStart.Add(outboxMaxAge-time.Minute))
+	if outbox.updated[0].Failed != nil {
+		t.Fatalf("Expected a retry before the alert is too old\n")
+	}
+
+	now := testStart.Add(outboxMaxAge)
+	recordDelivery(outbox, o, failures(1), now)
+	if f := outbox.updated[1].Failed; f == nil || !f.Equal(now) {
+		t.Errorf("Expected the alert to be marked failed at %s, got %v\n", now, f)
+	}
+	if len(outbox.deleted) != 0 {
+		t.Errorf("Expected the failed alert to be kept\n")
+	}
+}
@@ -23,14 +23,50 @@ type subprobe struct {
 	enteredState time.Time
 	lastNormal   time.Time
 
+	// pendingState is a worse state the subprobe has been reading since
+	// pendingSince, for pendingReadings readings, that is not yet confirmed.
+	// Nothing is pending unless pendingState is worse than state.
+	pendingState    state.State
+	pendingSince    time.Time
+	pendingReadings int
+
+	// stateChanges holds when the subprobe changed state within the
+	// monitor's flap window. While flapping, alerts on state changes are
+	// suppressed; flapStartState is the state it was in before it started,
+	// and flapWorstState the worst state it has been in since.
+	stateChanges   []time.Time
+	flapping       bool
+	flapStartState state.State
+	flapWorstState state.State
+
 	saveNextReading bool
 
 	triggerSets map[db.TargetType]sameTypeTriggerSet
+	escalations []*escalation
 
 	*env.Env
 }
 
-func newSubprobe(name string, status db.SubprobeStatus, monitor *monitor) *subprobe {
+// newSubprobe makes a subprobe that picks up from its saved status, the times
+// its triggers last alerted, when its escalations' incidents started, and its
+// readings within the monitor's flap window.
+func newSubprobe(name string, status db.SubprobeStatus, monitor *monitor, lastAlerts, incidentStarts map[db.TriggerID]time.Time, recentReadings []*db.Reading) *subprobe {
+	var pendingSince time.Time
+	if status.PendingSince != nil {
+		pendingSince = *status.PendingSince
+	}
+
+	// Readings are saved when the state changes, so the recent ones are
+	// the state changes still in the flap window.
+	var stateChanges []time.Time
+	flapWorstState := status.State
+	for _, r := range recentReadings {
+		stateChanges = append(stateChanges, r.Recorded)
+		if r.State > flapWorstState {
+			flapWorstState = r.State
+		}
+	}
+
 	return &subprobe{
 		id:              status.SubprobeID,
 		monitor:         monitor,
@@ -39,8 +75,16 @@ func newSubprobe(name string, status db.SubprobeStatus, monitor *monitor) *subpr
 		state:           status.State,
 		enteredState:    status.EnteredState,
 		lastNormal:      status.LastNormal,
+		pendingSince:    pendingSince,
+		pendingState:    status.PendingState,
+		pendingReadings: int(status.PendingReadings),
+		stateChanges:    stateChanges,
+		flapping:        status.Flapping,
+		flapStartState:  status.State,
+		flapWorstState:  flapWorstState,
 		saveNextReading: false,
-		triggerSets:     newSubprobeTriggerSets(monitor, name),
+		triggerSets:     newSubprobeTriggerSets(monitor, name, lastAlerts),
+		escalations:     newSubprobeEscalations(monitor, name, lastAlerts, incidentStarts),
 		Env:             monitor.Env,
 	}
 }
@@ -62,11 +106,18 @@ func createSubprobe(monitor *monitor, reading probe.Reading) (*subprobe, error)
 		// Make sure the first reading is saved.
 		saveNextReading: true,
 
-		triggerSets: newSubprobeTriggerSets(monitor, reading.Subprobe),
+		triggerSets: newSubprobeTriggerSets(monitor, reading.Subprobe, nil),
+		escalations: newSubprobeEscalations(monitor, reading.Subprobe, nil, nil),
 
 		Env: monitor.Env,
 	}
 
+	if monitor.confirms() {
+		// A new subprobe's first state needs confirming like any other,
+		// which process takes care of.
+		s.state = state.Normal
+	}
+
 	err := s.DB.Tx(func(tx *db.Tx) error {
 		var err error
 
@@ -91,10 +142,15 @@ func createSubprobe(monitor *monitor, reading probe.Reading) (*subprobe, error)
 }
 
 // newSubprobeTriggerSets filters monitor's triggers down to a map appropriate
-// for the triggerSets field of a subprobe with the given name.
-func newSubprobeTriggerSets(monitor *monitor, name string) map[db.TargetType]sameTypeTriggerSet {
+// for the triggerSets field of a subprobe with the given name. lastAlerts holds
+// when the triggers last alerted for the subprobe, if ever. Triggers targeting
+// escalation policies are left to newSubprobeEscalations.
+func newSubprobeTriggerSets(monitor *monitor, name string, lastAlerts map[db.TriggerID]time.Time) map[db.TargetType]sameTypeTriggerSet {
 	triggerSets := make(map[db.TargetType]sameTypeTriggerSet)
 	for _, monitorTrigger := range monitor.triggers {
+		if monitorTrigger.steps != nil {
+			continue
+		}
 		if monitorTrigger.subprobes.MatchString(name) {
 			triggerTemplate := monitorTrigger.triggerTemplate
 			targetType := triggerTemplate.target.Type().ID()
@@ -105,21 +161,94 @@ func newSubprobeTriggerSets(monitor *monitor, name string) map[db.TargetType]sam
 				triggerSets[targetType] = triggerSet
 			}
 
-			triggerSet.add(newTrigger(triggerTemplate, monitor.Env))
+			t := newTrigger(triggerTemplate, monitor.Env)
+			t.lastAlert = lastAlerts[triggerTemplate.id]
+			triggerSet.add(t)
 		}
 	}
 	return triggerSets
 }
 
-func (s *subprobe) process(r probe.Reading, isSilenced bool) {
+// newSubprobeEscalations makes the escalations for the monitor's triggers
+// targeting escalation policies that apply to the subprobe with the given name.
+// incidentStarts holds when the subprobe last reached the level of each
+// trigger, if it's still at or above it.
+func newSubprobeEscalations(monitor *monitor, name string, lastAlerts, incidentStarts map[db.TriggerID]time.Time) []*escalation {
+	var escalations []*escalation
+	for _, monitorTrigger := range monitor.triggers {
+		if monitorTrigger.steps != nil && monitorTrigger.subprobes.MatchString(name) {
+			escalations = append(escalations, newEscalation(
+				monitorTrigger.triggerTemplate, monitorTrigger.steps, lastAlerts, incidentStarts, monitor.Env))
+		}
+	}
+	return escalations
+}
+
+// process updates the subprobe for reading r and sends any alerts it calls
+// for. ack is the subprobe's acknowledgement, if any.
+func (s *subprobe) process(r probe.Reading, isSilenced bool, ack *db.SubprobeAck) {
 	oldState := s.state
 
+	r = s.confirm(r)
 	s.updateFor(r)
+	startedFlapping, stoppedFlapping := s.updateFlapping(oldState, r)
+	isAcked := s.updateAck(ack)
 
 	if !isSilenced {
 		alert := s.newAlert(oldState, r)
-		for _, triggerSet := range s.triggerSets {
-			triggerSet.alert(alert)
+		switch {
+		case startedFlapping:
+			alert.Flapping = true
+		case stoppedFlapping:
+			alert.OldState = s.flapSettledFrom(r.State)
+		}
+
+		if isAcked && alert.OldState == alert.NewState {
+			if log.GetLevel() >= log.DebugLevel {
+				log.WithFields(log.Fields{
+					"monitor":  s.monitor.id,
+					"subprobe": s.name,
+					"state":    r.State,
+					"recorded": r.Recorded,
+					"ackedBy":  ack.AckedBy,
+				}).Debug("Suppressing repeat alerts for acknowledged subprobe.")
+			}
+		} else if s.monitor.inOutage {
+			if log.GetLevel() >= log.DebugLevel {
+				log.WithFields(log.Fields{
+					"monitor":  s.monitor.id,
+					"subprobe": s.name,
+					"state":    r.State,
+					"recorded": r.Recorded,
+					"resource": s.monitor.resource,
+				}).Debug("Suppressing alerts while resource is down.")
+			}
+		} else if s.suppressedByParent(alert) {
+			if log.GetLevel() >= log.DebugLevel {
+				log.WithFields(log.Fields{
+					"monitor":  s.monitor.id,
+					"subprobe": s.name,
+					"state":    r.State,
+					"recorded": r.Recorded,
+					"parent":   s.monitor.suppressedBy,
+				}).Debug("Suppressing alerts while monitor depended on is down.")
+			}
+		} else if s.flapping && !startedFlapping && alert.OldState != alert.NewState {
+			if log.GetLevel() >= log.DebugLevel {
+				log.WithFields(log.Fields{
+					"monitor":  s.monitor.id,
+					"subprobe": s.name,
+					"state":    r.State,
+					"recorded": r.Recorded,
+				}).Debug("Suppressing alerts for flapping subprobe.")
+			}
+		} else {
+			for _, triggerSet := range s.triggerSets {
+				triggerSet.alert(alert, s.monitor)
+			}
+			for _, escalation := range s.escalations {
+				escalation.alert(alert)
+			}
 		}
 	} else if r.State != state.Normal && log.GetLevel() >= log.DebugLevel {
 		log.WithFields(log.Fields{
@@ -140,6 +269,59 @@ func (s *subprobe) process(r probe.Reading, isSilenced bool) {
 	}
 }
 
+// confirm returns r with its state replaced by the state the subprobe is
+// confirmed to be in as of r. A state worse than the subprobe's current one
+// stays pending until the subprobe has read at least as bad for the monitor's
+// confirmation readings and period, at which point the least severe state
+// read since is confirmed. Better states are confirmed right away.
+func (s *subprobe) confirm(r probe.Reading) probe.Reading {
+	if !s.monitor.confirms() || r.State <= s.state {
+		s.pendingState = state.Normal
+		s.pendingReadings = 0
+		return r
+	}
+
+	if s.pendingState <= s.state {
+		s.pendingState = r.State
+		s.pendingSince = r.Recorded
+		s.pendingReadings = 0
+	} else if r.State < s.pendingState {
+		s.pendingState = r.State
+	}
+	s.pendingReadings++
+
+	if s.pendingReadings < s.monitor.confirmReadings ||
+		r.Recorded.Sub(s.pendingSince) < s.monitor.confirmPeriod {
+		r.State = s.state
+		return r
+	}
+
+	r.State = s.pendingState
+	s.pendingState = state.Normal
+	s.pendingReadings = 0
+	return r
+}
+
+// updateAck returns whether ack applies to the subprobe's current state. An
+// acknowledgement lasts until the state worsens or recovers, after which it is
+// removed.
+func (s *subprobe) updateAck(ack *db.SubprobeAck) bool {
+	if ack == nil {
+		return false
+	}
+	if ack.State == s.state && s.state != state.Normal {
+		return true
+	}
+
+	if err := s.DB.DeleteSubprobeAck(s.id); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"monitor":  s.monitor.id,
+			"subprobe": s.name,
+		}).Error("Could not remove outdated acknowledgement.")
+	}
+	return false
+}
+
 func (s *subprobe) updateFor(r probe.Reading) {
 	stateChanged := s.state != r.State
 	s.lastReading = r.Recorded
@@ -153,6 +335,64 @@ func (s *subprobe) updateFor(r probe.Reading) {
 	s.saveNextReading = s.saveNextReading || stateChanged
 }
 
+// updateFlapping records a state change from oldState to r's state, if any,
+// and forgets those older than the monitor's flap window. A subprobe starts
+// flapping once it has changed state more than the monitor's flap changes
+// within the window, and stops once it is down to half of that.
+func (s *subprobe) updateFlapping(oldState state.State, r probe.Reading) (started, stopped bool) {
+	if !s.monitor.detectsFlapping() {
+		stopped = s.flapping
+		s.flapping = false
+		s.stateChanges = nil
+		return
+	}
+
+	if oldState != r.State {
+		s.stateChanges = append(s.stateChanges, r.Recorded)
+	}
+
+	windowStart := r.Recorded.Add(-s.monitor.flapWindow)
+	i := 0
+	for i < len(s.stateChanges) && s.stateChanges[i].Before(windowStart) {
+		i++
+	}
+	s.stateChanges = s.stateChanges[i:]
+
+	switch {
+	case !s.flapping && len(s.stateChanges) > s.monitor.flapChanges:
+		s.flapping = true
+		s.flapStartState = oldState
+		s.flapWorstState = oldState
+		started = true
+	case s.flapping && len(s.stateChanges) <= s.monitor.flapChanges/2:
+		s.flapping = false
+		stopped = true
+	}
+	if s.flapping && r.State > s.flapWorstState {
+		s.flapWorstState = r.State
+	}
+	return
+}
+
+// flapSettledFrom returns the state to alert as coming from now that the
+// subprobe has stopped flapping and settled in state settled. That's where it
+// was before flapping, as if it went straight to where it settled, unless it
+// settled below the worst state it reached while flapping. Then it's the worst
+// state, so that triggers the flapping alerted see it recover.
+func (s *subprobe) flapSettledFrom(settled state.State) state.State {
+	if settled < s.flapWorstState {
+		return s.flapWorstState
+	}
+	return s.flapStartState
+}
+
+// suppressedByParent returns whether alert a is suppressed because a monitor
+// the subprobe's monitor depends on is down. Recoveries are still sent, so
+// that incidents opened before the parent went down get resolved.
+func (s *subprobe) suppressedByParent(a *target.Alert) bool {
+	return s.monitor.suppressedBy != 0 && a.NewState >= a.OldState
+}
+
 func (s *subprobe) newAlert(oldState state.State, r probe.Reading) *target.Alert {
 	return &target.Alert{
 		MonitorID:    s.monitor.id,
@@ -179,6 +419,10 @@ func (s *subprobe) record(r probe.Reading, isSilenced bool) error {
 	return errors.Mask(s.DB.Tx(func(tx *db.Tx) error {
 		status := s.dbStatus()
 		status.Silenced = isSilenced
+		if !isSilenced && s.monitor.suppressedBy != 0 && s.state != state.Normal {
+			suppressedBy := s.monitor.suppressedBy
+			status.SuppressedBy = &suppressedBy
+		}
 		if err := tx.UpdateSubprobeStatus(status); err != nil {
 			return errors.Maskf(err, "update subprobe status")
 		}
@@ -201,11 +445,19 @@ func (s *subprobe) record(r probe.Reading, isSilenced bool) error {
 }
 
 func (s *subprobe) dbStatus() db.SubprobeStatus {
-	return db.SubprobeStatus{
+	status := db.SubprobeStatus{
 		SubprobeID:   s.id,
 		Recorded:     s.lastReading,
 		State:        s.state,
 		EnteredState: s.enteredState,
 		LastNormal:   s.lastNormal,
+		Flapping:     s.flapping,
+	}
+	if s.pendingState > s.state {
+		pendingSince := s.pendingSince
+		status.PendingState = s.pendingState
+		status.PendingSince = &pendingSince
+		status.PendingReadings = int16(s.pendingReadings)
 	}
+	return status
 }
@@ -0,0 +1,216 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/probe"
+	"github.com/yext/revere/state"
+	"github.com/yext/revere/target"
+)
+
+var testStart = time.Date(2016, time.March, 7, 9, 30, 0, 0, time.UTC)
+
+func reading(s state.State, after time.Duration) probe.Reading {
+	return probe.Reading{Subprobe: "a.b.c", State: s, Recorded: testStart.Add(after)}
+}
+
+// flap feeds the subprobe readings in the given states a minute apart,
+// starting after the given time, returning whether it started and stopped
+// flapping on each.
+func flap(s *subprobe, after time.Duration, states ...state.State) (started, stopped []bool) {
+	for i, st := range states {
+		r := reading(st, after+time.Duration(i)*time.Minute)
+		oldState := s.state
+		s.updateFor(r)
+		start, stop := s.updateFlapping(oldState, r)
+		started = append(started, start)
+		stopped = append(stopped, stop)
+	}
+	return
+}
+
+func flappingMonitor() *monitor {
+	return &monitor{flapChanges: 2, flapWindow: 10 * time.Minute}
+}
+
+func TestFlappingStartsAndSettles(t *testing.T) {
+	s := &subprobe{monitor: flappingMonitor(), state: state.Normal}
+
+	started, _ := flap(s, 0, state.Error, state.Normal, state.Error)
+	if started[0] || started[1] || !started[2] || !s.flapping {
+		t.Fatalf("Expected flapping to start on the third state change, got %v\n", started)
+	}
+
+	// Back to Normal, then quiet until the changes leave the window.
+	_, stopped := flap(s, 3*time.Minute, state.Normal)
+	if stopped[0] || !s.flapping {
+		t.Fatalf("Expected still flapping\n")
+	}
+	_, stopped = flap(s, 14*time.Minute, state.Normal)
+	if !stopped[0] || s.flapping {
+		t.Fatalf("Expected flapping to stop once the changes left the window\n")
+	}
+
+	// It settled Normal after reaching Error, so triggers that heard about
+	// the flapping at Error need to hear about the recovery.
+	if from := s.flapSettledFrom(state.Normal); from != state.Error {
+		t.Errorf("Expected settle alert from Error, got %s\n", from)
+	}
+}
+
+func TestFlappingSettlesWorse(t *testing.T) {
+	s := &subprobe{monitor: flappingMonitor(), state: state.Normal}
+	flap(s, 0, state.Warning, state.Normal, state.Error)
+	if !s.flapping {
+		t.Fatalf("Expected flapping\n")
+	}
+
+	// Settling at the worst state alerts as if it went straight there.
+	if from := s.flapSettledFrom(state.Error); from != state.Normal {
+		t.Errorf("Expected settle alert from Normal, got %s\n", from)
+	}
+}
+
+func TestFlappingRebuiltFromReadings(t *testing.T) {
+	m := flappingMonitor()
+	status := db.SubprobeStatus{
+		SubprobeID: 1,
+		Recorded:   testStart.Add(3 * time.Minute),
+		State:      state.Error,
+		Flapping:   true,
+	}
+	var recent []*db.Reading
+	for i, st := range []state.State{state.Error, state.Normal, state.Error} {
+		recent = append(recent, &db.Reading{
+			SubprobeID: 1,
+			Recorded:   testStart.Add(time.Duration(i+1) * time.Minute),
+			State:      st,
+		})
+	}
+
+	s := newSubprobe("a.b.c", status, m, nil, nil, recent)
+	_, stopped := flap(s, 4*time.Minute, state.Error)
+	if stopped[0] || !s.flapping {
+		t.Errorf("Expected a restarted subprobe to keep flapping while its changes are in the window\n")
+	}
+
+	s = newSubprobe("a.b.c", status, m, nil, nil, nil)
+	_, stopped = flap(s, 4*time.Minute, state.Error)
+	if !stopped[0] {
+		t.Errorf("Expected a subprobe without recent changes to stop flapping\n")
+	}
+}
+
+// confirmAll feeds the subprobe readings in the given states a minute apart,
+// starting after the given time, returning the confirmed states.
+func confirmAll(s *subprobe, after time.Duration, states ...state.State) []state.State {
+	var confirmed []state.State
+	for i, st := range states {
+		r := s.confirm(reading(st, after+time.Duration(i)*time.Minute))
+		s.updateFor(r)
+		confirmed = append(confirmed, r.State)
+	}
+	return confirmed
+}
+
+func expectStates(t *testing.T, what string, got []state.State, expected ...state.State) {
+	if len(got) != len(expected) {
+		t.Fatalf("%s: expected %v, got %v\n", what, expected, got)
+	}
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Errorf("%s: expected %v, got %v\n", what, expected, got)
+			return
+		}
+	}
+}
+
+func TestConfirmAfterReadings(t *testing.T) {
+	s := &subprobe{monitor: &monitor{confirmReadings: 3}, state: state.Normal}
+
+	confirmed := confirmAll(s, 0, state.Error, state.Error, state.Error)
+	expectStates(t, "confirm after 3 readings", confirmed,
+		state.Normal, state.Normal, state.Error)
+	if s.pendingState != state.Normal || s.pendingReadings != 0 {
+		t.Errorf("Expected nothing pending once confirmed, got %s for %d readings\n",
+			s.pendingState, s.pendingReadings)
+	}
+}
+
+func TestConfirmLeastSevereState(t *testing.T) {
+	s := &subprobe{monitor: &monitor{confirmReadings: 3}, state: state.Normal}
+
+	confirmed := confirmAll(s, 0, state.Critical, state.Error, state.Critical)
+	expectStates(t, "confirm least severe pending state", confirmed,
+		state.Normal, state.Normal, state.Error)
+}
+
+func TestConfirmAfterPeriod(t *testing.T) {
+	s := &subprobe{monitor: &monitor{confirmPeriod: 2 * time.Minute}, state: state.Normal}
+
+	confirmed := confirmAll(s, 0, state.Error, state.Error, state.Error)
+	expectStates(t, "confirm after 2 minutes", confirmed,
+		state.Normal, state.Normal, state.Error)
+	if !s.enteredState.Equal(testStart.Add(2 * time.Minute)) {
+		t.Errorf("Expected the state to be entered when confirmed, got %s\n", s.enteredState)
+	}
+}
+
+func TestConfirmNeedsReadingsAndPeriod(t *testing.T) {
+	s := &subprobe{monitor: &monitor{confirmReadings: 2, confirmPeriod: 3 * time.Minute}, state: state.Normal}
+
+	confirmed := confirmAll(s, 0, state.Error, state.Error, state.Error, state.Error)
+	expectStates(t, "confirm after 2 readings and 3 minutes", confirmed,
+		state.Normal, state.Normal, state.Normal, state.Error)
+}
+
+func TestPendingCancelledWhenStateReturns(t *testing.T) {
+	s := &subprobe{monitor: &monitor{confirmReadings: 3}, state: state.Normal}
+
+	confirmed := confirmAll(s, 0, state.Error, state.Error, state.Normal)
+	expectStates(t, "cancel pending state", confirmed,
+		state.Normal, state.Normal, state.Normal)
+	if s.pendingState != state.Normal || s.pendingReadings != 0 {
+		t.Errorf("Expected pending state to be cancelled, got %s for %d readings\n",
+			s.pendingState, s.pendingReadings)
+	}
+
+	// Confirmation starts over.
+	confirmed = confirmAll(s, 3*time.Minute, state.Error, state.Error, state.Error)
+	expectStates(t, "confirm after starting over", confirmed,
+		state.Normal, state.Normal, state.Error)
+}
+
+func TestBetterStateConfirmedImmediately(t *testing.T) {
+	s := &subprobe{monitor: &monitor{confirmReadings: 3}, state: state.Error}
+
+	confirmed := confirmAll(s, 0, state.Warning, state.Normal)
+	expectStates(t, "confirm better states", confirmed, state.Warning, state.Normal)
+}
+
+func TestParentSuppressesAllButRecoveries(t *testing.T) {
+	s := &subprobe{monitor: &monitor{suppressedBy: 2}, state: state.Normal}
+
+	for _, c := range []struct {
+		old, new   state.State
+		suppressed bool
+	}{
+		{state.Normal, state.Error, true},
+		{state.Error, state.Error, true},
+		{state.Warning, state.Critical, true},
+		{state.Critical, state.Warning, false},
+		{state.Error, state.Normal, false},
+	} {
+		a := &target.Alert{OldState: c.old, NewState: c.new}
+		if got := s.suppressedByParent(a); got != c.suppressed {
+			t.Errorf("Expected suppressed %t from %s to %s, got %t\n", c.suppressed, c.old, c.new, got)
+		}
+	}
+
+	s.monitor.suppressedBy = 0
+	if s.suppressedByParent(&target.Alert{OldState: state.Normal, NewState: state.Error}) {
+		t.Errorf("Expected no suppression without a parent down\n")
+	}
+}
@@ -18,10 +18,14 @@ type triggerTemplate struct {
 	triggerOnExit bool
 	period        time.Duration
 	target        target.Target
+
+	// groupWindow is how long alerts on the monitor's subprobes are held so
+	// that they're sent together, if at all.
+	groupWindow time.Duration
 }
 
 func newTriggerTemplate(dbModel *db.Trigger, env *env.Env) (*triggerTemplate, error) {
-	target, err := target.New(dbModel.TargetType, dbModel.Target)
+	target, err := target.NewForTrigger(dbModel)
 	if err != nil {
 		return nil, errors.Maskf(err, "make target")
 	}
@@ -32,6 +36,7 @@ func newTriggerTemplate(dbModel *db.Trigger, env *env.Env) (*triggerTemplate, er
 		triggerOnExit: dbModel.TriggerOnExit,
 		period:        time.Duration(dbModel.PeriodMilli) * time.Millisecond,
 		target:        target,
+		groupWindow:   time.Duration(dbModel.GroupMilli) * time.Millisecond,
 	}, nil
 }
 
@@ -47,6 +52,10 @@ func newTrigger(template *triggerTemplate, Env *env.Env) *trigger {
 }
 
 func (t *trigger) shouldTrigger(a *target.Alert) bool {
+	if a.Flapping {
+		return a.OldState >= t.level || a.NewState >= t.level
+	}
+
 	if a.OldState == a.NewState {
 		if a.NewState < t.level {
 			return false
@@ -71,25 +80,50 @@ func (s sameTypeTriggerSet) add(t *trigger) {
 	s[t.id] = t
 }
 
-func (s sameTypeTriggerSet) alert(a *target.Alert) {
-	toAlert := make(map[db.TriggerID]target.Target)
-	var inactive []target.Target
-	var targetType target.Type
-	var Db *db.DB
+// alert queues a in the outbox for the triggers that should fire on it and
+// makes the first attempt to deliver it. Failed deliveries are retried from the
+// outbox. Triggers that group alerts instead hold a in m's groups until their
+// window ends.
+func (s sameTypeTriggerSet) alert(a *target.Alert, m *monitor) {
+	var toAlert, inactive []*trigger
 	for _, trigger := range s {
-		Db = trigger.Env.DB
-		if trigger.shouldTrigger(a) {
-			toAlert[trigger.id] = trigger.target
-			targetType = trigger.target.Type()
-		} else {
-			inactive = append(inactive, trigger.target)
+		switch {
+		case !trigger.shouldTrigger(a):
+			inactive = append(inactive, trigger)
+		case trigger.groupWindow > 0:
+			m.group(a, trigger)
+		default:
+			toAlert = append(toAlert, trigger)
 		}
 	}
 
+	send(a, toAlert, inactive)
+}
+
+// send queues a in the outbox for the triggers in toAlert, which all have the
+// same target type, and makes the first attempt to deliver it. inactive holds
+// the other triggers of that type that apply to the alerting subprobe.
+func send(a *target.Alert, toAlert, inactive []*trigger) {
 	if len(toAlert) == 0 {
 		return
 	}
 
+	targetType := toAlert[0].target.Type()
+	Db := toAlert[0].Env.DB
+
+	toAlertTargets := make(map[db.TriggerID]target.Target)
+	toAlertIDs := make([]db.TriggerID, 0, len(toAlert))
+	for _, trigger := range toAlert {
+		toAlertTargets[trigger.id] = trigger.target
+		toAlertIDs = append(toAlertIDs, trigger.id)
+	}
+	inactiveTargets := make([]target.Target, 0, len(inactive))
+	inactiveIDs := make([]db.TriggerID, 0, len(inactive))
+	for _, trigger := range inactive {
+		inactiveTargets = append(inactiveTargets, trigger.target)
+		inactiveIDs = append(inactiveIDs, trigger.id)
+	}
+
 	if log.GetLevel() >= log.DebugLevel {
 		log.WithFields(log.Fields{
 			"monitor":    a.MonitorID,
@@ -102,8 +136,33 @@ func (s sameTypeTriggerSet) alert(a *target.Alert) {
 		}).Debug("Sending alerts.")
 	}
 
-	errors := targetType.Alert(Db, a, toAlert, inactive)
+	now := time.Now()
+	o, err := newOutboxAlert(a, targetType.ID(), toAlertIDs, inactiveIDs, now)
+	if err == nil {
+		o.OutboxID, err = Db.InsertOutboxAlert(o)
+	}
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"monitor":    a.MonitorID,
+			"subprobe":   a.SubprobeName,
+			"targetType": targetType.ID(),
+		}).Error("Could not queue alert in outbox; delivering without retries.")
+		sendWithoutOutbox(a, targetType, Db, toAlert, toAlertTargets, inactiveTargets)
+		return
+	}
+
+	errs := targetType.Alert(Db, a, toAlertTargets, inactiveTargets)
+	recordDelivery(Db, o, errs, time.Now())
+
+	// Alerts that failed are in the outbox to be retried, so count them as
+	// sent.
+	recordAlerted(a, toAlert, now)
+}
 
+func sendWithoutOutbox(a *target.Alert, targetType target.Type, Db *db.DB, toAlert []*trigger, toAlertTargets map[db.TriggerID]target.Target, inactiveTargets []target.Target) {
+	errors := targetType.Alert(Db, a, toAlertTargets, inactiveTargets)
+
+	failed := make(map[db.TriggerID]bool)
 	for _, errAndIDs := range errors {
 		log.WithError(errAndIDs.Err).WithFields(log.Fields{
 			"monitor":    a.MonitorID,
@@ -115,12 +174,37 @@ func (s sameTypeTriggerSet) alert(a *target.Alert) {
 		}).Error("Some alerts failed and have been lost.")
 
 		for _, id := range errAndIDs.IDs {
-			delete(toAlert, id)
+			failed[id] = true
 		}
 	}
 
-	now := time.Now()
-	for id, _ := range toAlert {
-		s[id].lastAlert = now
+	alerted := make([]*trigger, 0, len(toAlert))
+	for _, trigger := range toAlert {
+		if !failed[trigger.id] {
+			alerted = append(alerted, trigger)
+		}
+	}
+	recordAlerted(a, alerted, time.Now())
+}
+
+// recordAlerted notes that the given triggers alerted at t, saving it so that
+// restarting the monitor doesn't resend repeat alerts early.
+func recordAlerted(a *target.Alert, triggers []*trigger, t time.Time) {
+	if len(triggers) == 0 {
+		return
+	}
+
+	ids := make([]db.TriggerID, 0, len(triggers))
+	for _, trigger := range triggers {
+		trigger.lastAlert = t
+		ids = append(ids, trigger.id)
+	}
+
+	if err := triggers[0].Env.DB.SaveTriggerLastAlerts(a.SubprobeID, ids, t); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"monitor":  a.MonitorID,
+			"subprobe": a.SubprobeName,
+			"triggers": ids,
+		}).Error("Could not save trigger last alert times.")
 	}
 }
@@ -0,0 +1,66 @@
+package daemon
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+	"github.com/yext/revere/target"
+)
+
+// repeatingMonitor returns a monitor whose subprobes alert by email through
+// trigger 20 at Error, repeating hourly.
+func repeatingMonitor(t *testing.T) *monitor {
+	tgt, err := target.NewForTrigger(&db.Trigger{
+		TargetType: target.EmailType{}.Id(),
+		Target:     []byte(`{"Addresses": [{"To": "ops@example.com"}]}`),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error making target: %v\n", err)
+	}
+	return &monitor{triggers: []monitorTrigger{{
+		subprobes: regexp.MustCompile(".*"),
+		triggerTemplate: &triggerTemplate{
+			id:     20,
+			level:  state.Error,
+			period: time.Hour,
+			target: tgt,
+		},
+	}}}
+}
+
+func reloadedTrigger(t *testing.T, lastAlerts map[db.TriggerID]time.Time) *trigger {
+	status := db.SubprobeStatus{SubprobeID: 1, Recorded: time.Now(), State: state.Error}
+	s := newSubprobe("a.b.c", status, repeatingMonitor(t), lastAlerts, nil, nil)
+	trigger := s.triggerSets[target.EmailType{}.Id()][20]
+	if trigger == nil {
+		t.Fatalf("Expected the subprobe to have trigger 20\n")
+	}
+	return trigger
+}
+
+func TestReloadedTriggerKeepsPeriod(t *testing.T) {
+	repeat := &target.Alert{OldState: state.Error, NewState: state.Error}
+
+	lastAlert := time.Now().Add(-10 * time.Minute)
+	trigger := reloadedTrigger(t, map[db.TriggerID]time.Time{20: lastAlert})
+	if !trigger.lastAlert.Equal(lastAlert) {
+		t.Errorf("Expected the last alert time to be restored, got %s\n", trigger.lastAlert)
+	}
+	if trigger.shouldTrigger(repeat) {
+		t.Errorf("Expected no repeat alert within the period after reloading\n")
+	}
+
+	trigger = reloadedTrigger(t, map[db.TriggerID]time.Time{20: time.Now().Add(-2 * time.Hour)})
+	if !trigger.shouldTrigger(repeat) {
+		t.Errorf("Expected a repeat alert once the period is up\n")
+	}
+
+	// A trigger that never alerted repeats right away.
+	trigger = reloadedTrigger(t, nil)
+	if !trigger.shouldTrigger(repeat) {
+		t.Errorf("Expected a repeat alert from a trigger that never alerted\n")
+	}
+}
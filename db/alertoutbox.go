@@ -0,0 +1,163 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+)
+
+type OutboxID int64
+
+// OutboxAlert is an alert waiting in the outbox to be delivered to the targets
+// of some triggers, all of the same target type. Alerts that could not be
+// delivered before giving up on them are kept with Failed set.
+type OutboxAlert struct {
+	OutboxID   OutboxID
+	MonitorID  MonitorID
+	SubprobeID SubprobeID
+	TargetType TargetType
+
+	Alert              types.JSONText
+	TriggerIDs         types.JSONText
+	InactiveTriggerIDs types.JSONText
+
+	Created     time.Time
+	Attempts    int32
+	NextAttempt time.Time
+	LastError   string
+	Failed      *time.Time
+}
+
+func (db *DB) InsertOutboxAlert(a *OutboxAlert) (OutboxID, error) {
+	q := `INSERT INTO pfx_alert_outbox (
+	        monitorid, subprobeid, targettype, alert, triggerids, inactivetriggerids,
+	        created, attempts, nextattempt, lasterror, failed
+	      ) VALUES (
+	        :monitorid, :subprobeid, :targettype, :alert, :triggerids, :inactivetriggerids,
+	        :created, :attempts, :nextattempt, :lasterror, :failed
+	      )`
+	result, err := db.NamedExec(cq(db, q), a)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return OutboxID(id), nil
+}
+
+// UpdateOutboxAlert saves the outcome of an attempt to deliver a.
+func (db *DB) UpdateOutboxAlert(a *OutboxAlert) error {
+	q := `UPDATE pfx_alert_outbox
+	      SET triggerids = :triggerids,
+	          attempts = :attempts,
+	          nextattempt = :nextattempt,
+	          lasterror = :lasterror,
+	          failed = :failed
+	      WHERE outboxid = :outboxid`
+	_, err := db.NamedExec(cq(db, q), a)
+	return errors.Trace(err)
+}
+
+func (db *DB) DeleteOutboxAlert(id OutboxID) error {
+	q := `DELETE FROM pfx_alert_outbox WHERE outboxid = ?`
+	_, err := db.Exec(cq(db, q), id)
+	return errors.Trace(err)
+}
+
+func (db *DB) LoadOutboxAlert(id OutboxID) (*OutboxAlert, error) {
+	var a OutboxAlert
+	q := `SELECT * FROM pfx_alert_outbox WHERE outboxid = ?`
+	if err := db.Get(&a, cq(db, q), id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Trace(err)
+	}
+	return &a, nil
+}
+
+// LoadDueOutboxAlerts loads up to limit alerts that have not failed and are
+// due for another delivery attempt as of t, earliest first.
+func (db *DB) LoadDueOutboxAlerts(t time.Time, limit int) ([]*OutboxAlert, error) {
+	var alerts []*OutboxAlert
+	q := `SELECT * FROM pfx_alert_outbox
+	      WHERE failed IS NULL AND nextattempt <= ?
+	      ORDER BY nextattempt
+	      LIMIT ?`
+	if err := db.Select(&alerts, cq(db, q), t, limit); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return alerts, nil
+}
+
+// LoadFailedOutboxAlerts loads the alerts that were given up on, most recent
+// first.
+func (db *DB) LoadFailedOutboxAlerts() ([]*OutboxAlert, error) {
+	var alerts []*OutboxAlert
+	q := `SELECT * FROM pfx_alert_outbox
+	      WHERE failed IS NOT NULL
+	      ORDER BY failed DESC`
+	if err := db.Select(&alerts, cq(db, q)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return alerts, nil
+}
+
+// CountFailedOutboxAlertsSince counts, per target type, the alerts that were
+// given up on at or after t.
+func (db *DB) CountFailedOutboxAlertsSince(t time.Time) (map[TargetType]int, error) {
+	var counts []struct {
+		TargetType TargetType
+		Count      int
+	}
+	q := `SELECT targettype, COUNT(*) AS count FROM pfx_alert_outbox
+	      WHERE failed >= ?
+	      GROUP BY targettype`
+	if err := db.Select(&counts, cq(db, q), t); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := make(map[TargetType]int, len(counts))
+	for _, c := range counts {
+		result[c.TargetType] = c.Count
+	}
+	return result, nil
+}
+
+// RetryOutboxAlert puts a failed alert back in the outbox to be delivered
+// as though it were new.
+func (db *DB) RetryOutboxAlert(id OutboxID, t time.Time) error {
+	q := `UPDATE pfx_alert_outbox
+	      SET created = ?, attempts = 0, nextattempt = ?, failed = NULL
+	      WHERE outboxid = ? AND failed IS NOT NULL`
+	_, err := db.Exec(cq(db, q), t, t, id)
+	return errors.Trace(err)
+}
+
+// LoadTriggers loads the triggers with the given IDs that still exist.
+func (db *DB) LoadTriggers(ids []TriggerID) (map[TriggerID]*Trigger, error) {
+	result := make(map[TriggerID]*Trigger, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	var triggers []*Trigger
+	q, args, err := sqlx.In(`SELECT * FROM pfx_triggers WHERE triggerid IN (?)`, ids)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := db.Select(&triggers, cq(db, q), args...); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	for _, t := range triggers {
+		result[t.TriggerID] = t
+	}
+	return result, nil
+}
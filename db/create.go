@@ -9,20 +9,12 @@ import (
 	"github.com/yext/revere/state"
 )
 
+// create makes the tables of schema version 1. Later versions are reached by
+// migrating from it.
 func (db *DB) create() error {
-	// Necessary to make foreign key names unique database-wide.
-	noDBPrefix := db.prefix[strings.Index(db.prefix, ".")+1:]
-
 	for _, table := range createTables {
-		query := fmt.Sprintf("CREATE TABLE pfx_%s (", table.name)
-		query += strings.Join(table.rowsAndKeys, ", ")
-		query += ") ENGINE=InnoDB CHARACTER SET=utf8mb4"
-		query = strings.Replace(query, "nodbpfx_", noDBPrefix, -1)
-		query = cq(db, query)
-
-		_, err := db.Exec(query)
-		if err != nil {
-			return errors.Maskf(err, "create table %s", table.name)
+		if err := db.createTable(table.name, table.rowsAndKeys); err != nil {
+			return errors.Trace(err)
 		}
 	}
 	for i, query := range createExtra {
@@ -34,6 +26,20 @@ func (db *DB) create() error {
 	return nil
 }
 
+func (db *DB) createTable(name string, rowsAndKeys []string) error {
+	// Necessary to make foreign key names unique database-wide.
+	noDBPrefix := db.prefix[strings.Index(db.prefix, ".")+1:]
+
+	query := fmt.Sprintf("CREATE TABLE pfx_%s (", name)
+	query += strings.Join(rowsAndKeys, ", ")
+	query += ") ENGINE=InnoDB CHARACTER SET=utf8mb4"
+	query = strings.Replace(query, "nodbpfx_", noDBPrefix, -1)
+	query = cq(db, query)
+
+	_, err := db.Exec(query)
+	return errors.Maskf(err, "create table %s", name)
+}
+
 var createTables = []struct {
 	name        string
 	rowsAndKeys []string
@@ -54,7 +54,7 @@ func (db *DB) Beginx() (*Tx, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &Tx{Tx: tx, prefix: db.prefix}, nil
+	return &Tx{Tx: tx, prefix: db.prefix, db: db}, nil
 }
 
 func (db *DB) Tx(f func(*Tx) error) (err error) {
@@ -81,6 +81,14 @@ func (db *DB) Tx(f func(*Tx) error) (err error) {
 type Tx struct {
 	*sqlx.Tx
 	prefix string
+	db     *DB
+}
+
+// DB returns the database this transaction was started on. It is meant for
+// long-lived users, such as probes, that are constructed within a
+// transaction but need to keep querying after it has finished.
+func (tx *Tx) DB() *DB {
+	return tx.db
 }
 
 // Prefix returns the prefix to add to table names in queries.
@@ -89,7 +97,7 @@ func (tx *Tx) Prefix() string {
 }
 
 func (tx *Tx) Unsafe() *Tx {
-	return &Tx{Tx: tx.Tx.Unsafe(), prefix: tx.prefix}
+	return &Tx{Tx: tx.Tx.Unsafe(), prefix: tx.prefix, db: tx.db}
 }
 
 // dbOrTx makes it easier to implement data loading methods that can be run
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/state"
+)
+
+// MonitorDependency is a monitor depending on a parent monitor. While any of
+// the parent's subprobes matching Subprobes is at or above Level, the
+// monitor's alerts are suppressed.
+type MonitorDependency struct {
+	MonitorID MonitorID
+	ParentID  MonitorID
+	Subprobes string
+	Level     state.State
+
+	// MonitorName and ParentName are filled in when loading dependencies.
+	MonitorName string
+	ParentName  string
+}
+
+// ActiveDependency is a subprobe of a parent monitor that is at or above the
+// level of a dependency on it.
+type ActiveDependency struct {
+	ParentID     MonitorID
+	Subprobes    string
+	SubprobeName string
+	State        state.State
+}
+
+const dependencyColumns = `d.monitorid, d.parentid, d.subprobes, d.level,
+	c.name AS monitorname, p.name AS parentname
+	FROM pfx_monitor_dependencies d
+	JOIN pfx_monitors c ON c.monitorid = d.monitorid
+	JOIN pfx_monitors p ON p.monitorid = d.parentid`
+
+// LoadDependenciesForMonitor loads the dependencies of the monitor with the
+// given ID on its parents.
+func (tx *Tx) LoadDependenciesForMonitor(id MonitorID) ([]*MonitorDependency, error) {
+	var ds []*MonitorDependency
+	q := `SELECT ` + dependencyColumns + `
+	      WHERE d.monitorid = ?
+	      ORDER BY p.name`
+	if err := tx.Select(&ds, cq(tx, q), id); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ds, nil
+}
+
+// LoadDependentsOfMonitor loads the dependencies of other monitors on the
+// monitor with the given ID.
+func (tx *Tx) LoadDependentsOfMonitor(id MonitorID) ([]*MonitorDependency, error) {
+	var ds []*MonitorDependency
+	q := `SELECT ` + dependencyColumns + `
+	      WHERE d.parentid = ?
+	      ORDER BY c.name`
+	if err := tx.Select(&ds, cq(tx, q), id); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ds, nil
+}
+
+func (db *DB) LoadMonitorDependencies() ([]*MonitorDependency, error) {
+	return loadMonitorDependencies(db)
+}
+
+func (tx *Tx) LoadMonitorDependencies() ([]*MonitorDependency, error) {
+	return loadMonitorDependencies(tx)
+}
+
+func loadMonitorDependencies(dt dbOrTx) ([]*MonitorDependency, error) {
+	dt = unsafe(dt)
+
+	var ds []*MonitorDependency
+	q := `SELECT ` + dependencyColumns + `
+	      ORDER BY c.name, p.name`
+	if err := dt.Select(&ds, cq(dt, q)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ds, nil
+}
+
+// LoadActiveDependenciesForMonitor loads the subprobes of the monitor's
+// active parents that are at or above the level of its dependencies on them.
+// Whether they match the dependencies' subprobe patterns is left to the
+// caller.
+func (db *DB) LoadActiveDependenciesForMonitor(id MonitorID) ([]ActiveDependency, error) {
+	var ds []ActiveDependency
+	q := `SELECT d.parentid, d.subprobes, s.name AS subprobename, ss.state
+	      FROM pfx_monitor_dependencies d
+	      JOIN pfx_monitors p ON p.monitorid = d.parentid AND p.archived IS NULL
+	      JOIN pfx_subprobes s ON s.monitorid = d.parentid AND s.archived IS NULL
+	      JOIN pfx_subprobe_statuses ss ON ss.subprobeid = s.subprobeid
+	      WHERE d.monitorid = ? AND ss.state >= d.level`
+	if err := db.Select(&ds, cq(db, q), id); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ds, nil
+}
+
+func (tx *Tx) CreateMonitorDependency(d MonitorDependency) error {
+	q := `INSERT INTO pfx_monitor_dependencies (monitorid, parentid, subprobes, level)
+	      VALUES (:monitorid, :parentid, :subprobes, :level)`
+	_, err := tx.NamedExec(cq(tx, q), d)
+	return errors.Trace(err)
+}
+
+func (tx *Tx) UpdateMonitorDependency(d MonitorDependency) error {
+	q := `UPDATE pfx_monitor_dependencies
+	      SET subprobes=:subprobes, level=:level
+	      WHERE monitorid=:monitorid AND parentid=:parentid`
+	_, err := tx.NamedExec(cq(tx, q), d)
+	return errors.Trace(err)
+}
+
+func (tx *Tx) DeleteMonitorDependency(d MonitorDependency) error {
+	q := `DELETE FROM pfx_monitor_dependencies
+	      WHERE monitorid=:monitorid AND parentid=:parentid`
+	_, err := tx.NamedExec(cq(tx, q), d)
+	return errors.Trace(err)
+}
@@ -0,0 +1,50 @@
+package db
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// EscalationIncident records when a subprobe last reached the level of a
+// trigger targeting an escalation policy, so that the steps notified since
+// are still told when it recovers after a daemon restart.
+type EscalationIncident struct {
+	TriggerID     TriggerID
+	SubprobeID    SubprobeID
+	IncidentStart time.Time
+}
+
+func (tx *Tx) LoadEscalationIncidentsForMonitor(id MonitorID) (map[SubprobeID]map[TriggerID]time.Time, error) {
+	var incidents []EscalationIncident
+	q := `SELECT ei.triggerid, ei.subprobeid, ei.incidentstart
+	      FROM pfx_escalation_incidents ei
+	      JOIN pfx_subprobes s USING (subprobeid)
+	      WHERE s.monitorid = ?`
+	if err := tx.Select(&incidents, cq(tx, q), id); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := make(map[SubprobeID]map[TriggerID]time.Time)
+	for _, i := range incidents {
+		if result[i.SubprobeID] == nil {
+			result[i.SubprobeID] = make(map[TriggerID]time.Time)
+		}
+		result[i.SubprobeID][i.TriggerID] = i.IncidentStart
+	}
+	return result, nil
+}
+
+func (db *DB) SaveEscalationIncident(i EscalationIncident) error {
+	q := `INSERT INTO pfx_escalation_incidents (triggerid, subprobeid, incidentstart)
+	      VALUES (:triggerid, :subprobeid, :incidentstart)
+	      ON DUPLICATE KEY UPDATE incidentstart = VALUES(incidentstart)`
+	_, err := db.NamedExec(cq(db, q), i)
+	return errors.Trace(err)
+}
+
+func (db *DB) DeleteEscalationIncident(subprobeID SubprobeID, triggerID TriggerID) error {
+	q := `DELETE FROM pfx_escalation_incidents WHERE subprobeid = ? AND triggerid = ?`
+	_, err := db.Exec(cq(db, q), subprobeID, triggerID)
+	return errors.Trace(err)
+}
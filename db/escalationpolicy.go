@@ -0,0 +1,158 @@
+package db
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+)
+
+type EscalationPolicyID int32
+
+type EscalationPolicy struct {
+	PolicyID    EscalationPolicyID
+	Name        string
+	Description string
+}
+
+// EscalationStep alerts its trigger's target once a subprobe has been in a
+// triggering state for DelayMilli without being acknowledged. Only the
+// trigger's target is used; the policy's own trigger decides when it applies.
+type EscalationStep struct {
+	PolicyID   EscalationPolicyID
+	DelayMilli int64
+	*Trigger
+}
+
+func (db *DB) LoadEscalationPolicy(id EscalationPolicyID) (*EscalationPolicy, error) {
+	return loadEscalationPolicy(db, id)
+}
+
+func (tx *Tx) LoadEscalationPolicy(id EscalationPolicyID) (*EscalationPolicy, error) {
+	return loadEscalationPolicy(tx, id)
+}
+
+func loadEscalationPolicy(dt dbOrTx, id EscalationPolicyID) (*EscalationPolicy, error) {
+	dt = unsafe(dt)
+
+	var p EscalationPolicy
+	q := `SELECT * FROM pfx_escalation_policies WHERE policyid = ?`
+	err := dt.Get(&p, cq(dt, q), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Trace(err)
+	}
+	return &p, nil
+}
+
+func (db *DB) LoadEscalationPolicies() ([]*EscalationPolicy, error) {
+	return loadEscalationPolicies(db)
+}
+
+func (tx *Tx) LoadEscalationPolicies() ([]*EscalationPolicy, error) {
+	return loadEscalationPolicies(tx)
+}
+
+func loadEscalationPolicies(dt dbOrTx) ([]*EscalationPolicy, error) {
+	dt = unsafe(dt)
+
+	var ps []*EscalationPolicy
+	q := `SELECT * FROM pfx_escalation_policies ORDER BY name`
+	if err := dt.Select(&ps, cq(dt, q)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ps, nil
+}
+
+func (tx *Tx) CreateEscalationPolicy(p *EscalationPolicy) (EscalationPolicyID, error) {
+	q := `INSERT INTO pfx_escalation_policies (name, description)
+	      VALUES (:name, :description)`
+	result, err := tx.NamedExec(cq(tx, q), p)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return EscalationPolicyID(id), nil
+}
+
+func (tx *Tx) UpdateEscalationPolicy(p *EscalationPolicy) error {
+	q := `UPDATE pfx_escalation_policies
+	      SET name=:name, description=:description
+	      WHERE policyid=:policyid`
+	_, err := tx.NamedExec(cq(tx, q), p)
+	return errors.Trace(err)
+}
+
+// LoadEscalationSteps loads a policy's steps in the order they are reached.
+func (tx *Tx) LoadEscalationSteps(id EscalationPolicyID) ([]*EscalationStep, error) {
+	t := unsafe(tx)
+
+	var steps []*EscalationStep
+	q := `SELECT *
+	      FROM pfx_escalation_steps
+	      JOIN pfx_triggers USING (triggerid)
+	      WHERE pfx_escalation_steps.policyid = ?
+	      ORDER BY pfx_escalation_steps.delaymilli, pfx_escalation_steps.triggerid`
+	if err := t.Select(&steps, cq(t, q), id); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return steps, nil
+}
+
+func (tx *Tx) CreateEscalationStep(s EscalationStep) (TriggerID, error) {
+	var err error
+	s.TriggerID, err = tx.createTrigger(s.Trigger)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	q := `INSERT INTO pfx_escalation_steps (policyid, delaymilli, triggerid)
+	      VALUES (:policyid, :delaymilli, :triggerid)`
+	_, err = tx.NamedExec(cq(tx, q), s)
+	return s.TriggerID, errors.Trace(err)
+}
+
+func (tx *Tx) UpdateEscalationStep(s EscalationStep) error {
+	err := tx.updateTrigger(s.Trigger)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	q := `UPDATE pfx_escalation_steps
+	      SET delaymilli=:delaymilli
+	      WHERE triggerid=:triggerid`
+	_, err = tx.NamedExec(cq(tx, q), s)
+	return errors.Trace(err)
+}
+
+func (tx *Tx) DeleteEscalationStep(triggerID TriggerID) error {
+	return tx.deleteTrigger(triggerID)
+}
+
+// TouchMonitorsWithTriggerTarget marks the monitors with a trigger, of their
+// own or from a label, with the given target as changed, so the daemon
+// reloads them.
+func (tx *Tx) TouchMonitorsWithTriggerTarget(targetType TargetType, target types.JSONText) error {
+	q := `UPDATE pfx_monitors
+	      SET changed=NOW(), version=version+1
+	      WHERE monitorid IN (
+	        SELECT mt.monitorid
+	        FROM pfx_monitor_triggers mt
+	        JOIN pfx_triggers t USING (triggerid)
+	        WHERE t.targettype = ? AND t.target = ?
+	        UNION
+	        SELECT lm.monitorid
+	        FROM pfx_labels_monitors lm
+	        JOIN pfx_label_triggers lt USING (labelid)
+	        JOIN pfx_triggers t USING (triggerid)
+	        WHERE t.targettype = ? AND t.target = ?
+	      )`
+	_, err := tx.Exec(cq(tx, q), targetType, target, targetType, target)
+	return errors.Trace(err)
+}
@@ -0,0 +1,50 @@
+package db
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
+
+type Heartbeat struct {
+	Token    string
+	Name     string
+	LastPing time.Time
+}
+
+// RecordHeartbeat notes that the heartbeat with the given token and name
+// checked in at the given time. A name new to the token is only recorded if
+// fewer than maxNames others share the token; recorded reports whether it was.
+func (db *DB) RecordHeartbeat(h Heartbeat, maxNames int) (recorded bool, err error) {
+	err = db.Tx(func(tx *Tx) error {
+		var others int
+		q := `SELECT COUNT(*) FROM pfx_heartbeats WHERE token = ? AND name <> ?`
+		if err := tx.Get(&others, cq(tx, q), h.Token, h.Name); err != nil {
+			return errors.Trace(err)
+		}
+		if others >= maxNames {
+			return nil
+		}
+
+		q = `INSERT INTO pfx_heartbeats (token, name, lastping)
+		     VALUES (:token, :name, :lastping)
+		     ON DUPLICATE KEY UPDATE lastping = GREATEST(lastping, VALUES(lastping))`
+		if _, err := tx.NamedExec(cq(tx, q), h); err != nil {
+			return errors.Trace(err)
+		}
+		recorded = true
+		return nil
+	})
+	return recorded, errors.Trace(err)
+}
+
+// LoadHeartbeats loads the most recent ping of every heartbeat sharing the
+// given token.
+func (db *DB) LoadHeartbeats(token string) ([]*Heartbeat, error) {
+	var heartbeats []*Heartbeat
+	q := `SELECT * FROM pfx_heartbeats WHERE token = ? ORDER BY name`
+	if err := db.Select(&heartbeats, cq(db, q), token); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return heartbeats, nil
+}
@@ -1,6 +1,22 @@
 package db
 
+import (
+	"github.com/juju/errors"
+)
+
+// Init makes Revere's tables if the database hasn't been initialized, then
+// migrates them to the latest schema version.
 func (db *DB) Init() error {
-	// TODO(eefi): Check the schema_history table.
-	return db.create()
+	version, err := db.LoadSchemaVersion()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if version == 0 {
+		if err := db.create(); err != nil {
+			return errors.Trace(err)
+		}
+		version = 1
+	}
+	return errors.Trace(db.migrate(version))
 }
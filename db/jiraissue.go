@@ -0,0 +1,46 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// JiraIssue records the Jira issue opened in a project for a subprobe's
+// current incident.
+type JiraIssue struct {
+	SubprobeID SubprobeID
+	Project    string
+	IssueKey   string
+	Created    time.Time
+}
+
+// LoadJiraIssue returns the open issue for a subprobe in project, or nil if
+// there is none.
+func (db *DB) LoadJiraIssue(subprobeID SubprobeID, project string) (*JiraIssue, error) {
+	var i JiraIssue
+	q := `SELECT * FROM pfx_jira_issues WHERE subprobeid = ? AND project = ?`
+	err := db.Get(&i, cq(db, q), subprobeID, project)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &i, nil
+}
+
+func (db *DB) SaveJiraIssue(i JiraIssue) error {
+	q := `INSERT INTO pfx_jira_issues (subprobeid, project, issuekey, created)
+	      VALUES (:subprobeid, :project, :issuekey, :created)
+	      ON DUPLICATE KEY UPDATE issuekey = VALUES(issuekey), created = VALUES(created)`
+	_, err := db.NamedExec(cq(db, q), i)
+	return errors.Trace(err)
+}
+
+func (db *DB) DeleteJiraIssue(subprobeID SubprobeID, project string) error {
+	q := `DELETE FROM pfx_jira_issues WHERE subprobeid = ? AND project = ?`
+	_, err := db.Exec(cq(db, q), subprobeID, project)
+	return errors.Trace(err)
+}
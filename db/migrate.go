@@ -0,0 +1,347 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/juju/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yext/revere/state"
+)
+
+// mysqlNoSuchTable is MySQL's error number for a table that doesn't exist.
+const mysqlNoSuchTable = 1146
+
+// schemaMigration upgrades the schema from the version before it to version.
+// Its new tables are created before its queries run.
+type schemaMigration struct {
+	version   int
+	newTables []schemaTable
+	queries   []string
+}
+
+type schemaTable struct {
+	name        string
+	rowsAndKeys []string
+}
+
+// schemaMigrations upgrade the version 1 schema made by create, in order.
+// Changing the schema means adding a migration to the end, never editing one
+// that has been released.
+var schemaMigrations = []schemaMigration{
+	{
+		// Heartbeat pings.
+		version: 2,
+		newTables: []schemaTable{
+			{
+				name: "heartbeats",
+				rowsAndKeys: []string{
+					"token VARCHAR(64) NOT NULL",
+					"name VARCHAR(150) NOT NULL",
+					"lastping DATETIME NOT NULL",
+					"PRIMARY KEY (token, name)",
+				},
+			},
+		},
+	},
+	{
+		// Confirmation of worse states.
+		version: 3,
+		queries: []string{
+			`ALTER TABLE pfx_monitors
+			 ADD COLUMN confirmreadings SMALLINT NOT NULL DEFAULT 0,
+			 ADD COLUMN confirmmilli BIGINT NOT NULL DEFAULT 0`,
+			`ALTER TABLE pfx_subprobe_statuses
+			 ADD COLUMN pendingstate TINYINT NOT NULL DEFAULT 0,
+			 ADD COLUMN pendingsince DATETIME DEFAULT NULL,
+			 ADD COLUMN pendingreadings SMALLINT NOT NULL DEFAULT 0,
+			 ADD KEY idx_pendingstate (pendingstate)`,
+		},
+	},
+	{
+		// Flap detection.
+		version: 4,
+		queries: []string{
+			`ALTER TABLE pfx_monitors
+			 ADD COLUMN flapchanges SMALLINT NOT NULL DEFAULT 0,
+			 ADD COLUMN flapmilli BIGINT NOT NULL DEFAULT 0`,
+			`ALTER TABLE pfx_subprobe_statuses
+			 ADD COLUMN flapping BOOLEAN NOT NULL DEFAULT FALSE`,
+		},
+	},
+	{
+		// The alert outbox.
+		version: 5,
+		newTables: []schemaTable{
+			{
+				name: "alert_outbox",
+				rowsAndKeys: []string{
+					"outboxid BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY",
+					"monitorid INTEGER UNSIGNED NOT NULL",
+					"subprobeid INTEGER UNSIGNED NOT NULL",
+					"targettype SMALLINT NOT NULL",
+					"alert TEXT NOT NULL",
+					"triggerids TEXT NOT NULL",
+					"inactivetriggerids TEXT NOT NULL",
+					"created DATETIME NOT NULL",
+					"attempts INTEGER NOT NULL DEFAULT 0",
+					"nextattempt DATETIME NOT NULL",
+					"lasterror TEXT NOT NULL",
+					"failed DATETIME DEFAULT NULL",
+					"KEY idx_failed_nextattempt (failed, nextattempt)",
+				},
+			},
+		},
+	},
+	{
+		// Trigger last alert times.
+		version: 6,
+		newTables: []schemaTable{
+			{
+				name: "trigger_last_alerts",
+				rowsAndKeys: []string{
+					"triggerid INTEGER UNSIGNED NOT NULL",
+					"subprobeid INTEGER UNSIGNED NOT NULL",
+					"lastalert DATETIME NOT NULL",
+					"PRIMARY KEY (subprobeid, triggerid)",
+					"CONSTRAINT nodbpfx_trigger_last_alerts_fk_triggerid FOREIGN KEY (triggerid) REFERENCES pfx_triggers (triggerid) ON DELETE CASCADE",
+					"CONSTRAINT nodbpfx_trigger_last_alerts_fk_subprobeid FOREIGN KEY (subprobeid) REFERENCES pfx_subprobes (subprobeid) ON DELETE CASCADE",
+				},
+			},
+		},
+	},
+	{
+		// Acknowledgements.
+		version: 7,
+		newTables: []schemaTable{
+			{
+				name: "subprobe_acks",
+				rowsAndKeys: []string{
+					"subprobeid INTEGER UNSIGNED NOT NULL PRIMARY KEY",
+					"state TINYINT NOT NULL",
+					"ackedby VARCHAR(100) NOT NULL",
+					"acked DATETIME NOT NULL",
+					"CONSTRAINT nodbpfx_subprobe_acks_fk_subprobeid FOREIGN KEY (subprobeid) REFERENCES pfx_subprobes (subprobeid) ON DELETE CASCADE",
+				},
+			},
+		},
+	},
+	{
+		// Escalation policies.
+		version: 8,
+		newTables: []schemaTable{
+			{
+				name: "escalation_policies",
+				rowsAndKeys: []string{
+					"policyid INTEGER UNSIGNED AUTO_INCREMENT PRIMARY KEY",
+					"name VARCHAR(30) NOT NULL",
+					"description TEXT NOT NULL",
+				},
+			},
+			{
+				name: "escalation_steps",
+				rowsAndKeys: []string{
+					"policyid INTEGER UNSIGNED NOT NULL",
+					"delaymilli BIGINT NOT NULL",
+					"triggerid INTEGER UNSIGNED NOT NULL",
+					"PRIMARY KEY (policyid, triggerid)",
+					"UNIQUE KEY idx_triggerid (triggerid)",
+					"CONSTRAINT nodbpfx_escalation_steps_fk_policyid FOREIGN KEY (policyid) REFERENCES pfx_escalation_policies (policyid) ON DELETE CASCADE",
+					"CONSTRAINT nodbpfx_escalation_steps_fk_triggerid FOREIGN KEY (triggerid) REFERENCES pfx_triggers (triggerid) ON DELETE CASCADE",
+				},
+			},
+		},
+	},
+	{
+		// On-call schedules.
+		version: 9,
+		newTables: []schemaTable{
+			{
+				name: "schedules",
+				rowsAndKeys: []string{
+					"scheduleid INTEGER UNSIGNED AUTO_INCREMENT PRIMARY KEY",
+					"name VARCHAR(30) NOT NULL",
+					"description TEXT NOT NULL",
+				},
+			},
+			{
+				name: "schedule_layers",
+				rowsAndKeys: []string{
+					"layerid INTEGER UNSIGNED AUTO_INCREMENT PRIMARY KEY",
+					"scheduleid INTEGER UNSIGNED NOT NULL",
+					"start DATETIME NOT NULL",
+					"rotationmilli BIGINT NOT NULL",
+					"members TEXT NOT NULL",
+					"CONSTRAINT nodbpfx_schedule_layers_fk_scheduleid FOREIGN KEY (scheduleid) REFERENCES pfx_schedules (scheduleid) ON DELETE CASCADE",
+				},
+			},
+			{
+				name: "schedule_overrides",
+				rowsAndKeys: []string{
+					"overrideid INTEGER UNSIGNED AUTO_INCREMENT PRIMARY KEY",
+					"scheduleid INTEGER UNSIGNED NOT NULL",
+					"email VARCHAR(255) NOT NULL",
+					"start DATETIME NOT NULL",
+					"end DATETIME NOT NULL",
+					"KEY idx_scheduleid_end (scheduleid, end)",
+					"CONSTRAINT nodbpfx_schedule_overrides_fk_scheduleid FOREIGN KEY (scheduleid) REFERENCES pfx_schedules (scheduleid) ON DELETE CASCADE",
+				},
+			},
+		},
+	},
+	{
+		// Slack threads.
+		version: 10,
+		newTables: []schemaTable{
+			{
+				name: "slack_threads",
+				rowsAndKeys: []string{
+					"subprobeid INTEGER UNSIGNED NOT NULL",
+					"channel VARCHAR(255) NOT NULL",
+					"channelid VARCHAR(255) NOT NULL",
+					"ts VARCHAR(32) NOT NULL",
+					"lastnormal DATETIME NOT NULL",
+					"PRIMARY KEY (subprobeid, channel)",
+					"CONSTRAINT nodbpfx_slack_threads_fk_subprobeid FOREIGN KEY (subprobeid) REFERENCES pfx_subprobes (subprobeid) ON DELETE CASCADE",
+				},
+			},
+		},
+	},
+	{
+		// Jira issues.
+		version: 11,
+		newTables: []schemaTable{
+			{
+				name: "jira_issues",
+				rowsAndKeys: []string{
+					"subprobeid INTEGER UNSIGNED NOT NULL",
+					"project VARCHAR(255) NOT NULL",
+					"issuekey VARCHAR(255) NOT NULL",
+					"created DATETIME NOT NULL",
+					"PRIMARY KEY (subprobeid, project)",
+					"CONSTRAINT nodbpfx_jira_issues_fk_subprobeid FOREIGN KEY (subprobeid) REFERENCES pfx_subprobes (subprobeid) ON DELETE CASCADE",
+				},
+			},
+		},
+	},
+	{
+		// Alert grouping windows.
+		version: 12,
+		queries: []string{
+			`ALTER TABLE pfx_triggers
+			 ADD COLUMN groupmilli BIGINT NOT NULL DEFAULT 0`,
+		},
+	},
+	{
+		// Monitor dependencies.
+		version: 13,
+		newTables: []schemaTable{
+			{
+				name: "monitor_dependencies",
+				rowsAndKeys: []string{
+					"monitorid INTEGER UNSIGNED NOT NULL",
+					"parentid INTEGER UNSIGNED NOT NULL",
+					"subprobes TEXT NOT NULL",
+					fmt.Sprintf("level TINYINT NOT NULL DEFAULT %d", state.Critical),
+					"PRIMARY KEY (monitorid, parentid)",
+					"KEY idx_parentid (parentid)",
+					"CONSTRAINT nodbpfx_monitor_dependencies_fk_monitorid FOREIGN KEY (monitorid) REFERENCES pfx_monitors (monitorid) ON DELETE CASCADE",
+					"CONSTRAINT nodbpfx_monitor_dependencies_fk_parentid FOREIGN KEY (parentid) REFERENCES pfx_monitors (monitorid) ON DELETE CASCADE",
+				},
+			},
+		},
+		queries: []string{
+			`ALTER TABLE pfx_subprobe_statuses
+			 ADD COLUMN suppressedby INTEGER UNSIGNED DEFAULT NULL`,
+		},
+	},
+	{
+		// Escalation incident starts.
+		version: 14,
+		newTables: []schemaTable{
+			{
+				name: "escalation_incidents",
+				rowsAndKeys: []string{
+					"triggerid INTEGER UNSIGNED NOT NULL",
+					"subprobeid INTEGER UNSIGNED NOT NULL",
+					"incidentstart DATETIME NOT NULL",
+					"PRIMARY KEY (subprobeid, triggerid)",
+					"CONSTRAINT nodbpfx_escalation_incidents_fk_triggerid FOREIGN KEY (triggerid) REFERENCES pfx_triggers (triggerid) ON DELETE CASCADE",
+					"CONSTRAINT nodbpfx_escalation_incidents_fk_subprobeid FOREIGN KEY (subprobeid) REFERENCES pfx_subprobes (subprobeid) ON DELETE CASCADE",
+				},
+			},
+		},
+	},
+}
+
+// SchemaVersion is the schema version this Revere needs.
+func SchemaVersion() int {
+	if len(schemaMigrations) == 0 {
+		return 1
+	}
+	return schemaMigrations[len(schemaMigrations)-1].version
+}
+
+// LoadSchemaVersion loads the version of the database's schema, which is 0
+// if it hasn't been initialized. A migration that didn't complete is an
+// error, since its changes need to be finished or undone by hand.
+func (db *DB) LoadSchemaVersion() (int, error) {
+	var history struct {
+		Version            int
+		MigrationCompleted sql.NullTime
+	}
+	q := `SELECT version, migrationcompleted FROM pfx_schema_history
+	      ORDER BY version DESC LIMIT 1`
+	err := db.Get(&history, cq(db, q))
+	if mysqlErr, ok := err.(*mysql.MySQLError); ok && mysqlErr.Number == mysqlNoSuchTable {
+		return 0, nil
+	}
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	if !history.MigrationCompleted.Valid {
+		return 0, errors.Errorf("migration to schema version %d did not complete", history.Version)
+	}
+	return history.Version, nil
+}
+
+// migrate brings the schema from version up to the latest one, recording
+// each migration in the schema history.
+func (db *DB) migrate(version int) error {
+	for _, m := range schemaMigrations {
+		if m.version <= version {
+			continue
+		}
+		log.WithField("version", m.version).Info("Migrating database schema.")
+
+		q := `INSERT INTO pfx_schema_history (version, migrationstarted)
+		      VALUES (?, UTC_TIMESTAMP())`
+		if _, err := db.Exec(cq(db, q), m.version); err != nil {
+			return errors.Maskf(err, "start migration to version %d", m.version)
+		}
+
+		for _, table := range m.newTables {
+			if err := db.createTable(table.name, table.rowsAndKeys); err != nil {
+				return errors.Maskf(err, "migrate to version %d", m.version)
+			}
+		}
+		for i, query := range m.queries {
+			if _, err := db.Exec(cq(db, query)); err != nil {
+				return errors.Maskf(err, "migrate to version %d: run query %d", m.version, i)
+			}
+		}
+
+		q = `UPDATE pfx_schema_history SET migrationcompleted = UTC_TIMESTAMP()
+		     WHERE version = ?`
+		if _, err := db.Exec(cq(db, q), m.version); err != nil {
+			return errors.Maskf(err, "finish migration to version %d", m.version)
+		}
+	}
+	return nil
+}
@@ -23,6 +23,17 @@ type Monitor struct {
 	Changed     time.Time
 	Version     int32
 	Archived    *time.Time
+
+	// ConfirmReadings and ConfirmMilli are how many consecutive readings
+	// and how long a subprobe must be in a worse state before it is
+	// confirmed to be in it. Until then, the state is pending.
+	ConfirmReadings int16
+	ConfirmMilli    int64
+
+	// FlapChanges and FlapMilli are how many state changes within how long
+	// mark a subprobe as flapping. Zero disables flap detection.
+	FlapChanges int16
+	FlapMilli   int64
 }
 
 type MonitorTrigger struct {
@@ -44,8 +55,8 @@ type MonitorVersionInfo struct {
 }
 
 func (tx *Tx) CreateMonitor(m *Monitor) (MonitorID, error) {
-	q := `INSERT INTO pfx_monitors (name, owner, description, response, probetype, probe, changed, version, archived)
-		VALUES (:name, :owner, :description, :response, :probetype, :probe, NOW(), 1, :archived)`
+	q := `INSERT INTO pfx_monitors (name, owner, description, response, probetype, probe, changed, version, archived, confirmreadings, confirmmilli, flapchanges, flapmilli)
+		VALUES (:name, :owner, :description, :response, :probetype, :probe, NOW(), 1, :archived, :confirmreadings, :confirmmilli, :flapchanges, :flapmilli)`
 	result, err := tx.NamedExec(cq(tx, q), m)
 	if err != nil {
 		return 0, errors.Trace(err)
@@ -68,7 +79,11 @@ func (tx *Tx) UpdateMonitor(m *Monitor) error {
 	          probe=:probe,
 	          changed=NOW(),
 	          version=version+1,
-	          archived=:archived
+	          archived=:archived,
+	          confirmreadings=:confirmreadings,
+	          confirmmilli=:confirmmilli,
+	          flapchanges=:flapchanges,
+	          flapmilli=:flapmilli
 	      WHERE monitorid=:monitorid`
 	_, err := tx.NamedExec(cq(tx, q), m)
 	return errors.Trace(err)
@@ -143,6 +158,34 @@ func loadMonitors(dt dbOrTx) ([]*Monitor, error) {
 	return monitors, nil
 }
 
+// LoadActiveProbesOfType loads the configuration of every probe of the given
+// type belonging to a monitor that isn't archived.
+func (db *DB) LoadActiveProbesOfType(probeType ProbeType) ([]types.JSONText, error) {
+	var probes []types.JSONText
+	q := `SELECT probe FROM pfx_monitors WHERE probetype = ? AND archived IS NULL`
+	if err := db.Select(&probes, cq(db, q), probeType); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return probes, nil
+}
+
+// MonitorProbe is the probe configuration of a monitor.
+type MonitorProbe struct {
+	MonitorID MonitorID
+	Probe     types.JSONText
+}
+
+// LoadActiveMonitorProbesOfType loads the probe configuration of every monitor
+// that isn't archived with a probe of the given type.
+func (db *DB) LoadActiveMonitorProbesOfType(probeType ProbeType) ([]MonitorProbe, error) {
+	var probes []MonitorProbe
+	q := `SELECT monitorid, probe FROM pfx_monitors WHERE probetype = ? AND archived IS NULL`
+	if err := db.Select(&probes, cq(db, q), probeType); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return probes, nil
+}
+
 func (tx *Tx) LoadMonitorsWithLabel(id LabelID) ([]*Monitor, error) {
 	var monitors []*Monitor
 	err := tx.Select(&monitors, cq(tx, `
@@ -26,6 +26,25 @@ func (db *DB) LoadReadings(subprobeID SubprobeID) ([]*Reading, error) {
 	return readings, nil
 }
 
+// LoadReadingsForMonitorSince loads the readings of the monitor's subprobes
+// recorded at or after since, oldest first, by subprobe.
+func (tx *Tx) LoadReadingsForMonitorSince(id MonitorID, since time.Time) (map[SubprobeID][]*Reading, error) {
+	var readings []*Reading
+	q := `SELECT r.* FROM pfx_readings r
+	      JOIN pfx_subprobes s USING (subprobeid)
+	      WHERE s.monitorid = ? AND r.recorded >= ?
+	      ORDER BY r.recorded`
+	if err := tx.Select(&readings, cq(tx, q), id, since); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := make(map[SubprobeID][]*Reading)
+	for _, r := range readings {
+		result[r.SubprobeID] = append(result[r.SubprobeID], r)
+	}
+	return result, nil
+}
+
 func (tx *Tx) InsertReading(r Reading) error {
 	q := `INSERT INTO pfx_readings (subprobeid, recorded, state)
 	      VALUES (:subprobeid, :recorded, :state)`
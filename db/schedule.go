@@ -0,0 +1,207 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+)
+
+type ScheduleID int32
+
+type Schedule struct {
+	ScheduleID  ScheduleID
+	Name        string
+	Description string
+}
+
+type ScheduleLayerID int32
+
+// ScheduleLayer rotates through its members, handing off to the next one
+// every RotationMilli starting at Start. Members is a JSON array of email
+// addresses.
+type ScheduleLayer struct {
+	LayerID       ScheduleLayerID
+	ScheduleID    ScheduleID
+	Start         time.Time
+	RotationMilli int64
+	Members       types.JSONText
+}
+
+type ScheduleOverrideID int32
+
+// ScheduleOverride puts Email on call for its schedule from Start until End,
+// in place of whoever the layers would put on call.
+type ScheduleOverride struct {
+	OverrideID ScheduleOverrideID
+	ScheduleID ScheduleID
+	Email      string
+	Start      time.Time
+	End        time.Time
+}
+
+func (db *DB) LoadSchedule(id ScheduleID) (*Schedule, error) {
+	return loadSchedule(db, id)
+}
+
+func (tx *Tx) LoadSchedule(id ScheduleID) (*Schedule, error) {
+	return loadSchedule(tx, id)
+}
+
+func loadSchedule(dt dbOrTx, id ScheduleID) (*Schedule, error) {
+	dt = unsafe(dt)
+
+	var s Schedule
+	q := `SELECT * FROM pfx_schedules WHERE scheduleid = ?`
+	err := dt.Get(&s, cq(dt, q), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Trace(err)
+	}
+	return &s, nil
+}
+
+func (db *DB) LoadSchedules() ([]*Schedule, error) {
+	return loadSchedules(db)
+}
+
+func (tx *Tx) LoadSchedules() ([]*Schedule, error) {
+	return loadSchedules(tx)
+}
+
+func loadSchedules(dt dbOrTx) ([]*Schedule, error) {
+	dt = unsafe(dt)
+
+	var ss []*Schedule
+	q := `SELECT * FROM pfx_schedules ORDER BY name`
+	if err := dt.Select(&ss, cq(dt, q)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ss, nil
+}
+
+func (tx *Tx) CreateSchedule(s *Schedule) (ScheduleID, error) {
+	q := `INSERT INTO pfx_schedules (name, description)
+	      VALUES (:name, :description)`
+	result, err := tx.NamedExec(cq(tx, q), s)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return ScheduleID(id), nil
+}
+
+func (tx *Tx) UpdateSchedule(s *Schedule) error {
+	q := `UPDATE pfx_schedules
+	      SET name=:name, description=:description
+	      WHERE scheduleid=:scheduleid`
+	_, err := tx.NamedExec(cq(tx, q), s)
+	return errors.Trace(err)
+}
+
+func (db *DB) LoadScheduleLayers(id ScheduleID) ([]*ScheduleLayer, error) {
+	return loadScheduleLayers(db, id)
+}
+
+func (tx *Tx) LoadScheduleLayers(id ScheduleID) ([]*ScheduleLayer, error) {
+	return loadScheduleLayers(tx, id)
+}
+
+func loadScheduleLayers(dt dbOrTx, id ScheduleID) ([]*ScheduleLayer, error) {
+	dt = unsafe(dt)
+
+	var layers []*ScheduleLayer
+	q := `SELECT * FROM pfx_schedule_layers WHERE scheduleid = ? ORDER BY layerid`
+	if err := dt.Select(&layers, cq(dt, q), id); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return layers, nil
+}
+
+func (tx *Tx) CreateScheduleLayer(l *ScheduleLayer) (ScheduleLayerID, error) {
+	q := `INSERT INTO pfx_schedule_layers (scheduleid, start, rotationmilli, members)
+	      VALUES (:scheduleid, :start, :rotationmilli, :members)`
+	result, err := tx.NamedExec(cq(tx, q), l)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return ScheduleLayerID(id), nil
+}
+
+func (tx *Tx) UpdateScheduleLayer(l *ScheduleLayer) error {
+	q := `UPDATE pfx_schedule_layers
+	      SET start=:start, rotationmilli=:rotationmilli, members=:members
+	      WHERE layerid=:layerid AND scheduleid=:scheduleid`
+	_, err := tx.NamedExec(cq(tx, q), l)
+	return errors.Trace(err)
+}
+
+func (tx *Tx) DeleteScheduleLayer(id ScheduleLayerID) error {
+	q := `DELETE FROM pfx_schedule_layers WHERE layerid = ?`
+	_, err := tx.Exec(cq(tx, q), id)
+	return errors.Trace(err)
+}
+
+// LoadScheduleOverrides loads the schedule's overrides that end after t, in
+// the order they start.
+func (db *DB) LoadScheduleOverrides(id ScheduleID, t time.Time) ([]*ScheduleOverride, error) {
+	return loadScheduleOverrides(db, id, t)
+}
+
+func (tx *Tx) LoadScheduleOverrides(id ScheduleID, t time.Time) ([]*ScheduleOverride, error) {
+	return loadScheduleOverrides(tx, id, t)
+}
+
+func loadScheduleOverrides(dt dbOrTx, id ScheduleID, t time.Time) ([]*ScheduleOverride, error) {
+	dt = unsafe(dt)
+
+	var overrides []*ScheduleOverride
+	q := `SELECT * FROM pfx_schedule_overrides
+	      WHERE scheduleid = ? AND end > ?
+	      ORDER BY start, overrideid`
+	if err := dt.Select(&overrides, cq(dt, q), id, t.UTC()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return overrides, nil
+}
+
+func (tx *Tx) CreateScheduleOverride(o *ScheduleOverride) (ScheduleOverrideID, error) {
+	q := `INSERT INTO pfx_schedule_overrides (scheduleid, email, start, end)
+	      VALUES (:scheduleid, :email, :start, :end)`
+	result, err := tx.NamedExec(cq(tx, q), o)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return ScheduleOverrideID(id), nil
+}
+
+func (tx *Tx) UpdateScheduleOverride(o *ScheduleOverride) error {
+	q := `UPDATE pfx_schedule_overrides
+	      SET email=:email, start=:start, end=:end
+	      WHERE overrideid=:overrideid AND scheduleid=:scheduleid`
+	_, err := tx.NamedExec(cq(tx, q), o)
+	return errors.Trace(err)
+}
+
+func (tx *Tx) DeleteScheduleOverride(id ScheduleOverrideID) error {
+	q := `DELETE FROM pfx_schedule_overrides WHERE overrideid = ?`
+	_, err := tx.Exec(cq(tx, q), id)
+	return errors.Trace(err)
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// SlackThread records the Slack message that started the thread for a
+// subprobe's current incident in a channel. Channel is the channel as
+// configured on the target, while ChannelID is Slack's ID for it, which
+// updating the message needs.
+type SlackThread struct {
+	SubprobeID SubprobeID
+	Channel    string
+	ChannelID  string
+	TS         string
+	LastNormal time.Time
+}
+
+// LoadSlackThread returns the thread for a subprobe in channel, or nil if there
+// is none.
+func (db *DB) LoadSlackThread(subprobeID SubprobeID, channel string) (*SlackThread, error) {
+	var t SlackThread
+	q := `SELECT * FROM pfx_slack_threads WHERE subprobeid = ? AND channel = ?`
+	err := db.Get(&t, cq(db, q), subprobeID, channel)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &t, nil
+}
+
+// SaveSlackThread records t, replacing any earlier thread for the subprobe in
+// the channel.
+func (db *DB) SaveSlackThread(t SlackThread) error {
+	q := `INSERT INTO pfx_slack_threads (subprobeid, channel, channelid, ts, lastnormal)
+	      VALUES (:subprobeid, :channel, :channelid, :ts, :lastnormal)
+	      ON DUPLICATE KEY UPDATE
+	        channelid = VALUES(channelid), ts = VALUES(ts), lastnormal = VALUES(lastnormal)`
+	_, err := db.NamedExec(cq(db, q), t)
+	return errors.Trace(err)
+}
+
+func (db *DB) DeleteSlackThread(subprobeID SubprobeID, channel string) error {
+	q := `DELETE FROM pfx_slack_threads WHERE subprobeid = ? AND channel = ?`
+	_, err := db.Exec(cq(db, q), subprobeID, channel)
+	return errors.Trace(err)
+}
@@ -24,6 +24,16 @@ type SubprobeWithStatusInfo struct {
 	Name        string
 	Archived    *time.Time
 	*SubprobeStatus
+
+	// AckedState, AckedBy and Acked are set if the subprobe has been
+	// acknowledged. The acknowledgement is only current if AckedState is
+	// the subprobe's state.
+	AckedState *state.State
+	AckedBy    *string
+	Acked      *time.Time
+
+	// SuppressedByName is the name of the monitor in SuppressedBy.
+	SuppressedByName *string
 }
 
 func (db *DB) LoadSubprobe(subprobeID SubprobeID) (*Subprobe, error) {
@@ -73,11 +83,30 @@ func (tx *Tx) LoadSubprobesBySeverityForLabel(labelID LabelID) ([]*SubprobeWithS
 		ORDER BY ss.state DESC, ss.enteredstate, s.name`, state.Normal, labelID))
 }
 
+// LoadPendingSubprobes loads the subprobes with pending states that are not
+// yet confirmed, most severe first.
+func (tx *Tx) LoadPendingSubprobes() ([]*SubprobeWithStatusInfo, error) {
+	return loadSubprobesWithStatus(tx,
+		`WHERE ss.pendingstate > ss.state
+		ORDER BY ss.pendingstate DESC, ss.pendingsince, s.name`)
+}
+
+func (tx *Tx) LoadPendingSubprobesForLabel(labelID LabelID) ([]*SubprobeWithStatusInfo, error) {
+	return loadSubprobesWithStatus(tx, fmt.Sprintf(
+		`JOIN pfx_labels_monitors lm USING (monitorid)
+		WHERE ss.pendingstate > ss.state AND lm.labelid = %d
+		ORDER BY ss.pendingstate DESC, ss.pendingsince, s.name`, labelID))
+}
+
 func loadSubprobesWithStatus(dt dbOrTx, condition string) ([]*SubprobeWithStatusInfo, error) {
 	var subprobes []*SubprobeWithStatusInfo
 	q := fmt.Sprintf(
-		`SELECT s.monitorid, s.name, s.archived, ss.*, m.name AS monitorname FROM pfx_subprobes s
+		`SELECT s.monitorid, s.name, s.archived, ss.*, m.name AS monitorname,
+		   a.state AS ackedstate, a.ackedby, a.acked,
+		   (SELECT name FROM pfx_monitors WHERE monitorid = ss.suppressedby) AS suppressedbyname
+		 FROM pfx_subprobes s
 	     LEFT JOIN pfx_subprobe_statuses ss ON s.subprobeid = ss.subprobeid
+		 LEFT JOIN pfx_subprobe_acks a ON s.subprobeid = a.subprobeid
 		 JOIN pfx_monitors m USING (monitorid)
 		 %s`, condition)
 	err := dt.Select(&subprobes, cq(dt, q))
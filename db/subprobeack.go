@@ -0,0 +1,51 @@
+package db
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/state"
+)
+
+// SubprobeAck records that someone is handling a subprobe's problem. It only
+// applies while the subprobe stays in State.
+type SubprobeAck struct {
+	SubprobeID SubprobeID
+	State      state.State
+	AckedBy    string
+	Acked      time.Time
+}
+
+func (db *DB) LoadSubprobeAcksForMonitor(id MonitorID) (map[SubprobeID]SubprobeAck, error) {
+	var acks []SubprobeAck
+	q := `SELECT a.* FROM pfx_subprobe_acks a
+	      JOIN pfx_subprobes s USING (subprobeid)
+	      WHERE s.monitorid = ?`
+	if err := db.Select(&acks, cq(db, q), id); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := make(map[SubprobeID]SubprobeAck, len(acks))
+	for _, a := range acks {
+		result[a.SubprobeID] = a
+	}
+	return result, nil
+}
+
+// SaveSubprobeAck records a, replacing any earlier acknowledgement of the
+// subprobe.
+func (tx *Tx) SaveSubprobeAck(a SubprobeAck) error {
+	q := `INSERT INTO pfx_subprobe_acks (subprobeid, state, ackedby, acked)
+	      VALUES (:subprobeid, :state, :ackedby, :acked)
+	      ON DUPLICATE KEY UPDATE
+	        state = VALUES(state), ackedby = VALUES(ackedby), acked = VALUES(acked)`
+	_, err := tx.NamedExec(cq(tx, q), a)
+	return errors.Trace(err)
+}
+
+func (db *DB) DeleteSubprobeAck(id SubprobeID) error {
+	q := `DELETE FROM pfx_subprobe_acks WHERE subprobeid = ?`
+	_, err := db.Exec(cq(db, q), id)
+	return errors.Trace(err)
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"time"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/juju/errors"
 
 	"github.com/yext/revere/state"
@@ -15,6 +16,21 @@ type SubprobeStatus struct {
 	Silenced     bool
 	EnteredState time.Time
 	LastNormal   time.Time
+
+	// PendingState is a state worse than State that the subprobe has been
+	// reading since PendingSince, for PendingReadings readings, but that is
+	// not yet confirmed. It is not pending unless it is worse than State.
+	PendingState    state.State
+	PendingSince    *time.Time
+	PendingReadings int16
+
+	// Flapping is whether the subprobe is changing state too often, which
+	// suppresses alerts on its state changes.
+	Flapping bool
+
+	// SuppressedBy is the monitor the subprobe's monitor depends on whose
+	// state suppressed its alerts as of its last reading, if any.
+	SuppressedBy *MonitorID
 }
 
 func (db *DB) LoadSubprobeStatusesForMonitor(id MonitorID) (map[string]SubprobeStatus, error) {
@@ -56,14 +72,24 @@ func (tx *Tx) InsertSubprobeStatus(s SubprobeStatus) error {
 	        state,
 	        silenced,
 	        enteredstate,
-	        lastnormal
+	        lastnormal,
+	        pendingstate,
+	        pendingsince,
+	        pendingreadings,
+	        flapping,
+	        suppressedby
 	      ) VALUES (
 	        :subprobeid,
 		:recorded,
 		:state,
 		:silenced,
 		:enteredstate,
-		:lastnormal
+		:lastnormal,
+		:pendingstate,
+		:pendingsince,
+		:pendingreadings,
+		:flapping,
+		:suppressedby
 	      )`
 	_, err := tx.NamedExec(cq(tx, q), s)
 	if err != nil {
@@ -79,7 +105,12 @@ func (tx *Tx) UpdateSubprobeStatus(s SubprobeStatus) error {
 	          state = :state,
 	          silenced = :silenced,
 	          enteredstate = :enteredstate,
-	          lastnormal = :lastnormal
+	          lastnormal = :lastnormal,
+	          pendingstate = :pendingstate,
+	          pendingsince = :pendingsince,
+	          pendingreadings = :pendingreadings,
+	          flapping = :flapping,
+	          suppressedby = :suppressedby
 	      WHERE subprobeid = :subprobeid`
 	result, err := tx.NamedExec(cq(tx, q), s)
 	if err != nil {
@@ -110,3 +141,39 @@ func (tx *Tx) UpdateSubprobeStatus(s SubprobeStatus) error {
 	// TODO(eefi): Try to fix the DB by inserting a new row?
 	return errors.Errorf("no status row for subprobe with ID %d", s.SubprobeID)
 }
+
+// MonitorState summarizes the current status of a monitor as the worst state
+// of its active, unsilenced subprobes.
+type MonitorState struct {
+	MonitorID MonitorID
+	Name      string
+	State     state.State
+}
+
+// LoadMonitorStates loads the current state of each of the given monitors
+// that exists and is not archived.
+func (db *DB) LoadMonitorStates(ids []MonitorID) ([]*MonitorState, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var states []*MonitorState
+	q, args, err := sqlx.In(`
+		SELECT m.monitorid, m.name, COALESCE(MAX(ss.state), 0) AS state
+		FROM pfx_monitors m
+		LEFT JOIN pfx_subprobes s
+		  ON s.monitorid = m.monitorid AND s.archived IS NULL
+		LEFT JOIN pfx_subprobe_statuses ss
+		  ON ss.subprobeid = s.subprobeid AND NOT ss.silenced
+		WHERE m.monitorid IN (?) AND m.archived IS NULL
+		GROUP BY m.monitorid, m.name
+		ORDER BY m.name`, ids)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if err := db.Select(&states, cq(db, q), args...); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return states, nil
+}
@@ -15,13 +15,14 @@ type Trigger struct {
 	Level         state.State
 	TriggerOnExit bool
 	PeriodMilli   int32
+	GroupMilli    int64
 	TargetType    TargetType
 	Target        types.JSONText
 }
 
 func (tx *Tx) createTrigger(t *Trigger) (TriggerID, error) {
-	q := `INSERT INTO pfx_triggers (level, triggeronexit, periodmilli, targettype, target)
-	      VALUES (:level, :triggeronexit, :periodmilli, :targettype, :target)`
+	q := `INSERT INTO pfx_triggers (level, triggeronexit, periodmilli, groupmilli, targettype, target)
+	      VALUES (:level, :triggeronexit, :periodmilli, :groupmilli, :targettype, :target)`
 	result, err := tx.NamedExec(cq(tx, q), t)
 	if err != nil {
 		return 0, errors.Trace(err)
@@ -39,6 +40,7 @@ func (tx *Tx) updateTrigger(t *Trigger) error {
 	      SET level=:level,
 	          triggeronexit=:triggeronexit,
 	          periodmilli=:periodmilli,
+	          groupmilli=:groupmilli,
 	          targettype=:targettype,
 	          target=:target
 	      WHERE triggerid=:triggerid`
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// TriggerLastAlert records when a trigger last alerted for a subprobe, so
+// repeat alerts keep to the trigger's period across daemon restarts.
+type TriggerLastAlert struct {
+	TriggerID  TriggerID
+	SubprobeID SubprobeID
+	LastAlert  time.Time
+}
+
+func (tx *Tx) LoadTriggerLastAlertsForMonitor(id MonitorID) (map[SubprobeID]map[TriggerID]time.Time, error) {
+	var lastAlerts []TriggerLastAlert
+	q := `SELECT tla.triggerid, tla.subprobeid, tla.lastalert
+	      FROM pfx_trigger_last_alerts tla
+	      JOIN pfx_subprobes s USING (subprobeid)
+	      WHERE s.monitorid = ?`
+	if err := tx.Select(&lastAlerts, cq(tx, q), id); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := make(map[SubprobeID]map[TriggerID]time.Time)
+	for _, la := range lastAlerts {
+		if result[la.SubprobeID] == nil {
+			result[la.SubprobeID] = make(map[TriggerID]time.Time)
+		}
+		result[la.SubprobeID][la.TriggerID] = la.LastAlert
+	}
+	return result, nil
+}
+
+// SaveTriggerLastAlerts records that the given triggers alerted for a
+// subprobe at t.
+func (db *DB) SaveTriggerLastAlerts(subprobeID SubprobeID, triggerIDs []TriggerID, t time.Time) error {
+	if len(triggerIDs) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(triggerIDs))
+	args := make([]interface{}, 0, 3*len(triggerIDs))
+	for i, id := range triggerIDs {
+		values[i] = "(?, ?, ?)"
+		args = append(args, id, subprobeID, t)
+	}
+
+	q := `INSERT INTO pfx_trigger_last_alerts (triggerid, subprobeid, lastalert)
+	      VALUES ` + strings.Join(values, ", ") + `
+	      ON DUPLICATE KEY UPDATE lastalert = VALUES(lastalert)`
+	_, err := db.Exec(cq(db, q), args...)
+	return errors.Trace(err)
+}
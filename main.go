@@ -46,6 +46,7 @@ import (
 	"golang.org/x/sys/unix"
 
 	"github.com/yext/revere/daemon"
+	"github.com/yext/revere/db"
 	"github.com/yext/revere/env"
 	"github.com/yext/revere/web/server"
 )
@@ -80,6 +81,9 @@ func main() {
 		return
 	}
 
+	err = checkSchemaVersion(env)
+	ifErrPrintAndExit(err)
+
 	for _, mode := range modes {
 		switch mode {
 		case "daemon":
@@ -127,6 +131,18 @@ func loadEnv() (*env.Env, error) {
 	return env, nil
 }
 
+func checkSchemaVersion(env *env.Env) error {
+	version, err := env.DB.LoadSchemaVersion()
+	if err != nil {
+		return errors.Mask(err)
+	}
+	if version < db.SchemaVersion() {
+		return errors.Errorf("database schema is at version %d, but version %d is needed; run with -mode initdb to upgrade it",
+			version, db.SchemaVersion())
+	}
+	return nil
+}
+
 func parseMode() ([]string, error) {
 	modes := make(map[string]bool)
 	for _, m := range strings.Split(*mode, ",") {
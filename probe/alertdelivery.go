@@ -0,0 +1,77 @@
+package probe
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+)
+
+// AlertDelivery implements a probe that reports on Revere itself, entering a
+// configured state while alerts that Revere gave up delivering are recent. It
+// reports a single subprobe.
+type AlertDelivery struct {
+	*Polling
+
+	db           *db.DB
+	window       time.Duration
+	failureState state.State
+}
+
+// alertDeliverySubprobe is the name of the single subprobe alert delivery
+// probes report.
+const alertDeliverySubprobe = "delivery"
+
+func newAlertDelivery(tx *db.Tx, configJSON types.JSONText, readingsSink chan<- []Reading) (Probe, error) {
+	a := AlertDelivery{}
+
+	var config AlertDeliveryDBModel
+	err := configJSON.Unmarshal(&config)
+	if err != nil {
+		return nil, errors.Maskf(err, "deserialize probe config")
+	}
+
+	checkPeriod := time.Duration(config.CheckPeriodMilli) * time.Millisecond
+	a.Polling, err = NewPolling(checkPeriod, &a, readingsSink)
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+
+	a.window = time.Duration(config.WindowMilli) * time.Millisecond
+	if a.window <= 0 {
+		return nil, errors.Errorf("invalid window: %s", a.window)
+	}
+
+	if !isValidAlertDeliveryFailureState(config.FailureState) {
+		return nil, errors.Errorf("invalid failure state: %s", config.FailureState)
+	}
+	a.failureState = config.FailureState
+
+	a.db = tx.DB()
+	return &a, nil
+}
+
+func (a *AlertDelivery) Check() []Reading {
+	now := time.Now()
+
+	counts, err := a.db.CountFailedOutboxAlertsSince(now.Add(-a.window))
+	if err != nil {
+		log.WithError(err).Error("Could not count failed alert deliveries.")
+
+		return []Reading{{alertDeliverySubprobe, state.Unknown, now, nil}}
+	}
+
+	s := state.Normal
+	if len(counts) > 0 {
+		s = a.failureState
+	}
+
+	return []Reading{{alertDeliverySubprobe, s, now, alertDeliveryDetails{
+		window: a.window,
+		counts: counts,
+	}}}
+}
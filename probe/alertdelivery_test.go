@@ -0,0 +1,78 @@
+package probe_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/yext/revere/probe"
+	"github.com/yext/revere/state"
+	"github.com/yext/revere/test"
+)
+
+var (
+	alertDeliveryId        = 8
+	alertDeliveryName      = "Alert Delivery"
+	alertDeliveryProbeType = AlertDeliveryType{}
+	validAlertDeliveryJson = test.DefaultAlertDeliveryProbeJson
+)
+
+func validAlertDeliveryProbe() (*AlertDeliveryProbe, error) {
+	probe, err := LoadFromParams(alertDeliveryProbeType.Id(), validAlertDeliveryJson)
+	if err != nil {
+		return nil, err
+	}
+
+	alertDeliveryProbe, ok := probe.(AlertDeliveryProbe)
+	if !ok {
+		return nil, fmt.Errorf("Invalid probe loaded for probe type: %s\n", alertDeliveryProbeType.Name())
+	}
+
+	return &alertDeliveryProbe, nil
+}
+
+func TestAlertDeliveryId(t *testing.T) {
+	if int(alertDeliveryProbeType.Id()) != alertDeliveryId {
+		t.Errorf("Expected alert delivery probe type id: %d, got %d\n", alertDeliveryId, alertDeliveryProbeType.Id())
+	}
+}
+
+func TestAlertDeliveryName(t *testing.T) {
+	if alertDeliveryProbeType.Name() != alertDeliveryName {
+		t.Errorf("Expected alert delivery probe type name: %s, got %s\n", alertDeliveryName, alertDeliveryProbeType.Name())
+	}
+}
+
+func TestValidAlertDelivery(t *testing.T) {
+	alertDeliveryProbe, err := validAlertDeliveryProbe()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if errs := alertDeliveryProbe.Validate(); errs != nil {
+		t.Errorf("Unexpected errors for valid alert delivery probe: %v\n", errs)
+	}
+}
+
+func TestInvalidAlertDelivery(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*AlertDeliveryProbe)
+	}{
+		{"window", func(a *AlertDeliveryProbe) { a.Window = 0 }},
+		{"normal failure state", func(a *AlertDeliveryProbe) { a.FailureState = state.Normal }},
+		{"unknown failure state", func(a *AlertDeliveryProbe) { a.FailureState = state.Unknown }},
+		{"check period", func(a *AlertDeliveryProbe) { a.CheckPeriod = 0 }},
+	}
+
+	for _, tt := range tests {
+		alertDeliveryProbe, err := validAlertDeliveryProbe()
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		tt.modify(alertDeliveryProbe)
+		if errs := alertDeliveryProbe.Validate(); errs == nil {
+			t.Errorf("Expected error for invalid %s\n", tt.name)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package probe
+
+import "github.com/yext/revere/state"
+
+// AlertDeliveryDBModel defines the JSON serialization format for saving alert
+// delivery probes' settings in the database.
+type AlertDeliveryDBModel struct {
+	WindowMilli      int64
+	FailureState     state.State
+	CheckPeriodMilli int64
+}
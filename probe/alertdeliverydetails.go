@@ -0,0 +1,33 @@
+package probe
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yext/revere/db"
+)
+
+type alertDeliveryDetails struct {
+	window time.Duration
+	counts map[db.TargetType]int
+}
+
+func (d alertDeliveryDetails) Text() string {
+	if len(d.counts) == 0 {
+		return fmt.Sprintf("No alerts failed permanently in the past %s", d.window)
+	}
+
+	targetTypes := make([]db.TargetType, 0, len(d.counts))
+	for t := range d.counts {
+		targetTypes = append(targetTypes, t)
+	}
+	sort.Slice(targetTypes, func(i, j int) bool { return targetTypes[i] < targetTypes[j] })
+
+	lines := []string{fmt.Sprintf("Alerts failed permanently in the past %s", d.window)}
+	for _, t := range targetTypes {
+		lines = append(lines, fmt.Sprintf("Target type %d: %d alert(s)", t, d.counts[t]))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,10 @@
+package probe
+
+import (
+	"github.com/jmoiron/sqlx/types"
+	"github.com/yext/revere/db"
+)
+
+func (AlertDeliveryType) New(tx *db.Tx, config types.JSONText, readingsSink chan<- []Reading) (Probe, error) {
+	return newAlertDelivery(tx, config, readingsSink)
+}
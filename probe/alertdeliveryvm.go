@@ -0,0 +1,140 @@
+package probe
+
+import (
+	"encoding/json"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+	"github.com/yext/revere/util"
+)
+
+type AlertDeliveryType struct{}
+
+type AlertDeliveryProbe struct {
+	AlertDeliveryType
+
+	Window          int64
+	WindowType      string
+	FailureState    state.State
+	CheckPeriod     int64
+	CheckPeriodType string
+}
+
+var validAlertDeliveryFailureStates = []state.State{
+	state.Warning,
+	state.Error,
+	state.Critical,
+}
+
+func init() {
+	registerProbeType(AlertDeliveryType{})
+}
+
+func (AlertDeliveryType) Id() db.ProbeType {
+	return 8
+}
+
+func (AlertDeliveryType) Name() string {
+	return "Alert Delivery"
+}
+
+func (AlertDeliveryType) loadFromParams(probe string) (VM, error) {
+	var a AlertDeliveryProbe
+	err := json.Unmarshal([]byte(probe), &a)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (AlertDeliveryType) loadFromDb(encodedProbe string, tx *db.Tx) (VM, error) {
+	var a AlertDeliveryDBModel
+	err := json.Unmarshal([]byte(encodedProbe), &a)
+	if err != nil {
+		return nil, err
+	}
+
+	window, windowType := util.GetPeriodAndType(a.WindowMilli)
+	checkPeriod, checkPeriodType := util.GetPeriodAndType(a.CheckPeriodMilli)
+
+	return &AlertDeliveryProbe{
+		Window:          window,
+		WindowType:      windowType,
+		FailureState:    a.FailureState,
+		CheckPeriod:     checkPeriod,
+		CheckPeriodType: checkPeriodType,
+	}, nil
+}
+
+func (AlertDeliveryType) blank() (VM, error) {
+	return &AlertDeliveryProbe{
+		Window:          1,
+		WindowType:      "hour",
+		FailureState:    state.Error,
+		CheckPeriod:     1,
+		CheckPeriodType: "minute",
+	}, nil
+}
+
+func (AlertDeliveryType) Templates() map[string]string {
+	return map[string]string{
+		"edit": "alert-delivery-edit.html",
+		"view": "alert-delivery-view.html",
+	}
+}
+
+func (AlertDeliveryType) Scripts() map[string][]string {
+	return map[string][]string{}
+}
+
+func (AlertDeliveryType) AcceptedResourceTypes() []db.ResourceType {
+	return []db.ResourceType{}
+}
+
+func (a AlertDeliveryProbe) HasResource(id db.ResourceID) bool {
+	return false
+}
+
+func (a AlertDeliveryProbe) SerializeForFrontend() map[string]string {
+	return map[string]string{}
+}
+
+func (a AlertDeliveryProbe) SerializeForDB() (string, error) {
+	aDB := AlertDeliveryDBModel{
+		WindowMilli:      util.GetMs(a.Window, a.WindowType),
+		FailureState:     a.FailureState,
+		CheckPeriodMilli: util.GetMs(a.CheckPeriod, a.CheckPeriodType),
+	}
+
+	aDBJSON, err := json.Marshal(aDB)
+	return string(aDBJSON), err
+}
+
+func (a AlertDeliveryProbe) Type() VMType {
+	return AlertDeliveryType{}
+}
+
+func (a AlertDeliveryProbe) Validate() (errs []string) {
+	if util.GetMs(a.Window, a.WindowType) <= 0 {
+		errs = append(errs, "Invalid window")
+	}
+
+	if !isValidAlertDeliveryFailureState(a.FailureState) {
+		errs = append(errs, "Invalid state for failed alerts")
+	}
+
+	if util.GetMs(a.CheckPeriod, a.CheckPeriodType) <= 0 {
+		errs = append(errs, "Invalid check period")
+	}
+
+	return
+}
+
+func isValidAlertDeliveryFailureState(s state.State) bool {
+	for _, vs := range validAlertDeliveryFailureStates {
+		if s == vs {
+			return true
+		}
+	}
+	return false
+}
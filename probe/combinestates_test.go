@@ -0,0 +1,66 @@
+package probe
+
+import (
+	"testing"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+)
+
+func monitorStates(states ...state.State) []*db.MonitorState {
+	ms := make([]*db.MonitorState, len(states))
+	for i, s := range states {
+		ms[i] = &db.MonitorState{MonitorID: db.MonitorID(i + 1), State: s}
+	}
+	return ms
+}
+
+func TestCombineStates(t *testing.T) {
+	for _, c := range []struct {
+		states   []*db.MonitorState
+		expected int
+		minCount int
+		state    state.State
+	}{
+		{monitorStates(state.Normal, state.Error, state.Warning), 3, 1, state.Error},
+		{monitorStates(state.Normal, state.Error, state.Warning), 3, 2, state.Warning},
+		{monitorStates(state.Normal, state.Error, state.Warning), 3, 3, state.Normal},
+		{monitorStates(state.Critical, state.Error), 2, 2, state.Error},
+
+		// Missing monitors make the rule impossible to apply.
+		{monitorStates(state.Error, state.Error), 3, 3, state.Unknown},
+		{monitorStates(state.Normal, state.Normal), 3, 1, state.Unknown},
+		{monitorStates(), 2, 1, state.Unknown},
+	} {
+		if s := combineStates(c.states, c.expected, c.minCount); s != c.state {
+			t.Errorf("Expected %s for %d of %d monitors at count %d, got %s\n",
+				c.state, len(c.states), c.expected, c.minCount, s)
+		}
+	}
+}
+
+func TestCompositeCycle(t *testing.T) {
+	combined := map[db.MonitorID][]db.MonitorID{
+		1: {2, 3},
+		2: {4},
+		3: {4, 5},
+		5: {6},
+	}
+
+	if compositeCycle(1, combined) {
+		t.Errorf("Expected no cycle for monitor 1\n")
+	}
+
+	combined[6] = []db.MonitorID{1}
+	if !compositeCycle(1, combined) {
+		t.Errorf("Expected a cycle through monitors 3, 5 and 6\n")
+	}
+	if compositeCycle(2, combined) {
+		t.Errorf("Expected no cycle for monitor 2\n")
+	}
+
+	combined[4] = []db.MonitorID{2}
+	if !compositeCycle(2, combined) {
+		t.Errorf("Expected a cycle between monitors 2 and 4\n")
+	}
+}
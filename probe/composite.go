@@ -0,0 +1,119 @@
+package probe
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+)
+
+// Composite implements a probe whose state is derived from the current states
+// of other monitors. It reports a single subprobe.
+type Composite struct {
+	*Polling
+
+	db         *db.DB
+	monitorIDs []db.MonitorID
+	rule       string
+
+	// minCount is how many monitors must be at least as bad as the reported
+	// state.
+	minCount int
+}
+
+// compositeSubprobe is the name of the single subprobe composite probes
+// report.
+const compositeSubprobe = "composite"
+
+// compositeRules maps rule names to descriptions for display.
+var compositeRules = map[string]string{
+	"any":   "worst state of any monitor",
+	"all":   "state all monitors are at least as bad as",
+	"count": "state at least N monitors are at least as bad as",
+}
+
+func newComposite(tx *db.Tx, configJSON types.JSONText, readingsSink chan<- []Reading) (Probe, error) {
+	c := Composite{}
+
+	var config CompositeDBModel
+	err := configJSON.Unmarshal(&config)
+	if err != nil {
+		return nil, errors.Maskf(err, "deserialize probe config")
+	}
+
+	checkPeriod := time.Duration(config.CheckPeriodMilli) * time.Millisecond
+	c.Polling, err = NewPolling(checkPeriod, &c, readingsSink)
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+
+	if len(config.MonitorIDs) == 0 {
+		return nil, errors.New("no monitors to combine")
+	}
+
+	c.db = tx.DB()
+	c.monitorIDs = make([]db.MonitorID, len(config.MonitorIDs))
+	for i, id := range config.MonitorIDs {
+		c.monitorIDs[i] = db.MonitorID(id)
+	}
+
+	c.rule = config.Rule
+	switch c.rule {
+	case "any":
+		c.minCount = 1
+	case "all":
+		c.minCount = len(c.monitorIDs)
+	case "count":
+		if config.MinCount < 1 || config.MinCount > len(c.monitorIDs) {
+			return nil, errors.Errorf("invalid count %d for %d monitors",
+				config.MinCount, len(c.monitorIDs))
+		}
+		c.minCount = config.MinCount
+	default:
+		return nil, errors.Errorf("unknown composite rule: %s", c.rule)
+	}
+
+	return &c, nil
+}
+
+func (c *Composite) Check() []Reading {
+	now := time.Now()
+
+	monitorStates, err := c.db.LoadMonitorStates(c.monitorIDs)
+	if err != nil {
+		log.WithError(err).Error("Could not load monitor states.")
+
+		return []Reading{{compositeSubprobe, state.Unknown, now, nil}}
+	}
+
+	s := combineStates(monitorStates, len(c.monitorIDs), c.minCount)
+
+	return []Reading{{compositeSubprobe, s, now, compositeDetails{
+		rule:     compositeRules[c.rule],
+		minCount: c.minCount,
+		expected: len(c.monitorIDs),
+		monitors: monitorStates,
+	}}}
+}
+
+// combineStates returns the worst state that at least minCount of the given
+// monitors are in or worse. If fewer than the expected monitors still exist,
+// the rule can't be applied as configured, so the state is Unknown.
+func combineStates(monitorStates []*db.MonitorState, expected, minCount int) state.State {
+	if len(monitorStates) < expected {
+		return state.Unknown
+	}
+
+	states := make([]state.State, len(monitorStates))
+	for i, ms := range monitorStates {
+		states[i] = ms.State
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i] > states[j] })
+
+	return states[minCount-1]
+}
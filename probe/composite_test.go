@@ -0,0 +1,84 @@
+package probe_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/yext/revere/db"
+	. "github.com/yext/revere/probe"
+	"github.com/yext/revere/test"
+)
+
+var (
+	compositeId        = 5
+	compositeName      = "Composite"
+	compositeProbeType = CompositeType{}
+	validCompositeJson = test.DefaultCompositeProbeJson
+)
+
+func validCompositeProbe() (*CompositeProbe, error) {
+	probe, err := LoadFromParams(compositeProbeType.Id(), validCompositeJson)
+	if err != nil {
+		return nil, err
+	}
+
+	compositeProbe, ok := probe.(CompositeProbe)
+	if !ok {
+		return nil, fmt.Errorf("Invalid probe loaded for probe type: %s\n", compositeProbeType.Name())
+	}
+
+	return &compositeProbe, nil
+}
+
+func TestCompositeId(t *testing.T) {
+	if int(compositeProbeType.Id()) != compositeId {
+		t.Errorf("Expected composite probe type id: %d, got %d\n", compositeId, compositeProbeType.Id())
+	}
+}
+
+func TestCompositeName(t *testing.T) {
+	if compositeProbeType.Name() != compositeName {
+		t.Errorf("Expected composite probe type name: %s, got %s\n", compositeName, compositeProbeType.Name())
+	}
+}
+
+func TestValidComposite(t *testing.T) {
+	compositeProbe, err := validCompositeProbe()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if errs := compositeProbe.Validate(); errs != nil {
+		t.Errorf("Unexpected errors for valid composite probe: %v\n", errs)
+	}
+
+	if !compositeProbe.HasMonitor(2) || compositeProbe.HasMonitor(4) {
+		t.Errorf("Unexpected monitors for composite probe: %v\n", compositeProbe.MonitorIDs)
+	}
+}
+
+func TestInvalidComposite(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*CompositeProbe)
+	}{
+		{"no monitors", func(c *CompositeProbe) { c.MonitorIDs = nil }},
+		{"repeated monitors", func(c *CompositeProbe) { c.MonitorIDs = []db.MonitorID{1, 1} }},
+		{"rule", func(c *CompositeProbe) { c.Rule = "most" }},
+		{"zero count", func(c *CompositeProbe) { c.MinCount = 0 }},
+		{"too large count", func(c *CompositeProbe) { c.MinCount = 4 }},
+		{"check period", func(c *CompositeProbe) { c.CheckPeriod = 0 }},
+	}
+
+	for _, tt := range tests {
+		compositeProbe, err := validCompositeProbe()
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		tt.modify(compositeProbe)
+		if errs := compositeProbe.Validate(); errs == nil {
+			t.Errorf("Expected error for invalid %s\n", tt.name)
+		}
+	}
+}
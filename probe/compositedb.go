@@ -0,0 +1,15 @@
+package probe
+
+// CompositeDBModel defines the JSON serialization format for saving composite
+// probes' settings in the database.
+type CompositeDBModel struct {
+	MonitorIDs []int64
+
+	// Rule is one of the keys of compositeRules.
+	Rule string
+
+	// MinCount is how many monitors must be failing for the "count" rule.
+	MinCount int
+
+	CheckPeriodMilli int64
+}
@@ -0,0 +1,29 @@
+package probe
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yext/revere/db"
+)
+
+type compositeDetails struct {
+	rule     string
+	minCount int
+	expected int
+	monitors []*db.MonitorState
+}
+
+func (d compositeDetails) Text() string {
+	lines := []string{fmt.Sprintf("Reporting the %s", d.rule)}
+	if d.rule == compositeRules["count"] {
+		lines[0] += fmt.Sprintf(" (N = %d)", d.minCount)
+	}
+	for _, m := range d.monitors {
+		lines = append(lines, fmt.Sprintf("%s: %s", m.Name, m.State))
+	}
+	if missing := d.expected - len(d.monitors); missing > 0 {
+		lines = append(lines, fmt.Sprintf("%d monitor(s) archived or deleted, so the state is Unknown", missing))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,207 @@
+package probe
+
+import (
+	"encoding/json"
+
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/util"
+)
+
+type CompositeType struct{}
+
+type CompositeProbe struct {
+	CompositeType
+
+	MonitorIDs      []db.MonitorID
+	Rule            string
+	MinCount        int
+	CheckPeriod     int64
+	CheckPeriodType string
+}
+
+func init() {
+	registerProbeType(CompositeType{})
+}
+
+func (CompositeType) Id() db.ProbeType {
+	return 5
+}
+
+func (CompositeType) Name() string {
+	return "Composite"
+}
+
+func (CompositeType) loadFromParams(probe string) (VM, error) {
+	var c CompositeProbe
+	err := json.Unmarshal([]byte(probe), &c)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (CompositeType) loadFromDb(encodedProbe string, tx *db.Tx) (VM, error) {
+	var c CompositeDBModel
+	err := json.Unmarshal([]byte(encodedProbe), &c)
+	if err != nil {
+		return nil, err
+	}
+
+	monitorIDs := make([]db.MonitorID, len(c.MonitorIDs))
+	for i, id := range c.MonitorIDs {
+		monitorIDs[i] = db.MonitorID(id)
+	}
+
+	checkPeriod, checkPeriodType := util.GetPeriodAndType(c.CheckPeriodMilli)
+
+	return &CompositeProbe{
+		MonitorIDs:      monitorIDs,
+		Rule:            c.Rule,
+		MinCount:        c.MinCount,
+		CheckPeriod:     checkPeriod,
+		CheckPeriodType: checkPeriodType,
+	}, nil
+}
+
+func (CompositeType) blank() (VM, error) {
+	return &CompositeProbe{Rule: "any"}, nil
+}
+
+func (CompositeType) Templates() map[string]string {
+	return map[string]string{
+		"edit": "composite-edit.html",
+		"view": "composite-view.html",
+	}
+}
+
+func (CompositeType) Scripts() map[string][]string {
+	return map[string][]string{
+		"edit": []string{
+			"composite.js",
+		},
+	}
+}
+
+func (CompositeType) AcceptedResourceTypes() []db.ResourceType {
+	return []db.ResourceType{}
+}
+
+func (c CompositeProbe) HasResource(id db.ResourceID) bool {
+	return false
+}
+
+// HasMonitor returns whether the composite probe derives its state from the
+// given monitor.
+func (c CompositeProbe) HasMonitor(id db.MonitorID) bool {
+	for _, m := range c.MonitorIDs {
+		if m == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (c CompositeProbe) SerializeForFrontend() map[string]string {
+	return map[string]string{
+		"Rule": c.Rule,
+	}
+}
+
+func (c CompositeProbe) SerializeForDB() (string, error) {
+	monitorIDs := make([]int64, len(c.MonitorIDs))
+	for i, id := range c.MonitorIDs {
+		monitorIDs[i] = int64(id)
+	}
+
+	cDB := CompositeDBModel{
+		MonitorIDs:       monitorIDs,
+		Rule:             c.Rule,
+		MinCount:         c.MinCount,
+		CheckPeriodMilli: util.GetMs(c.CheckPeriod, c.CheckPeriodType),
+	}
+
+	cDBJSON, err := json.Marshal(cDB)
+	return string(cDBJSON), err
+}
+
+func (c CompositeProbe) Type() VMType {
+	return CompositeType{}
+}
+
+func (c CompositeProbe) Validate() (errs []string) {
+	if len(c.MonitorIDs) == 0 {
+		errs = append(errs, "At least one monitor is required")
+	}
+
+	seen := make(map[db.MonitorID]bool)
+	for _, id := range c.MonitorIDs {
+		if seen[id] {
+			errs = append(errs, "Monitors must not be repeated")
+			break
+		}
+		seen[id] = true
+	}
+
+	if _, ok := compositeRules[c.Rule]; !ok {
+		errs = append(errs, "Invalid composite rule")
+	}
+
+	if c.Rule == "count" && (c.MinCount < 1 || c.MinCount > len(c.MonitorIDs)) {
+		errs = append(errs, "Count must be between 1 and the number of monitors")
+	}
+
+	if util.GetMs(c.CheckPeriod, c.CheckPeriodType) <= 0 {
+		errs = append(errs, "Invalid check period")
+	}
+
+	return
+}
+
+// IsCompositeCycle returns whether the composite probe of monitor id
+// combining monitorIDs would make the monitor's state depend on itself,
+// through the composite probes of monitors that aren't archived.
+func IsCompositeCycle(DB *db.DB, id db.MonitorID, monitorIDs []db.MonitorID) (bool, error) {
+	probes, err := DB.LoadActiveMonitorProbesOfType(CompositeType{}.Id())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+
+	combined := make(map[db.MonitorID][]db.MonitorID)
+	for _, p := range probes {
+		var config CompositeDBModel
+		if err := p.Probe.Unmarshal(&config); err != nil {
+			continue
+		}
+		for _, m := range config.MonitorIDs {
+			combined[p.MonitorID] = append(combined[p.MonitorID], db.MonitorID(m))
+		}
+	}
+	combined[id] = monitorIDs
+
+	return compositeCycle(id, combined), nil
+}
+
+// compositeCycle returns whether monitor id's state depends on itself, given
+// the monitors each composite monitor combines.
+func compositeCycle(id db.MonitorID, combined map[db.MonitorID][]db.MonitorID) bool {
+	visited := make(map[db.MonitorID]bool)
+	var reaches func(from db.MonitorID) bool
+	reaches = func(from db.MonitorID) bool {
+		for _, m := range combined[from] {
+			if m == id {
+				return true
+			}
+			if visited[m] {
+				continue
+			}
+			visited[m] = true
+			if reaches(m) {
+				return true
+			}
+		}
+		return false
+	}
+	return reaches(id)
+}
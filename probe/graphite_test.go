@@ -108,7 +108,7 @@ func TestValidGraphiteThresholdPeriodType(t *testing.T) {
 		gtProbe.AuditPeriodType = pt
 		errs = gtProbe.Validate()
 		if errs != nil {
-			t.Error("Unexpected error for audit period type: %s\n", pt)
+			t.Errorf("Unexpected error for audit period type: %s\n", pt)
 		}
 	}
 }
@@ -132,3 +132,132 @@ func TestInvalidGraphiteThresholdPeriodType(t *testing.T) {
 		t.Error("Expected error for invalid audit period type")
 	}
 }
+
+func TestValidGraphiteThresholdAuditFunction(t *testing.T) {
+	gtProbe, err := validGraphiteThresholdProbe()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	for _, af := range []string{"min", "max", "avg", "sum", "last", "count", "p50", "p90", "p95", "p99"} {
+		gtProbe.AuditFunction = af
+		errs := gtProbe.Validate()
+		if errs != nil {
+			t.Errorf("Unexpected error for audit function: %s: %v\n", af, errs)
+		}
+	}
+}
+
+func TestInvalidGraphiteThresholdAuditFunction(t *testing.T) {
+	gtProbe, err := validGraphiteThresholdProbe()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	gtProbe.AuditFunction = "median"
+	errs := gtProbe.Validate()
+	if errs == nil {
+		t.Error("Expected error for invalid audit function")
+	}
+}
+
+func TestGraphiteThresholdPercentBreaching(t *testing.T) {
+	gtProbe, err := validGraphiteThresholdProbe()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	gtProbe.AuditFunction = "pct_breaching"
+	errs := gtProbe.Validate()
+	if errs == nil {
+		t.Error("Expected error for percent breaching without breach value")
+	}
+
+	breachValue, critical := float64(100), float64(120)
+	gtProbe.BreachValue = &breachValue
+	gtProbe.Thresholds = ThresholdsModel{Critical: &critical}
+	errs = gtProbe.Validate()
+	if errs == nil {
+		t.Error("Expected error for percent breaching thresholds over 100")
+	}
+
+	warning, errorValue := float64(10), float64(20)
+	gtProbe.Thresholds = ThresholdsModel{Warning: &warning, Error: &errorValue}
+	errs = gtProbe.Validate()
+	if errs != nil {
+		t.Errorf("Unexpected errors for valid percent breaching probe: %v\n", errs)
+	}
+}
+
+func TestGraphiteThresholdNoDataPolicy(t *testing.T) {
+	gtProbe, err := validGraphiteThresholdProbe()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	for _, policy := range []string{"", "keep", "normal", "unknown", "warning", "error"} {
+		gtProbe.NoDataPolicy = policy
+		errs := gtProbe.Validate()
+		if errs != nil {
+			t.Errorf("Unexpected error for no data policy: %q: %v\n", policy, errs)
+		}
+	}
+
+	gtProbe.NoDataPolicy = "critical"
+	errs := gtProbe.Validate()
+	if errs == nil {
+		t.Error("Expected error for invalid no data policy")
+	}
+}
+
+func TestGraphiteThresholdMinNonNullRatio(t *testing.T) {
+	gtProbe, err := validGraphiteThresholdProbe()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	for _, ratio := range []float64{0, 0.5, 1} {
+		gtProbe.MinNonNullRatio = ratio
+		errs := gtProbe.Validate()
+		if errs != nil {
+			t.Errorf("Unexpected error for min non-null ratio: %g: %v\n", ratio, errs)
+		}
+	}
+
+	for _, ratio := range []float64{-0.1, 1.5} {
+		gtProbe.MinNonNullRatio = ratio
+		errs := gtProbe.Validate()
+		if errs == nil {
+			t.Errorf("Expected error for min non-null ratio: %g\n", ratio)
+		}
+	}
+}
+
+func TestGraphiteThresholdRecoveryThresholds(t *testing.T) {
+	gtProbe, err := validGraphiteThresholdProbe()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	gtProbe.TriggerIf = ">"
+	errorValue, recovery := float64(100), float64(90)
+	gtProbe.Thresholds = ThresholdsModel{Error: &errorValue}
+	gtProbe.RecoveryThresholds = ThresholdsModel{Error: &recovery}
+	errs := gtProbe.Validate()
+	if errs != nil {
+		t.Errorf("Unexpected errors for valid recovery threshold: %v\n", errs)
+	}
+
+	pastRecovery := float64(110)
+	gtProbe.RecoveryThresholds = ThresholdsModel{Error: &pastRecovery}
+	errs = gtProbe.Validate()
+	if errs == nil {
+		t.Error("Expected error for recovery threshold past its threshold")
+	}
+
+	gtProbe.RecoveryThresholds = ThresholdsModel{Warning: &recovery}
+	errs = gtProbe.Validate()
+	if errs == nil {
+		t.Error("Expected error for recovery threshold without a threshold")
+	}
+}
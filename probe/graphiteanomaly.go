@@ -0,0 +1,266 @@
+package probe
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/resource"
+	"github.com/yext/revere/state"
+)
+
+// GraphiteAnomaly implements a probe that assigns states based on how far a
+// Graphite metric deviates from its own recent history.
+type GraphiteAnomaly struct {
+	*Polling
+
+	graphiteBase       string
+	resourceID         db.ResourceID
+	expression         string
+	timeToAudit        time.Duration
+	recentTimeToIgnore time.Duration
+	baselinePeriod     time.Duration
+
+	thresholds      []stateThreshold
+	summarizeValues func(values []float64) float64
+	deviationUnit   string
+	direction       string
+
+	auditFunctionName string
+
+	noData noDataPolicy
+}
+
+var (
+	// deviationUnits maps deviation units to functions computing how far
+	// value is from a baseline in that unit.
+	deviationUnits = map[string]func(value float64, b baseline) float64{
+		"stddev": func(value float64, b baseline) float64 {
+			return safeDivide(value-b.mean, b.stddev)
+		},
+		"percent": func(value float64, b baseline) float64 {
+			return safeDivide(value-b.mean, math.Abs(b.mean)) * 100
+		},
+	}
+
+	// anomalyDirections maps which deviations are anomalous to functions
+	// that turn a signed deviation into how anomalous it is.
+	anomalyDirections = map[string]func(deviation float64) float64{
+		"above": func(deviation float64) float64 {
+			return deviation
+		},
+		"below": func(deviation float64) float64 {
+			return -deviation
+		},
+		"both": math.Abs,
+	}
+)
+
+// baseline summarizes the historical values an audited value is compared to.
+type baseline struct {
+	mean   float64
+	stddev float64
+}
+
+func newBaseline(values []float64) baseline {
+	sum, count := float64(0), 0
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			sum += v
+			count++
+		}
+	}
+	if count == 0 {
+		return baseline{math.NaN(), math.NaN()}
+	}
+	mean := sum / float64(count)
+
+	squares := float64(0)
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			squares += (v - mean) * (v - mean)
+		}
+	}
+	return baseline{mean, math.Sqrt(squares / float64(count))}
+}
+
+// band returns the range of values within deviation of b.
+func (b baseline) band(deviation float64, unit string) (low, high float64) {
+	var width float64
+	switch unit {
+	case "percent":
+		width = math.Abs(b.mean) * deviation / 100
+	default:
+		width = b.stddev * deviation
+	}
+	return b.mean - width, b.mean + width
+}
+
+// safeDivide divides a by b, treating any nonzero amount over a zero spread
+// as infinitely far away rather than producing NaN.
+func safeDivide(a, b float64) float64 {
+	if b == 0 {
+		if a == 0 {
+			return 0
+		}
+		return math.Copysign(math.Inf(1), a)
+	}
+	return a / b
+}
+
+func newGraphiteAnomaly(tx *db.Tx, configJSON types.JSONText, readingsSink chan<- []Reading) (Probe, error) {
+	ga := GraphiteAnomaly{}
+
+	var config GraphiteAnomalyDBModel
+	err := configJSON.Unmarshal(&config)
+	if err != nil {
+		return nil, errors.Maskf(err, "deserialize probe config")
+	}
+
+	checkPeriod := time.Duration(config.CheckPeriodMilli) * time.Millisecond
+	ga.Polling, err = NewPolling(checkPeriod, &ga, readingsSink)
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+
+	gds, err := loadGraphiteResource(tx, db.ResourceID(config.ResourceID))
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+
+	ga.graphiteBase = fmt.Sprintf("http://%s/", gds.URL)
+	ga.resourceID = db.ResourceID(config.ResourceID)
+	ga.expression = config.Expression
+	ga.timeToAudit = time.Duration(config.TimeToAuditMilli) * time.Millisecond
+	ga.recentTimeToIgnore = time.Duration(config.RecentTimeToIgnoreMilli) * time.Millisecond
+	ga.baselinePeriod = time.Duration(config.BaselinePeriodMilli) * time.Millisecond
+	if ga.baselinePeriod <= 0 {
+		return nil, errors.Errorf("cannot compute baseline over nonpositive period %s", ga.baselinePeriod)
+	}
+
+	ga.thresholds = newStateThresholds(
+		config.Thresholds.Warning, config.Thresholds.Error, config.Thresholds.Critical)
+
+	var ok bool
+
+	ga.summarizeValues, ok = auditFunctions[config.AuditFunction]
+	if !ok {
+		return nil, errors.Errorf("unknown audit function: %s", config.AuditFunction)
+	}
+
+	if _, ok = deviationUnits[config.DeviationUnit]; !ok {
+		return nil, errors.Errorf("unknown deviation unit: %s", config.DeviationUnit)
+	}
+	ga.deviationUnit = config.DeviationUnit
+
+	if _, ok = anomalyDirections[config.Direction]; !ok {
+		return nil, errors.Errorf("unknown anomaly direction: %s", config.Direction)
+	}
+	ga.direction = config.Direction
+
+	ga.auditFunctionName = config.AuditFunction
+
+	ga.noData, err = newNoDataPolicy(config.NoDataPolicy, config.MinNonNullRatio, config.AuditFunction)
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+
+	return &ga, nil
+}
+
+func (ga *GraphiteAnomaly) Resource() db.ResourceID {
+	return ga.resourceID
+}
+
+func (ga *GraphiteAnomaly) Check() []Reading {
+	now := time.Now()
+
+	auditEnd := now.Add(-ga.recentTimeToIgnore)
+	auditStart := auditEnd.Add(-ga.timeToAudit)
+
+	g := resource.GraphiteDaemon{Base: ga.graphiteBase}
+
+	series, err := g.Query(ga.expression, auditStart.Add(-ga.baselinePeriod), auditEnd)
+	if err != nil {
+		log.WithError(err).Error("Could not query Graphite.")
+
+		return unreachableReadings(now, err)
+	}
+
+	if len(series) == 0 {
+		return ga.noData.noSeriesReadings(ga.expression, now)
+	}
+
+	readings := make([]Reading, 0, len(series)+1)
+	for _, s := range series {
+		baselineValues, auditedValues := splitGraphiteSeries(s, auditStart)
+
+		summaryValue := ga.summarizeValues(auditedValues)
+		b := newBaseline(baselineValues)
+		if math.IsNaN(summaryValue) || !ga.noData.hasEnoughData(auditedValues) {
+			readings = append(readings, ga.noData.readings(
+				s.Name, now, newNoDataDetails(ga.expression, auditedValues, ga.noData.minNonNullRatio))...)
+			continue
+		}
+		if math.IsNaN(b.mean) {
+			// No history to compare against yet.
+			readings = append(readings, ga.noData.readings(
+				s.Name, now, newNoBaselineDetails(ga.expression, baselineValues))...)
+			continue
+		}
+
+		deviation := deviationUnits[ga.deviationUnit](summaryValue, b)
+		anomalousness := anomalyDirections[ga.direction](deviation)
+
+		r := Reading{s.Name, state.Normal, now, nil}
+
+		var triggeredThreshold float64
+		r.State, triggeredThreshold = applyThresholds(
+			anomalousness, ga.thresholds, triggerIfFunctions[">="])
+
+		r.Details = graphiteAnomalyDetails{
+			auditFunction: ga.auditFunctionName,
+			timeToAudit:   ga.timeToAudit,
+			deviationUnit: ga.deviationUnit,
+			direction:     ga.direction,
+
+			measured:  summaryValue,
+			deviation: deviation,
+			baseline:  b,
+			threshold: triggeredThreshold,
+
+			graphite:       &g,
+			expression:     ga.expression,
+			seriesName:     s.Name,
+			measuredEnd:    auditEnd,
+			baselinePeriod: ga.baselinePeriod,
+		}
+
+		readings = append(readings, r)
+	}
+	readings = append(readings, Reading{"_", state.Normal, now, nil})
+
+	return readings
+}
+
+// splitGraphiteSeries splits s's values into those from before and those
+// from at or after t.
+func splitGraphiteSeries(s resource.GraphiteSeries, t time.Time) (before, after []float64) {
+	if s.Step <= 0 {
+		return nil, s.Values
+	}
+
+	i := int(math.Ceil(float64(t.Sub(s.Start)) / float64(s.Step)))
+	if i < 0 {
+		i = 0
+	}
+	if i > len(s.Values) {
+		i = len(s.Values)
+	}
+	return s.Values[:i], s.Values[i:]
+}
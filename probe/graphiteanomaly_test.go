@@ -0,0 +1,81 @@
+package probe_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/yext/revere/probe"
+	"github.com/yext/revere/test"
+)
+
+var (
+	gaId        = 6
+	gaName      = "Graphite Anomaly"
+	gaProbeType = GraphiteAnomalyType{}
+	validGaJson = test.DefaultGraphiteAnomalyProbeJson
+)
+
+func validGraphiteAnomalyProbe() (*GraphiteAnomalyProbe, error) {
+	probe, err := LoadFromParams(gaProbeType.Id(), validGaJson)
+	if err != nil {
+		return nil, err
+	}
+
+	gaProbe, ok := probe.(GraphiteAnomalyProbe)
+	if !ok {
+		return nil, fmt.Errorf("Invalid probe loaded for probe type: %s\n", gaProbeType.Name())
+	}
+
+	return &gaProbe, nil
+}
+
+func TestGraphiteAnomalyId(t *testing.T) {
+	if int(gaProbeType.Id()) != gaId {
+		t.Errorf("Expected graphite anomaly probe type id: %d, got %d\n", gaId, gaProbeType.Id())
+	}
+}
+
+func TestGraphiteAnomalyName(t *testing.T) {
+	if gaProbeType.Name() != gaName {
+		t.Errorf("Expected graphite anomaly probe type name: %s, got %s\n", gaName, gaProbeType.Name())
+	}
+}
+
+func TestValidGraphiteAnomaly(t *testing.T) {
+	gaProbe, err := validGraphiteAnomalyProbe()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if errs := gaProbe.Validate(); errs != nil {
+		t.Errorf("Unexpected errors for valid graphite anomaly probe: %v\n", errs)
+	}
+}
+
+func TestInvalidGraphiteAnomaly(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*GraphiteAnomalyProbe)
+	}{
+		{"expression", func(g *GraphiteAnomalyProbe) { g.Expression = "" }},
+		{"audit function", func(g *GraphiteAnomalyProbe) { g.AuditFunction = "" }},
+		{"deviation unit", func(g *GraphiteAnomalyProbe) { g.DeviationUnit = "zscore" }},
+		{"direction", func(g *GraphiteAnomalyProbe) { g.Direction = "sideways" }},
+		{"threshold", func(g *GraphiteAnomalyProbe) { negative := -1.0; g.Thresholds.Warning = &negative }},
+		{"check period", func(g *GraphiteAnomalyProbe) { g.CheckPeriod = 0 }},
+		{"audit period", func(g *GraphiteAnomalyProbe) { g.AuditPeriodType = "" }},
+		{"baseline period", func(g *GraphiteAnomalyProbe) { g.BaselinePeriodType = "minute" }},
+	}
+
+	for _, tt := range tests {
+		gaProbe, err := validGraphiteAnomalyProbe()
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		tt.modify(gaProbe)
+		if errs := gaProbe.Validate(); errs == nil {
+			t.Errorf("Expected error for invalid %s\n", tt.name)
+		}
+	}
+}
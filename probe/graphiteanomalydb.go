@@ -0,0 +1,32 @@
+package probe
+
+// GraphiteAnomalyDBModel defines the JSON serialization format for saving
+// Graphite anomaly probes' settings in the database.
+type GraphiteAnomalyDBModel struct {
+	ResourceID int64
+	Expression string
+
+	// Thresholds are how far the audited value may deviate from the
+	// baseline, in units given by DeviationUnit.
+	Thresholds    GraphiteThresholdThresholdsDBModel
+	DeviationUnit string
+	Direction     string
+
+	CheckPeriodMilli int64
+
+	TimeToAuditMilli        int64
+	RecentTimeToIgnoreMilli int64
+	AuditFunction           string
+
+	// BaselinePeriodMilli is the length of the historical window, ending
+	// where the audited window starts, that the baseline is computed from.
+	BaselinePeriodMilli int64
+
+	// NoDataPolicy names how series without enough data in the audited
+	// window are reported; see noDataStates. Empty keeps their last state,
+	// but reports Normal when the expression returns no series.
+	// MinNonNullRatio is the fraction of points in the window that must be
+	// non-null for it to have enough data.
+	NoDataPolicy    string
+	MinNonNullRatio float64
+}
@@ -0,0 +1,95 @@
+package probe
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/yext/revere/durationfmt"
+	"github.com/yext/revere/resource"
+)
+
+type graphiteAnomalyDetails struct {
+	auditFunction string
+	timeToAudit   time.Duration
+	deviationUnit string
+	direction     string
+
+	measured  float64
+	deviation float64
+	baseline  baseline
+	threshold float64
+
+	graphite       *resource.GraphiteDaemon
+	expression     string
+	seriesName     string
+	measuredEnd    time.Time
+	baselinePeriod time.Duration
+}
+
+func (d graphiteAnomalyDetails) Text() string {
+	timeToAuditText := durationfmt.ExactMulti().Format(d.timeToAudit)
+	baselinePeriodText := durationfmt.ExactMulti().Format(d.baselinePeriod)
+
+	lines := []string{
+		fmt.Sprintf("%s of last %s: %g (%s from baseline)",
+			d.auditFunction, timeToAuditText, d.measured, d.deviationText(d.deviation)),
+		fmt.Sprintf("Baseline over previous %s: mean %g, stddev %g",
+			baselinePeriodText, d.baseline.mean, d.baseline.stddev),
+	}
+
+	if !math.IsNaN(d.threshold) {
+		low, high := d.baseline.band(d.threshold, d.deviationUnit)
+		lines = append(lines, fmt.Sprintf("Allowed band (%s %s): %g to %g",
+			d.direction, d.deviationText(d.threshold), low, high))
+	}
+
+	return fmt.Sprintf("%s\n\nGraph: %s\n", strings.Join(lines, "\n"), d.graphURL())
+}
+
+func (d graphiteAnomalyDetails) deviationText(deviation float64) string {
+	if d.deviationUnit == "percent" {
+		return fmt.Sprintf("%+g%%", deviation)
+	}
+	return fmt.Sprintf("%+g stddev", deviation)
+}
+
+func (d graphiteAnomalyDetails) graphURL() string {
+	measuredStart := d.measuredEnd.Add(-d.timeToAudit)
+
+	targets := make([]string, 0, 5)
+
+	timeHighlight := fmt.Sprintf(
+		`color(drawAsInfinite(timeSlice(timeFunction("", 1), "%s", "%s")), "yellow")`,
+		resource.GraphiteTimestamp(measuredStart),
+		resource.GraphiteTimestamp(d.measuredEnd))
+	targets = append(targets, timeHighlight)
+
+	targets = append(targets, fmt.Sprintf(`color(constantLine(%g), "blue")`, d.baseline.mean))
+
+	if !math.IsNaN(d.threshold) {
+		low, high := d.baseline.band(d.threshold, d.deviationUnit)
+		if d.direction != "above" {
+			targets = append(targets, fmt.Sprintf(`color(constantLine(%g), "red")`, low))
+		}
+		if d.direction != "below" {
+			targets = append(targets, fmt.Sprintf(`color(constantLine(%g), "red")`, high))
+		}
+	}
+
+	targets = append(targets, fmt.Sprintf(`color(grep(%s, "^%s$"), "green")`,
+		d.expression,
+		strings.Replace(regexp.QuoteMeta(d.seriesName), `"`, `\"`, -1)))
+
+	return d.graphite.RenderURL(targets, map[string]string{
+		"hideLegend": "true",
+		"width":      "970",
+		"height":     "600",
+		"tz":         "UTC",
+		"title":      d.seriesName,
+		"from":       resource.GraphiteTimestamp(measuredStart.Add(-d.baselinePeriod)),
+		"until":      resource.GraphiteTimestamp(d.measuredEnd),
+	})
+}
@@ -0,0 +1,220 @@
+package probe
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/resource"
+	"github.com/yext/revere/util"
+)
+
+type GraphiteAnomalyType struct{}
+
+type GraphiteAnomalyProbe struct {
+	GraphiteAnomalyType
+
+	URL                string
+	ResourceID         db.ResourceID
+	Expression         string
+	Thresholds         ThresholdsModel
+	DeviationUnit      string
+	Direction          string
+	AuditFunction      string
+	CheckPeriod        int64
+	CheckPeriodType    string
+	AuditPeriod        int64
+	AuditPeriodType    string
+	IgnoredPeriod      int64
+	IgnoredPeriodType  string
+	BaselinePeriod     int64
+	BaselinePeriodType string
+	NoDataPolicy       string
+	MinNonNullRatio    float64
+}
+
+func init() {
+	registerProbeType(GraphiteAnomalyType{})
+}
+
+func (GraphiteAnomalyType) Id() db.ProbeType {
+	return 6
+}
+
+func (GraphiteAnomalyType) Name() string {
+	return "Graphite Anomaly"
+}
+
+func (GraphiteAnomalyType) loadFromParams(probe string) (VM, error) {
+	var g GraphiteAnomalyProbe
+	err := json.Unmarshal([]byte(probe), &g)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (GraphiteAnomalyType) loadFromDb(encodedProbe string, tx *db.Tx) (VM, error) {
+	var g GraphiteAnomalyDBModel
+	err := json.Unmarshal([]byte(encodedProbe), &g)
+	if err != nil {
+		return nil, err
+	}
+
+	checkPeriod, checkPeriodType := util.GetPeriodAndType(g.CheckPeriodMilli)
+	auditPeriod, auditPeriodType := util.GetPeriodAndType(g.TimeToAuditMilli)
+	ignoredPeriod, ignoredPeriodType := util.GetPeriodAndType(g.RecentTimeToIgnoreMilli)
+	baselinePeriod, baselinePeriodType := util.GetPeriodAndType(g.BaselinePeriodMilli)
+
+	gds, err := loadGraphiteResource(tx, db.ResourceID(g.ResourceID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &GraphiteAnomalyProbe{
+		URL:        gds.URL,
+		ResourceID: db.ResourceID(g.ResourceID),
+		Expression: g.Expression,
+		Thresholds: ThresholdsModel{
+			g.Thresholds.Warning,
+			g.Thresholds.Error,
+			g.Thresholds.Critical,
+		},
+		DeviationUnit:      g.DeviationUnit,
+		Direction:          g.Direction,
+		AuditFunction:      g.AuditFunction,
+		CheckPeriod:        checkPeriod,
+		CheckPeriodType:    checkPeriodType,
+		AuditPeriod:        auditPeriod,
+		AuditPeriodType:    auditPeriodType,
+		IgnoredPeriod:      ignoredPeriod,
+		IgnoredPeriodType:  ignoredPeriodType,
+		BaselinePeriod:     baselinePeriod,
+		BaselinePeriodType: baselinePeriodType,
+		NoDataPolicy:       g.NoDataPolicy,
+		MinNonNullRatio:    g.MinNonNullRatio,
+	}, nil
+}
+
+func (GraphiteAnomalyType) blank() (VM, error) {
+	return &GraphiteAnomalyProbe{
+		DeviationUnit: "stddev",
+		Direction:     "both",
+	}, nil
+}
+
+func (GraphiteAnomalyType) Templates() map[string]string {
+	return map[string]string{
+		"edit": "graphite-anomaly-edit.html",
+		"view": "graphite-anomaly-view.html",
+	}
+}
+
+func (GraphiteAnomalyType) Scripts() map[string][]string {
+	return map[string][]string{
+		"edit": []string{
+			"graphite-anomaly.js",
+			"graphite-resource-loader.js",
+		},
+	}
+}
+
+func (GraphiteAnomalyType) AcceptedResourceTypes() []db.ResourceType {
+	return []db.ResourceType{
+		resource.Graphite{}.Id(),
+	}
+}
+
+func (g GraphiteAnomalyProbe) HasResource(id db.ResourceID) bool {
+	return g.ResourceID == id
+}
+
+func (g GraphiteAnomalyProbe) SerializeForFrontend() map[string]string {
+	var warningStr, errorStr, criticalStr string
+	if g.Thresholds.Warning != nil {
+		warningStr = strconv.FormatFloat(*g.Thresholds.Warning, 'f', -1, 64)
+	}
+	if g.Thresholds.Error != nil {
+		errorStr = strconv.FormatFloat(*g.Thresholds.Error, 'f', -1, 64)
+	}
+	if g.Thresholds.Critical != nil {
+		criticalStr = strconv.FormatFloat(*g.Thresholds.Critical, 'f', -1, 64)
+	}
+	return map[string]string{
+		"Expression": g.Expression,
+		"URL":        g.URL,
+		"Warning":    warningStr,
+		"Error":      errorStr,
+		"Critical":   criticalStr,
+	}
+}
+
+func (g GraphiteAnomalyProbe) SerializeForDB() (string, error) {
+	gaDB := GraphiteAnomalyDBModel{
+		ResourceID: int64(g.ResourceID),
+		Expression: g.Expression,
+		Thresholds: GraphiteThresholdThresholdsDBModel{
+			Warning:  g.Thresholds.Warning,
+			Error:    g.Thresholds.Error,
+			Critical: g.Thresholds.Critical,
+		},
+		DeviationUnit:           g.DeviationUnit,
+		Direction:               g.Direction,
+		CheckPeriodMilli:        util.GetMs(g.CheckPeriod, g.CheckPeriodType),
+		TimeToAuditMilli:        util.GetMs(g.AuditPeriod, g.AuditPeriodType),
+		RecentTimeToIgnoreMilli: util.GetMs(g.IgnoredPeriod, g.IgnoredPeriodType),
+		AuditFunction:           g.AuditFunction,
+		BaselinePeriodMilli:     util.GetMs(g.BaselinePeriod, g.BaselinePeriodType),
+		NoDataPolicy:            g.NoDataPolicy,
+		MinNonNullRatio:         g.MinNonNullRatio,
+	}
+
+	gaDBJSON, err := json.Marshal(gaDB)
+	return string(gaDBJSON), err
+}
+
+func (g GraphiteAnomalyProbe) Type() VMType {
+	return GraphiteAnomalyType{}
+}
+
+func (g GraphiteAnomalyProbe) Validate() (errs []string) {
+	if g.Expression == "" {
+		errs = append(errs, "Graphite expression is required")
+	}
+
+	if _, ok := auditFunctions[g.AuditFunction]; !ok {
+		errs = append(errs, "Invalid audit function")
+	}
+
+	if _, ok := deviationUnits[g.DeviationUnit]; !ok {
+		errs = append(errs, "Invalid deviation unit")
+	}
+
+	if _, ok := anomalyDirections[g.Direction]; !ok {
+		errs = append(errs, "Invalid anomaly direction")
+	}
+
+	for _, t := range []*float64{g.Thresholds.Warning, g.Thresholds.Error, g.Thresholds.Critical} {
+		if t != nil && *t <= 0 {
+			errs = append(errs, "Deviation thresholds must be positive")
+			break
+		}
+	}
+
+	if util.GetMs(g.CheckPeriod, g.CheckPeriodType) <= 0 {
+		errs = append(errs, "Invalid check period")
+	}
+
+	auditPeriod := util.GetMs(g.AuditPeriod, g.AuditPeriodType)
+	if auditPeriod <= 0 {
+		errs = append(errs, "Invalid audit period")
+	}
+
+	if util.GetMs(g.BaselinePeriod, g.BaselinePeriodType) <= auditPeriod {
+		errs = append(errs, "Baseline period must be longer than the audit period")
+	}
+
+	errs = append(errs, validateNoDataSettings(g.NoDataPolicy, g.MinNonNullRatio)...)
+
+	return
+}
@@ -0,0 +1,202 @@
+package probe
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/resource"
+	"github.com/yext/revere/state"
+)
+
+// GraphiteComparison implements a probe that assigns states based on how a
+// Graphite metric compares to the same metric at an earlier time, such as the
+// same time last week. Series are matched up by name.
+type GraphiteComparison struct {
+	*Polling
+
+	graphiteBase       string
+	resourceID         db.ResourceID
+	expression         string
+	timeToAudit        time.Duration
+	recentTimeToIgnore time.Duration
+	timeShift          time.Duration
+
+	thresholds      []stateThreshold
+	summarizeValues func(values []float64) float64
+	compare         func(current, previous float64) float64
+	triggersOn      func(summaryValue, threshold float64) bool
+
+	auditFunctionName string
+	comparisonName    string
+	triggerIfText     string
+
+	noData noDataPolicy
+}
+
+// comparisonFunctions maps comparison names to functions combining the
+// current and time-shifted summary values into the value thresholds apply to.
+var comparisonFunctions = map[string]func(current, previous float64) float64{
+	"ratio": func(current, previous float64) float64 {
+		if current == previous {
+			return 1
+		}
+		return safeDivide(current, previous)
+	},
+	"difference": func(current, previous float64) float64 {
+		return current - previous
+	},
+}
+
+func newGraphiteComparison(tx *db.Tx, configJSON types.JSONText, readingsSink chan<- []Reading) (Probe, error) {
+	gc := GraphiteComparison{}
+
+	var config GraphiteComparisonDBModel
+	err := configJSON.Unmarshal(&config)
+	if err != nil {
+		return nil, errors.Maskf(err, "deserialize probe config")
+	}
+
+	checkPeriod := time.Duration(config.CheckPeriodMilli) * time.Millisecond
+	gc.Polling, err = NewPolling(checkPeriod, &gc, readingsSink)
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+
+	gds, err := loadGraphiteResource(tx, db.ResourceID(config.ResourceID))
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+
+	gc.graphiteBase = fmt.Sprintf("http://%s/", gds.URL)
+	gc.resourceID = db.ResourceID(config.ResourceID)
+	gc.expression = config.Expression
+	gc.timeToAudit = time.Duration(config.TimeToAuditMilli) * time.Millisecond
+	gc.recentTimeToIgnore = time.Duration(config.RecentTimeToIgnoreMilli) * time.Millisecond
+	gc.timeShift = time.Duration(config.TimeShiftMilli) * time.Millisecond
+	if gc.timeShift <= 0 {
+		return nil, errors.Errorf("cannot compare against nonpositive time shift %s", gc.timeShift)
+	}
+
+	gc.thresholds = newStateThresholds(
+		config.Thresholds.Warning, config.Thresholds.Error, config.Thresholds.Critical)
+
+	var ok bool
+
+	gc.summarizeValues, ok = auditFunctions[config.AuditFunction]
+	if !ok {
+		return nil, errors.Errorf("unknown audit function: %s", config.AuditFunction)
+	}
+
+	gc.compare, ok = comparisonFunctions[config.Comparison]
+	if !ok {
+		return nil, errors.Errorf("unknown comparison: %s", config.Comparison)
+	}
+
+	gc.triggersOn, ok = triggerIfFunctions[config.TriggerIf]
+	if !ok {
+		return nil, errors.Errorf("unknown trigger if: %s", config.TriggerIf)
+	}
+
+	gc.auditFunctionName = config.AuditFunction
+	gc.comparisonName = config.Comparison
+	gc.triggerIfText = config.TriggerIf
+
+	gc.noData, err = newNoDataPolicy(config.NoDataPolicy, config.MinNonNullRatio, config.AuditFunction)
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+
+	return &gc, nil
+}
+
+func (gc *GraphiteComparison) Resource() db.ResourceID {
+	return gc.resourceID
+}
+
+func (gc *GraphiteComparison) Check() []Reading {
+	now := time.Now()
+
+	auditEnd := now.Add(-gc.recentTimeToIgnore)
+	auditStart := auditEnd.Add(-gc.timeToAudit)
+
+	g := resource.GraphiteDaemon{Base: gc.graphiteBase}
+
+	series, err := g.Query(gc.expression, auditStart, auditEnd)
+	if err != nil {
+		log.WithError(err).Error("Could not query Graphite.")
+
+		return unreachableReadings(now, err)
+	}
+
+	if len(series) == 0 {
+		return gc.noData.noSeriesReadings(gc.expression, now)
+	}
+
+	shiftedSeries, err := g.Query(gc.expression, auditStart.Add(-gc.timeShift), auditEnd.Add(-gc.timeShift))
+	if err != nil {
+		log.WithError(err).Error("Could not query Graphite for time-shifted values.")
+
+		return unreachableReadings(now, err)
+	}
+
+	previousValues := make(map[string][]float64, len(shiftedSeries))
+	for _, s := range shiftedSeries {
+		previousValues[s.Name] = s.Values
+	}
+
+	readings := make([]Reading, 0, len(series)+1)
+	for _, s := range series {
+		current := gc.summarizeValues(s.Values)
+		if math.IsNaN(current) || !gc.noData.hasEnoughData(s.Values) {
+			readings = append(readings, gc.noData.readings(
+				s.Name, now, newNoDataDetails(gc.expression, s.Values, gc.noData.minNonNullRatio))...)
+			continue
+		}
+
+		values, found := previousValues[s.Name]
+		previous := gc.summarizeValues(values)
+		if !found || math.IsNaN(previous) {
+			// The time-shifted series is missing or all NaNs, so there
+			// is nothing to compare against.
+			readings = append(readings, gc.noData.readings(
+				s.Name, now, newNoPreviousDetails(gc.expression, values))...)
+			continue
+		}
+
+		compared := gc.compare(current, previous)
+
+		r := Reading{s.Name, state.Normal, now, nil}
+
+		var triggeredThreshold float64
+		r.State, triggeredThreshold = applyThresholds(compared, gc.thresholds, gc.triggersOn)
+
+		r.Details = graphiteComparisonDetails{
+			auditFunction: gc.auditFunctionName,
+			timeToAudit:   gc.timeToAudit,
+			timeShift:     gc.timeShift,
+			comparison:    gc.comparisonName,
+			triggerIf:     gc.triggerIfText,
+
+			current:   current,
+			previous:  previous,
+			compared:  compared,
+			threshold: triggeredThreshold,
+
+			graphite:    &g,
+			expression:  gc.expression,
+			seriesName:  s.Name,
+			measuredEnd: auditEnd,
+		}
+
+		readings = append(readings, r)
+	}
+	readings = append(readings, Reading{"_", state.Normal, now, nil})
+
+	return readings
+}
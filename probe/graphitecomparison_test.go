@@ -0,0 +1,80 @@
+package probe_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/yext/revere/probe"
+	"github.com/yext/revere/test"
+)
+
+var (
+	gcId        = 7
+	gcName      = "Graphite Comparison"
+	gcProbeType = GraphiteComparisonType{}
+	validGcJson = test.DefaultGraphiteComparisonProbeJson
+)
+
+func validGraphiteComparisonProbe() (*GraphiteComparisonProbe, error) {
+	probe, err := LoadFromParams(gcProbeType.Id(), validGcJson)
+	if err != nil {
+		return nil, err
+	}
+
+	gcProbe, ok := probe.(GraphiteComparisonProbe)
+	if !ok {
+		return nil, fmt.Errorf("Invalid probe loaded for probe type: %s\n", gcProbeType.Name())
+	}
+
+	return &gcProbe, nil
+}
+
+func TestGraphiteComparisonId(t *testing.T) {
+	if int(gcProbeType.Id()) != gcId {
+		t.Errorf("Expected graphite comparison probe type id: %d, got %d\n", gcId, gcProbeType.Id())
+	}
+}
+
+func TestGraphiteComparisonName(t *testing.T) {
+	if gcProbeType.Name() != gcName {
+		t.Errorf("Expected graphite comparison probe type name: %s, got %s\n", gcName, gcProbeType.Name())
+	}
+}
+
+func TestValidGraphiteComparison(t *testing.T) {
+	gcProbe, err := validGraphiteComparisonProbe()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if errs := gcProbe.Validate(); errs != nil {
+		t.Errorf("Unexpected errors for valid graphite comparison probe: %v\n", errs)
+	}
+}
+
+func TestInvalidGraphiteComparison(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*GraphiteComparisonProbe)
+	}{
+		{"expression", func(g *GraphiteComparisonProbe) { g.Expression = "" }},
+		{"audit function", func(g *GraphiteComparisonProbe) { g.AuditFunction = "" }},
+		{"comparison", func(g *GraphiteComparisonProbe) { g.Comparison = "product" }},
+		{"trigger if", func(g *GraphiteComparisonProbe) { g.TriggerIf = "==" }},
+		{"check period", func(g *GraphiteComparisonProbe) { g.CheckPeriod = 0 }},
+		{"audit period", func(g *GraphiteComparisonProbe) { g.AuditPeriodType = "" }},
+		{"time shift", func(g *GraphiteComparisonProbe) { g.TimeShift = 0 }},
+	}
+
+	for _, tt := range tests {
+		gcProbe, err := validGraphiteComparisonProbe()
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		tt.modify(gcProbe)
+		if errs := gcProbe.Validate(); errs == nil {
+			t.Errorf("Expected error for invalid %s\n", tt.name)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package probe
+
+// GraphiteComparisonDBModel defines the JSON serialization format for saving
+// Graphite comparison probes' settings in the database.
+type GraphiteComparisonDBModel struct {
+	ResourceID int64
+	Expression string
+
+	// Thresholds apply to the comparison of the current and time-shifted
+	// values, as computed by the comparison function named by Comparison.
+	Thresholds GraphiteThresholdThresholdsDBModel
+	Comparison string
+	TriggerIf  string
+
+	CheckPeriodMilli int64
+
+	TimeToAuditMilli        int64
+	RecentTimeToIgnoreMilli int64
+	AuditFunction           string
+
+	// TimeShiftMilli is how far back the window being compared against is.
+	TimeShiftMilli int64
+
+	// NoDataPolicy names how series without enough data in the audited
+	// window are reported; see noDataStates. Empty keeps their last state,
+	// but reports Normal when the expression returns no series.
+	// MinNonNullRatio is the fraction of points in the window that must be
+	// non-null for it to have enough data.
+	NoDataPolicy    string
+	MinNonNullRatio float64
+}
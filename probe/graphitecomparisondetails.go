@@ -0,0 +1,83 @@
+package probe
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/yext/revere/durationfmt"
+	"github.com/yext/revere/resource"
+)
+
+type graphiteComparisonDetails struct {
+	auditFunction string
+	timeToAudit   time.Duration
+	timeShift     time.Duration
+	comparison    string
+	triggerIf     string
+
+	current   float64
+	previous  float64
+	compared  float64
+	threshold float64
+
+	graphite    *resource.GraphiteDaemon
+	expression  string
+	seriesName  string
+	measuredEnd time.Time
+}
+
+func (d graphiteComparisonDetails) Text() string {
+	timeToAuditText := durationfmt.ExactMulti().Format(d.timeToAudit)
+	timeShiftText := durationfmt.ExactMulti().Format(d.timeShift)
+
+	var thresholdVal string
+	if !math.IsNaN(d.threshold) {
+		thresholdVal = fmt.Sprintf(" %s %g", d.triggerIf, d.threshold)
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s of last %s: %g", d.auditFunction, timeToAuditText, d.current),
+		fmt.Sprintf("%s of same period %s earlier: %g", d.auditFunction, timeShiftText, d.previous),
+		fmt.Sprintf("%s: %g%s", d.comparison, d.compared, thresholdVal),
+	}
+
+	return fmt.Sprintf("%s\n\nGraph: %s\n", strings.Join(lines, "\n"), d.graphURL())
+}
+
+func (d graphiteComparisonDetails) graphURL() string {
+	measuredStart := d.measuredEnd.Add(-d.timeToAudit)
+
+	timeHighlight := fmt.Sprintf(
+		`color(drawAsInfinite(timeSlice(timeFunction("", 1), "%s", "%s")), "yellow")`,
+		resource.GraphiteTimestamp(measuredStart),
+		resource.GraphiteTimestamp(d.measuredEnd))
+
+	target := fmt.Sprintf(`grep(%s, "^%s$")`,
+		d.expression,
+		strings.Replace(regexp.QuoteMeta(d.seriesName), `"`, `\"`, -1))
+	shift := fmt.Sprintf("%ds", int64(d.timeShift/time.Second))
+
+	targets := []string{
+		timeHighlight,
+		fmt.Sprintf(`color(alias(timeShift(%s, "%s"), "%s earlier"), "blue")`,
+			target, shift, durationfmt.ExactMulti().Format(d.timeShift)),
+		fmt.Sprintf(`color(alias(%s, "current"), "green")`, target),
+	}
+
+	contextTime := d.timeToAudit
+	if contextTime < 30*time.Minute {
+		contextTime = 30 * time.Minute
+	}
+
+	return d.graphite.RenderURL(targets, map[string]string{
+		"width":  "970",
+		"height": "600",
+		"tz":     "UTC",
+		"title":  d.seriesName,
+		"from":   resource.GraphiteTimestamp(measuredStart.Add(-2 * contextTime)),
+		"until":  resource.GraphiteTimestamp(d.measuredEnd.Add(contextTime)),
+	})
+}
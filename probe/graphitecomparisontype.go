@@ -0,0 +1,10 @@
+package probe
+
+import (
+	"github.com/jmoiron/sqlx/types"
+	"github.com/yext/revere/db"
+)
+
+func (GraphiteComparisonType) New(tx *db.Tx, config types.JSONText, readingsSink chan<- []Reading) (Probe, error) {
+	return newGraphiteComparison(tx, config, readingsSink)
+}
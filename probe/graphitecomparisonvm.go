@@ -0,0 +1,213 @@
+package probe
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/resource"
+	"github.com/yext/revere/util"
+)
+
+type GraphiteComparisonType struct{}
+
+type GraphiteComparisonProbe struct {
+	GraphiteComparisonType
+
+	URL               string
+	ResourceID        db.ResourceID
+	Expression        string
+	Thresholds        ThresholdsModel
+	Comparison        string
+	TriggerIf         string
+	AuditFunction     string
+	CheckPeriod       int64
+	CheckPeriodType   string
+	AuditPeriod       int64
+	AuditPeriodType   string
+	IgnoredPeriod     int64
+	IgnoredPeriodType string
+	TimeShift         int64
+	TimeShiftType     string
+	NoDataPolicy      string
+	MinNonNullRatio   float64
+}
+
+func init() {
+	registerProbeType(GraphiteComparisonType{})
+}
+
+func (GraphiteComparisonType) Id() db.ProbeType {
+	return 7
+}
+
+func (GraphiteComparisonType) Name() string {
+	return "Graphite Comparison"
+}
+
+func (GraphiteComparisonType) loadFromParams(probe string) (VM, error) {
+	var g GraphiteComparisonProbe
+	err := json.Unmarshal([]byte(probe), &g)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (GraphiteComparisonType) loadFromDb(encodedProbe string, tx *db.Tx) (VM, error) {
+	var g GraphiteComparisonDBModel
+	err := json.Unmarshal([]byte(encodedProbe), &g)
+	if err != nil {
+		return nil, err
+	}
+
+	checkPeriod, checkPeriodType := util.GetPeriodAndType(g.CheckPeriodMilli)
+	auditPeriod, auditPeriodType := util.GetPeriodAndType(g.TimeToAuditMilli)
+	ignoredPeriod, ignoredPeriodType := util.GetPeriodAndType(g.RecentTimeToIgnoreMilli)
+	timeShift, timeShiftType := util.GetPeriodAndType(g.TimeShiftMilli)
+
+	gds, err := loadGraphiteResource(tx, db.ResourceID(g.ResourceID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &GraphiteComparisonProbe{
+		URL:        gds.URL,
+		ResourceID: db.ResourceID(g.ResourceID),
+		Expression: g.Expression,
+		Thresholds: ThresholdsModel{
+			g.Thresholds.Warning,
+			g.Thresholds.Error,
+			g.Thresholds.Critical,
+		},
+		Comparison:        g.Comparison,
+		TriggerIf:         g.TriggerIf,
+		AuditFunction:     g.AuditFunction,
+		CheckPeriod:       checkPeriod,
+		CheckPeriodType:   checkPeriodType,
+		AuditPeriod:       auditPeriod,
+		AuditPeriodType:   auditPeriodType,
+		IgnoredPeriod:     ignoredPeriod,
+		IgnoredPeriodType: ignoredPeriodType,
+		TimeShift:         timeShift,
+		TimeShiftType:     timeShiftType,
+		NoDataPolicy:      g.NoDataPolicy,
+		MinNonNullRatio:   g.MinNonNullRatio,
+	}, nil
+}
+
+func (GraphiteComparisonType) blank() (VM, error) {
+	return &GraphiteComparisonProbe{
+		Comparison:    "ratio",
+		TimeShift:     1,
+		TimeShiftType: "day",
+	}, nil
+}
+
+func (GraphiteComparisonType) Templates() map[string]string {
+	return map[string]string{
+		"edit": "graphite-comparison-edit.html",
+		"view": "graphite-comparison-view.html",
+	}
+}
+
+func (GraphiteComparisonType) Scripts() map[string][]string {
+	return map[string][]string{
+		"edit": []string{
+			"graphite-comparison.js",
+			"graphite-resource-loader.js",
+		},
+	}
+}
+
+func (GraphiteComparisonType) AcceptedResourceTypes() []db.ResourceType {
+	return []db.ResourceType{
+		resource.Graphite{}.Id(),
+	}
+}
+
+func (g GraphiteComparisonProbe) HasResource(id db.ResourceID) bool {
+	return g.ResourceID == id
+}
+
+func (g GraphiteComparisonProbe) SerializeForFrontend() map[string]string {
+	var warningStr, errorStr, criticalStr string
+	if g.Thresholds.Warning != nil {
+		warningStr = strconv.FormatFloat(*g.Thresholds.Warning, 'f', -1, 64)
+	}
+	if g.Thresholds.Error != nil {
+		errorStr = strconv.FormatFloat(*g.Thresholds.Error, 'f', -1, 64)
+	}
+	if g.Thresholds.Critical != nil {
+		criticalStr = strconv.FormatFloat(*g.Thresholds.Critical, 'f', -1, 64)
+	}
+	return map[string]string{
+		"Expression": g.Expression,
+		"URL":        g.URL,
+		"Warning":    warningStr,
+		"Error":      errorStr,
+		"Critical":   criticalStr,
+	}
+}
+
+func (g GraphiteComparisonProbe) SerializeForDB() (string, error) {
+	gcDB := GraphiteComparisonDBModel{
+		ResourceID: int64(g.ResourceID),
+		Expression: g.Expression,
+		Thresholds: GraphiteThresholdThresholdsDBModel{
+			Warning:  g.Thresholds.Warning,
+			Error:    g.Thresholds.Error,
+			Critical: g.Thresholds.Critical,
+		},
+		Comparison:              g.Comparison,
+		TriggerIf:               g.TriggerIf,
+		CheckPeriodMilli:        util.GetMs(g.CheckPeriod, g.CheckPeriodType),
+		TimeToAuditMilli:        util.GetMs(g.AuditPeriod, g.AuditPeriodType),
+		RecentTimeToIgnoreMilli: util.GetMs(g.IgnoredPeriod, g.IgnoredPeriodType),
+		AuditFunction:           g.AuditFunction,
+		TimeShiftMilli:          util.GetMs(g.TimeShift, g.TimeShiftType),
+		NoDataPolicy:            g.NoDataPolicy,
+		MinNonNullRatio:         g.MinNonNullRatio,
+	}
+
+	gcDBJSON, err := json.Marshal(gcDB)
+	return string(gcDBJSON), err
+}
+
+func (g GraphiteComparisonProbe) Type() VMType {
+	return GraphiteComparisonType{}
+}
+
+func (g GraphiteComparisonProbe) Validate() (errs []string) {
+	if g.Expression == "" {
+		errs = append(errs, "Graphite expression is required")
+	}
+
+	if _, ok := auditFunctions[g.AuditFunction]; !ok {
+		errs = append(errs, "Invalid audit function")
+	}
+
+	if _, ok := comparisonFunctions[g.Comparison]; !ok {
+		errs = append(errs, "Invalid comparison")
+	}
+
+	if _, ok := triggerIfFunctions[g.TriggerIf]; !ok {
+		errs = append(errs, "Invalid trigger if")
+	}
+
+	if util.GetMs(g.CheckPeriod, g.CheckPeriodType) <= 0 {
+		errs = append(errs, "Invalid check period")
+	}
+
+	if util.GetMs(g.AuditPeriod, g.AuditPeriodType) <= 0 {
+		errs = append(errs, "Invalid audit period")
+	}
+
+	if util.GetMs(g.TimeShift, g.TimeShiftType) <= 0 {
+		errs = append(errs, "Invalid time shift")
+	}
+
+	errs = append(errs, validateNoDataSettings(g.NoDataPolicy, g.MinNonNullRatio)...)
+
+	return
+}
@@ -0,0 +1,157 @@
+package probe
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/state"
+)
+
+// keepLastStateNoDataPolicy names the no data policy that reports nothing for
+// series without enough data, so they keep whatever state they were last in.
+const keepLastStateNoDataPolicy = "keep"
+
+// defaultNoDataPolicy is the policy of probes that don't choose one, including
+// those saved before no data policies existed. Like keeping the last state, it
+// reports nothing for series without enough data, but when the expression
+// returns no series at all it reports the probe's "_" subprobe Normal, as
+// Graphite probes always have.
+const defaultNoDataPolicy = ""
+
+// noDataStates maps the names of the other no data policies to the state they
+// report for series without enough data.
+var noDataStates = map[string]state.State{
+	"normal":  state.Normal,
+	"unknown": state.Unknown,
+	"warning": state.Warning,
+	"error":   state.Error,
+}
+
+// noDataPolicy decides what a Graphite probe reports for a series that has too
+// few non-null points in its audited window, or for an expression that
+// returns no series at all.
+type noDataPolicy struct {
+	name            string
+	minNonNullRatio float64
+
+	// alwaysEnoughData is set for audit functions that are meaningful
+	// however few non-null points there are.
+	alwaysEnoughData bool
+}
+
+func newNoDataPolicy(name string, minNonNullRatio float64, auditFunction string) (noDataPolicy, error) {
+	if !isValidNoDataPolicy(name) {
+		return noDataPolicy{}, errors.Errorf("unknown no data policy: %s", name)
+	}
+	if minNonNullRatio < 0 || minNonNullRatio > 1 {
+		return noDataPolicy{}, errors.Errorf("min non-null ratio out of range: %g", minNonNullRatio)
+	}
+	return noDataPolicy{name, minNonNullRatio, auditFunction == "count"}, nil
+}
+
+func isValidNoDataPolicy(name string) bool {
+	_, ok := noDataStates[name]
+	return ok || name == keepLastStateNoDataPolicy || name == defaultNoDataPolicy
+}
+
+// validateNoDataSettings returns the problems with a Graphite probe's no data
+// settings, for use by the probes' view models.
+func validateNoDataSettings(policy string, minNonNullRatio float64) (errs []string) {
+	if !isValidNoDataPolicy(policy) {
+		errs = append(errs, "Invalid no data policy")
+	}
+	if minNonNullRatio < 0 || minNonNullRatio > 1 {
+		errs = append(errs, "Minimum ratio of non-null points must be between 0 and 1")
+	}
+	return
+}
+
+// hasEnoughData returns whether values has at least one non-null point and at
+// least the minimum ratio of them.
+func (p noDataPolicy) hasEnoughData(values []float64) bool {
+	if p.alwaysEnoughData {
+		return true
+	}
+	nonNull := countNonNull(values)
+	return nonNull > 0 && float64(nonNull) >= p.minNonNullRatio*float64(len(values))
+}
+
+// readings returns what to report for subprobe when it does not have enough
+// data, which is nothing when keeping the last state.
+func (p noDataPolicy) readings(subprobe string, now time.Time, details noDataDetails) []Reading {
+	s, ok := noDataStates[p.name]
+	if !ok {
+		return nil
+	}
+	return []Reading{{subprobe, s, now, details}}
+}
+
+// noSeriesReadings returns what to report when expression returns no series at
+// all.
+func (p noDataPolicy) noSeriesReadings(expression string, now time.Time) []Reading {
+	if p.name == defaultNoDataPolicy {
+		return []Reading{{"_", state.Normal, now, nil}}
+	}
+	return p.readings("_", now, noDataDetails{expression: expression})
+}
+
+func countNonNull(values []float64) int {
+	count := 0
+	for _, value := range values {
+		if !math.IsNaN(value) {
+			count += 1
+		}
+	}
+	return count
+}
+
+type noDataDetails struct {
+	expression string
+
+	// nonNull and total count the points in the audited window, or in the
+	// compared period if missing is set. Both are zero when the expression
+	// returned no series.
+	nonNull         int
+	total           int
+	minNonNullRatio float64
+
+	// missing names what there was no data to compare the audited window
+	// against, such as a baseline, and period the period it comes from.
+	// Both are empty when the audited window itself lacks data.
+	missing string
+	period  string
+}
+
+func newNoDataDetails(expression string, values []float64, minNonNullRatio float64) noDataDetails {
+	return noDataDetails{expression, countNonNull(values), len(values), minNonNullRatio, "", ""}
+}
+
+// newNoBaselineDetails describes a series without any data in the baseline
+// period to compare it against.
+func newNoBaselineDetails(expression string, baselineValues []float64) noDataDetails {
+	return noDataDetails{expression, countNonNull(baselineValues), len(baselineValues), 0, "baseline", "baseline"}
+}
+
+// newNoPreviousDetails describes a series without any data in the
+// time-shifted period to compare it against.
+func newNoPreviousDetails(expression string, previousValues []float64) noDataDetails {
+	return noDataDetails{expression, countNonNull(previousValues), len(previousValues), 0, "previous data", "time-shifted"}
+}
+
+func (d noDataDetails) Text() string {
+	if d.missing != "" {
+		return fmt.Sprintf("No %s to compare against: %d of %d points in the %s period were non-null",
+			d.missing, d.nonNull, d.total, d.period)
+	}
+	if d.total == 0 {
+		return fmt.Sprintf("No data returned for %s", d.expression)
+	}
+	text := fmt.Sprintf("Not enough data: %d of %d points were non-null", d.nonNull, d.total)
+	if d.minNonNullRatio > 0 {
+		text += fmt.Sprintf(" (at least %g%% required)", d.minNonNullRatio*100)
+	}
+	return text
+}
@@ -0,0 +1,161 @@
+package probe
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yext/revere/state"
+)
+
+func TestNoSeriesReadings(t *testing.T) {
+	now := time.Now()
+	for _, c := range []struct {
+		policy   string
+		expected []state.State
+	}{
+		{"", []state.State{state.Normal}},
+		{"keep", nil},
+		{"unknown", []state.State{state.Unknown}},
+	} {
+		policy, err := newNoDataPolicy(c.policy, 0, "mean")
+		if err != nil {
+			t.Fatalf("Unexpected error for no data policy %q: %v\n", c.policy, err)
+		}
+
+		readings := policy.noSeriesReadings("a.b.c", now)
+		if len(readings) != len(c.expected) {
+			t.Errorf("Expected %d readings for no data policy %q, got %d\n",
+				len(c.expected), c.policy, len(readings))
+			continue
+		}
+		for i, r := range readings {
+			if r.Subprobe != "_" || r.State != c.expected[i] {
+				t.Errorf("Expected _ %s for no data policy %q, got %s %s\n",
+					c.expected[i], c.policy, r.Subprobe, r.State)
+			}
+		}
+	}
+}
+
+func TestDefaultNoDataPolicyKeepsSeriesState(t *testing.T) {
+	policy, err := newNoDataPolicy("", 0, "mean")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if readings := policy.readings("a.b.c", time.Now(), noDataDetails{}); readings != nil {
+		t.Errorf("Expected no readings for series without data, got %v\n", readings)
+	}
+}
+
+func TestAnomalyWithoutBaselineUsesNoDataPolicy(t *testing.T) {
+	// Points a minute apart ending now, where only the last is audited
+	// and the rest are the baseline.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now().Add(-4 * time.Minute).Unix()
+		fmt.Fprintf(w, "a.b,%d,%d,60|None,None,None,50\n", start, start+240)
+	}))
+	defer server.Close()
+
+	errorValue := 3.0
+	for _, c := range []struct {
+		policy   string
+		expected []state.State
+	}{
+		{"", nil},
+		{"unknown", []state.State{state.Unknown}},
+	} {
+		noData, err := newNoDataPolicy(c.policy, 0, "avg")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ga := &GraphiteAnomaly{
+			graphiteBase:    server.URL + "/",
+			expression:      "a.*",
+			timeToAudit:     90 * time.Second,
+			baselinePeriod:  time.Hour,
+			thresholds:      newStateThresholds(nil, &errorValue, nil),
+			summarizeValues: auditFunctions["avg"],
+			deviationUnit:   "stddev",
+			direction:       "both",
+			noData:          noData,
+		}
+
+		var states []state.State
+		for _, r := range ga.Check() {
+			if r.Subprobe != "a.b" {
+				continue
+			}
+			states = append(states, r.State)
+			if d, ok := r.Details.(noDataDetails); !ok || !strings.HasPrefix(d.Text(), "No baseline") {
+				t.Errorf("Expected details explaining the missing baseline, got %v\n", r.Details)
+			}
+		}
+		if len(states) != len(c.expected) || (len(states) > 0 && states[0] != c.expected[0]) {
+			t.Errorf("Expected %v for no data policy %q, got %v\n", c.expected, c.policy, states)
+		}
+	}
+}
+
+func TestComparisonWithoutPreviousDataUsesNoDataPolicy(t *testing.T) {
+	// Each check queries the current window, then the time-shifted one,
+	// where a.b is missing and a.c has only nulls.
+	queries := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		queries++
+		if queries%2 == 1 {
+			fmt.Fprint(w, "a.b,0,120,60|50,50\na.c,0,120,60|50,50\n")
+			return
+		}
+		fmt.Fprint(w, "a.c,0,120,60|None,None\n")
+	}))
+	defer server.Close()
+
+	warningValue := 0.5
+	for _, c := range []struct {
+		policy   string
+		expected state.State
+		reported bool
+	}{
+		{"", state.Normal, false},
+		{"error", state.Error, true},
+	} {
+		noData, err := newNoDataPolicy(c.policy, 0, "avg")
+		if err != nil {
+			t.Fatal(err)
+		}
+		gc := &GraphiteComparison{
+			graphiteBase:    server.URL + "/",
+			expression:      "a.*",
+			timeToAudit:     2 * time.Minute,
+			timeShift:       7 * 24 * time.Hour,
+			thresholds:      newStateThresholds(&warningValue, nil, nil),
+			summarizeValues: auditFunctions["avg"],
+			compare:         comparisonFunctions["ratio"],
+			triggersOn:      triggerIfFunctions["<="],
+			noData:          noData,
+		}
+
+		reported := make(map[string]state.State)
+		for _, r := range gc.Check() {
+			if r.Subprobe == "_" {
+				continue
+			}
+			reported[r.Subprobe] = r.State
+			if d, ok := r.Details.(noDataDetails); !ok || !strings.HasPrefix(d.Text(), "No previous data") {
+				t.Errorf("Expected details explaining the missing previous data for %s, got %v\n",
+					r.Subprobe, r.Details)
+			}
+		}
+		for _, subprobe := range []string{"a.b", "a.c"} {
+			s, ok := reported[subprobe]
+			if ok != c.reported || (ok && s != c.expected) {
+				t.Errorf("Expected %s reported %t with state %s for no data policy %q, got %t %s\n",
+					subprobe, c.reported, c.expected, c.policy, ok, s)
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package probe
 import (
 	"fmt"
 	"math"
+	"sort"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -20,21 +21,146 @@ type GraphiteThreshold struct {
 	*Polling
 
 	graphiteBase       string
+	resourceID         db.ResourceID
 	expression         string
 	timeToAudit        time.Duration
 	recentTimeToIgnore time.Duration
 
-	thresholds      []graphiteThresholdThreshold
+	thresholds      []stateThreshold
 	summarizeValues func(values []float64) float64
 	triggersOn      func(summaryValue, threshold float64) bool
 
 	auditFunctionName string
 	triggerIfText     string
+
+	// breachValue is the value points are compared against when auditing
+	// with percentBreachingAuditFunction, and nil otherwise.
+	breachValue *float64
+
+	noData noDataPolicy
+
+	// lastStates holds the state each subprobe was reported in on the
+	// last check, for applying recovery thresholds.
+	lastStates map[string]state.State
 }
 
-type graphiteThresholdThreshold struct {
+// stateThreshold is a value at or past which a threshold probe reports state.
+type stateThreshold struct {
 	state     state.State
 	threshold float64
+
+	// recovery is the value a subprobe already in state must get back past
+	// before leaving it, or NaN if leaving only requires no longer being
+	// past threshold.
+	recovery float64
+}
+
+// newStateThresholds returns the set thresholds among the given ones in
+// increasing severity order.
+func newStateThresholds(warningValue, errorValue, criticalValue *float64) []stateThreshold {
+	var thresholds []stateThreshold
+	if warningValue != nil {
+		thresholds = append(thresholds, stateThreshold{state.Warning, *warningValue, math.NaN()})
+	}
+	if errorValue != nil {
+		thresholds = append(thresholds, stateThreshold{state.Error, *errorValue, math.NaN()})
+	}
+	if criticalValue != nil {
+		thresholds = append(thresholds, stateThreshold{state.Critical, *criticalValue, math.NaN()})
+	}
+	return thresholds
+}
+
+// applyThresholds returns the state for summaryValue given thresholds in
+// increasing severity order, along with the threshold that was crossed, or NaN
+// if none was.
+func applyThresholds(summaryValue float64, thresholds []stateThreshold, triggersOn func(summaryValue, threshold float64) bool) (state.State, float64) {
+	s := state.Normal
+	triggeredThreshold := math.NaN()
+	for _, t := range thresholds {
+		if triggersOn(summaryValue, t.threshold) {
+			s = t.state
+			triggeredThreshold = t.threshold
+		}
+	}
+	return s, triggeredThreshold
+}
+
+// setRecoveryThresholds sets the recovery values of thresholds for the states
+// whose recovery values are given.
+func setRecoveryThresholds(thresholds []stateThreshold, warningValue, errorValue, criticalValue *float64) {
+	recoveries := map[state.State]*float64{
+		state.Warning:  warningValue,
+		state.Error:    errorValue,
+		state.Critical: criticalValue,
+	}
+	for i := range thresholds {
+		if recovery := recoveries[thresholds[i].state]; recovery != nil {
+			thresholds[i].recovery = *recovery
+		}
+	}
+}
+
+// readingStates returns the state of each subprobe in readings. Replacing
+// lastStates with it on each check drops the subprobes of series that went
+// away, so they don't pile up.
+func readingStates(readings []Reading) map[string]state.State {
+	states := make(map[string]state.State, len(readings))
+	for _, r := range readings {
+		states[r.Subprobe] = r.State
+	}
+	return states
+}
+
+// applyThresholdsWithRecovery is like applyThresholds, except that a subprobe
+// last in lastState stays in it, or in the most severe state below it, for as
+// long as summaryValue is still past that state's recovery value. The recovery
+// value keeping the subprobe in its state is also returned, or NaN if there is
+// none.
+func applyThresholdsWithRecovery(summaryValue float64, thresholds []stateThreshold, triggersOn func(summaryValue, threshold float64) bool, lastState state.State) (state.State, float64, float64) {
+	s, triggeredThreshold := applyThresholds(summaryValue, thresholds, triggersOn)
+	recovery := math.NaN()
+	for _, t := range thresholds {
+		if t.state > s && t.state <= lastState &&
+			!math.IsNaN(t.recovery) && triggersOn(summaryValue, t.recovery) {
+			s = t.state
+			triggeredThreshold = t.threshold
+			recovery = t.recovery
+		}
+	}
+	return s, triggeredThreshold, recovery
+}
+
+// validateRecoveryThresholds returns the problems with a threshold probe's
+// recovery thresholds, for use by the probes' view models. Each must have a
+// matching threshold and not be past it.
+func validateRecoveryThresholds(thresholds, recoveries ThresholdsModel, triggerIf string) (errs []string) {
+	triggersOn, ok := triggerIfFunctions[triggerIf]
+	if !ok {
+		return
+	}
+
+	levels := []struct {
+		name                string
+		threshold, recovery *float64
+	}{
+		{"Warning", thresholds.Warning, recoveries.Warning},
+		{"Error", thresholds.Error, recoveries.Error},
+		{"Critical", thresholds.Critical, recoveries.Critical},
+	}
+	for _, l := range levels {
+		if l.recovery == nil {
+			continue
+		}
+		if l.threshold == nil {
+			errs = append(errs, fmt.Sprintf("%s recovery threshold requires a %s threshold", l.name, l.name))
+			continue
+		}
+		if *l.recovery != *l.threshold && triggersOn(*l.recovery, *l.threshold) {
+			errs = append(errs, fmt.Sprintf("%s recovery threshold must not be past the %s threshold", l.name, l.name))
+		}
+	}
+	return
 }
 
 func newGraphiteThreshold(tx *db.Tx, configJSON types.JSONText, readingsSink chan<- []Reading) (Probe, error) {
@@ -52,62 +178,90 @@ func newGraphiteThreshold(tx *db.Tx, configJSON types.JSONText, readingsSink cha
 		return nil, errors.Mask(err)
 	}
 
-	dbds, err := tx.LoadResource(db.ResourceID(config.ResourceID))
+	gds, err := loadGraphiteResource(tx, db.ResourceID(config.ResourceID))
 	if err != nil {
 		return nil, errors.Mask(err)
 	}
 
-	if dbds == nil {
-		return nil, errors.Errorf("no resource found: %d", config.ResourceID)
-	}
-
-	ds, err := resource.LoadFromDB(resource.GraphiteResource{}.Id(), dbds.Resource)
-	if err != nil {
-		return nil, errors.Mask(err)
-	}
-
-	gds, found := ds.(*resource.GraphiteResource)
-	if !found {
-		return nil, errors.New("not a graphite resource")
-	}
-
 	gt.graphiteBase = fmt.Sprintf("http://%s/", gds.URL)
+	gt.resourceID = db.ResourceID(config.ResourceID)
 	gt.expression = config.Expression
 	gt.timeToAudit = time.Duration(config.TimeToAuditMilli) * time.Millisecond
 	gt.recentTimeToIgnore = time.Duration(config.RecentTimeToIgnoreMilli) * time.Millisecond
 
-	// Must be in increasing severity order.
-	if config.Thresholds.Warning != nil {
-		gt.thresholds = append(gt.thresholds,
-			graphiteThresholdThreshold{state.Warning, *config.Thresholds.Warning})
-	}
-	if config.Thresholds.Error != nil {
-		gt.thresholds = append(gt.thresholds,
-			graphiteThresholdThreshold{state.Error, *config.Thresholds.Error})
-	}
-	if config.Thresholds.Critical != nil {
-		gt.thresholds = append(gt.thresholds,
-			graphiteThresholdThreshold{state.Critical, *config.Thresholds.Critical})
-	}
+	gt.thresholds = newStateThresholds(
+		config.Thresholds.Warning, config.Thresholds.Error, config.Thresholds.Critical)
+	setRecoveryThresholds(gt.thresholds, config.RecoveryThresholds.Warning,
+		config.RecoveryThresholds.Error, config.RecoveryThresholds.Critical)
+	gt.lastStates = make(map[string]state.State)
 
 	var ok bool
 
-	gt.summarizeValues, ok = auditFunctions[config.AuditFunction]
-	if !ok {
-		return nil, errors.Errorf("unknown audit function: %s", config.AuditFunction)
-	}
-
 	gt.triggersOn, ok = triggerIfFunctions[config.TriggerIf]
 	if !ok {
 		return nil, errors.Errorf("unknown trigger if: %s", config.TriggerIf)
 	}
 
+	if config.AuditFunction == percentBreachingAuditFunction {
+		if config.BreachValue == nil {
+			return nil, errors.New("percent breaching requires a breach value")
+		}
+		breachValue := *config.BreachValue
+		pointTriggersOn := gt.triggersOn
+		gt.summarizeValues = func(values []float64) float64 {
+			return percentBreaching(values, breachValue, pointTriggersOn)
+		}
+		// Thresholds are now percentages of points, which are worse
+		// the higher they are.
+		gt.triggersOn = triggerIfFunctions[">="]
+		gt.breachValue = config.BreachValue
+	} else {
+		gt.summarizeValues, ok = auditFunctions[config.AuditFunction]
+		if !ok {
+			return nil, errors.Errorf("unknown audit function: %s", config.AuditFunction)
+		}
+	}
+
 	gt.auditFunctionName = config.AuditFunction
 	gt.triggerIfText = config.TriggerIf
 
+	gt.noData, err = newNoDataPolicy(config.NoDataPolicy, config.MinNonNullRatio, config.AuditFunction)
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+
 	return &gt, nil
 }
 
+func loadGraphiteResource(tx *db.Tx, id db.ResourceID) (*resource.GraphiteResource, error) {
+	dbds, err := tx.LoadResource(id)
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+
+	if dbds == nil {
+		return nil, errors.Errorf("no resource found: %d", id)
+	}
+
+	ds, err := resource.LoadFromDB(resource.GraphiteResource{}.Id(), dbds.Resource)
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+
+	gds, found := ds.(*resource.GraphiteResource)
+	if !found {
+		return nil, errors.New("not a graphite resource")
+	}
+
+	return gds, nil
+}
+
+// percentBreachingAuditFunction names the audit function that reports the
+// percent of points in the audited window that are past a breach value, as
+// judged by the probe's trigger if. Since it needs that extra configuration,
+// it is not in auditFunctions.
+const percentBreachingAuditFunction = "pct_breaching"
+
 var (
 	auditFunctions = map[string]func([]float64) float64{
 		"avg": func(values []float64) float64 {
@@ -145,6 +299,52 @@ var (
 			}
 			return min
 		},
+		"sum": func(values []float64) float64 {
+			sum := float64(0)
+			count := 0
+			for _, value := range values {
+				if !math.IsNaN(value) {
+					sum += value
+					count += 1
+				}
+			}
+			if count == 0 {
+				return math.NaN()
+			}
+			return sum
+		},
+		"last": func(values []float64) float64 {
+			for i := len(values) - 1; i >= 0; i-- {
+				if !math.IsNaN(values[i]) {
+					return values[i]
+				}
+			}
+			return math.NaN()
+		},
+		"count": func(values []float64) float64 {
+			count := 0
+			for _, value := range values {
+				if !math.IsNaN(value) {
+					count += 1
+				}
+			}
+			return float64(count)
+		},
+		"p50": percentileFunction(50),
+		"p90": percentileFunction(90),
+		"p95": percentileFunction(95),
+		"p99": percentileFunction(99),
+	}
+
+	// auditFunctionTexts describes audit functions whose names are not
+	// self-explanatory.
+	auditFunctionTexts = map[string]string{
+		"last":  "last value",
+		"count": "count of non-null values",
+		"p50":   "50th percentile",
+		"p90":   "90th percentile",
+		"p95":   "95th percentile",
+		"p99":   "99th percentile",
 	}
 
 	triggerIfFunctions = map[string]func(float64, float64) bool{
@@ -163,12 +363,74 @@ var (
 	}
 )
 
+// percentileFunction returns an audit function computing the pth percentile of
+// the non-null values using the nearest-rank method.
+func percentileFunction(p float64) func([]float64) float64 {
+	return func(values []float64) float64 {
+		sorted := make([]float64, 0, len(values))
+		for _, value := range values {
+			if !math.IsNaN(value) {
+				sorted = append(sorted, value)
+			}
+		}
+		if len(sorted) == 0 {
+			return math.NaN()
+		}
+		sort.Float64s(sorted)
+
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		if rank < 1 {
+			rank = 1
+		}
+		return sorted[rank-1]
+	}
+}
+
+// percentBreaching returns the percent of non-null values for which
+// triggersOn(value, breachValue) holds.
+func percentBreaching(values []float64, breachValue float64, triggersOn func(float64, float64) bool) float64 {
+	breaching, count := 0, 0
+	for _, value := range values {
+		if math.IsNaN(value) {
+			continue
+		}
+		count += 1
+		if triggersOn(value, breachValue) {
+			breaching += 1
+		}
+	}
+	if count == 0 {
+		return math.NaN()
+	}
+	return float64(breaching) / float64(count) * 100
+}
+
+// isValidAuditFunction returns whether name is a known audit function, for
+// probes that support percentBreachingAuditFunction.
+func isValidAuditFunction(name string) bool {
+	_, ok := auditFunctions[name]
+	return ok || name == percentBreachingAuditFunction
+}
+
+// auditFunctionText returns a human-readable description of the audit
+// function with the given name.
+func auditFunctionText(name string) string {
+	if text, ok := auditFunctionTexts[name]; ok {
+		return text
+	}
+	return name
+}
+
+func (gt *GraphiteThreshold) Resource() db.ResourceID {
+	return gt.resourceID
+}
+
 func (gt *GraphiteThreshold) Check() []Reading {
 	now := time.Now()
 
 	auditEnd := now.Add(-gt.recentTimeToIgnore)
 
-	g := resource.GraphiteDaemon{gt.graphiteBase}
+	g := resource.GraphiteDaemon{Base: gt.graphiteBase}
 
 	series, err := g.Query(gt.expression, auditEnd.Add(-gt.timeToAudit), auditEnd)
 	if err != nil {
@@ -176,30 +438,27 @@ func (gt *GraphiteThreshold) Check() []Reading {
 		log.WithError(err).Error("Could not query Graphite.")
 
 		// TODO(eefi): Put err in the details.
-		return []Reading{{"_", state.Unknown, now, nil}}
+		return unreachableReadings(now, err)
 	}
 
 	if len(series) == 0 {
-		return []Reading{{"_", state.Normal, now, nil}}
+		return gt.noData.noSeriesReadings(gt.expression, now)
 	}
 
 	readings := make([]Reading, 0, len(series)+1)
 	for _, s := range series {
 		summaryValue := gt.summarizeValues(s.Values)
-		if math.IsNaN(summaryValue) {
-			// Series was all NaNs.
+		if math.IsNaN(summaryValue) || !gt.noData.hasEnoughData(s.Values) {
+			readings = append(readings, gt.noData.readings(
+				s.Name, now, newNoDataDetails(gt.expression, s.Values, gt.noData.minNonNullRatio))...)
 			continue
 		}
 
 		r := Reading{s.Name, state.Normal, now, nil}
 
-		triggeredThreshold := math.NaN()
-		for _, t := range gt.thresholds {
-			if gt.triggersOn(summaryValue, t.threshold) {
-				r.State = t.state
-				triggeredThreshold = t.threshold
-			}
-		}
+		var triggeredThreshold, recovery float64
+		r.State, triggeredThreshold, recovery = applyThresholdsWithRecovery(
+			summaryValue, gt.thresholds, gt.triggersOn, gt.lastStates[s.Name])
 
 		r.Details = graphiteThresholdDetails{
 			auditFunction: gt.auditFunctionName,
@@ -208,6 +467,9 @@ func (gt *GraphiteThreshold) Check() []Reading {
 
 			measured:  summaryValue,
 			threshold: triggeredThreshold,
+			recovery:  recovery,
+
+			breachValue: gt.breachValue,
 
 			graphite:    &g,
 			expression:  gt.expression,
@@ -219,5 +481,7 @@ func (gt *GraphiteThreshold) Check() []Reading {
 	}
 	readings = append(readings, Reading{"_", state.Normal, now, nil})
 
+	gt.lastStates = readingStates(readings)
+
 	return readings
 }
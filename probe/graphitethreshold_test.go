@@ -0,0 +1,69 @@
+package probe
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yext/revere/state"
+)
+
+func TestGraphiteThresholdForgetsAbsentSeries(t *testing.T) {
+	var response string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, response)
+	}))
+	defer server.Close()
+
+	errorValue, errorRecovery := 40.0, 30.0
+	noData, err := newNoDataPolicy("", 0, "avg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gt := &GraphiteThreshold{
+		graphiteBase:    server.URL + "/",
+		expression:      "a.*",
+		timeToAudit:     5 * time.Minute,
+		thresholds:      newStateThresholds(nil, &errorValue, nil),
+		summarizeValues: auditFunctions["avg"],
+		triggersOn:      triggerIfFunctions[">"],
+		noData:          noData,
+		lastStates:      make(map[string]state.State),
+	}
+	setRecoveryThresholds(gt.thresholds, nil, &errorRecovery, nil)
+
+	expectState := func(readings []Reading, subprobe string, expected state.State) {
+		for _, r := range readings {
+			if r.Subprobe == subprobe {
+				if r.State != expected {
+					t.Errorf("Expected %s to be %s, got %s\n", subprobe, expected, r.State)
+				}
+				return
+			}
+		}
+		t.Errorf("Expected a reading for %s\n", subprobe)
+	}
+
+	response = "a.b,0,60,60|50\na.c,0,60,60|10\n"
+	readings := gt.Check()
+	expectState(readings, "a.b", state.Error)
+
+	// Still past the recovery threshold, so still Error.
+	response = "a.b,0,60,60|35\na.c,0,60,60|10\n"
+	expectState(gt.Check(), "a.b", state.Error)
+
+	response = "a.c,0,60,60|10\n"
+	gt.Check()
+	if _, ok := gt.lastStates["a.b"]; ok {
+		t.Errorf("Expected the state of the absent series to be forgotten\n")
+	}
+	if len(gt.lastStates) != 2 {
+		t.Errorf("Expected states for a.c and _ only, got %v\n", gt.lastStates)
+	}
+
+	// Coming back, the series starts over rather than recovering.
+	response = "a.b,0,60,60|35\na.c,0,60,60|10\n"
+	expectState(gt.Check(), "a.b", state.Normal)
+}
@@ -9,11 +9,27 @@ type GraphiteThresholdDBModel struct {
 	Thresholds GraphiteThresholdThresholdsDBModel
 	TriggerIf  string
 
+	// RecoveryThresholds are what a subprobe must get back past to leave the
+	// state of the corresponding threshold. Unset ones use the threshold.
+	RecoveryThresholds GraphiteThresholdThresholdsDBModel
+
 	CheckPeriodMilli int64
 
 	TimeToAuditMilli        int64
 	RecentTimeToIgnoreMilli int64
 	AuditFunction           string
+
+	// BreachValue is what points are compared against when AuditFunction
+	// is percentBreachingAuditFunction.
+	BreachValue *float64
+
+	// NoDataPolicy names how series without enough data in the audited
+	// window are reported; see noDataStates. Empty keeps their last state,
+	// but reports Normal when the expression returns no series.
+	// MinNonNullRatio is the fraction of points in the window that must be
+	// non-null for it to have enough data.
+	NoDataPolicy    string
+	MinNonNullRatio float64
 }
 
 // GraphiteThresholdThresholdsDBModel defines the JSON serialization format for
@@ -19,6 +19,13 @@ type graphiteThresholdDetails struct {
 	measured  float64
 	threshold float64
 
+	// recovery is the recovery value keeping the subprobe in its state, or
+	// NaN if the threshold itself was crossed.
+	recovery float64
+
+	// breachValue is set when measured is the percent of points past it.
+	breachValue *float64
+
 	graphite    *resource.GraphiteDaemon
 	expression  string
 	seriesName  string
@@ -27,14 +34,26 @@ type graphiteThresholdDetails struct {
 
 func (d graphiteThresholdDetails) Text() string {
 	timeToAuditText := durationfmt.ExactMulti().Format(d.timeToAudit)
-	measuredText := fmt.Sprintf("%s of last %s", d.auditFunction, timeToAuditText)
+
+	var measuredText string
+	thresholdTriggerIf := d.triggerIf
+	if d.breachValue != nil {
+		measuredText = fmt.Sprintf("percent of points in last %s %s %g",
+			timeToAuditText, d.triggerIf, *d.breachValue)
+		thresholdTriggerIf = ">="
+	} else {
+		measuredText = fmt.Sprintf("%s of last %s", auditFunctionText(d.auditFunction), timeToAuditText)
+	}
 
 	var thresholdText, thresholdVal string
 	if math.IsNaN(d.threshold) {
 		thresholdText, thresholdVal = "", ""
+	} else if !math.IsNaN(d.recovery) {
+		thresholdText = fmt.Sprintf(" %s recovery threshold", thresholdTriggerIf)
+		thresholdVal = fmt.Sprintf(" %s %g (threshold %g)", thresholdTriggerIf, d.recovery, d.threshold)
 	} else {
-		thresholdText = fmt.Sprintf(" %s threshold", d.triggerIf)
-		thresholdVal = fmt.Sprintf(" %s %g", d.triggerIf, d.threshold)
+		thresholdText = fmt.Sprintf(" %s threshold", thresholdTriggerIf)
+		thresholdVal = fmt.Sprintf(" %s %g", thresholdTriggerIf, d.threshold)
 	}
 
 	firstLine := fmt.Sprintf("%s%s: %g%s",
@@ -54,11 +73,16 @@ func (d graphiteThresholdDetails) graphURL() string {
 		resource.GraphiteTimestamp(d.measuredEnd))
 	targets = append(targets, timeHighlight)
 
-	if !math.IsNaN(d.threshold) {
-		thresholdLine := fmt.Sprintf(`color(constantLine(%g), "red")`, d.threshold)
+	if line, ok := d.thresholdLine(); ok {
+		thresholdLine := fmt.Sprintf(`color(constantLine(%g), "red")`, line)
 		targets = append(targets, thresholdLine)
 	}
 
+	if !math.IsNaN(d.recovery) && d.breachValue == nil {
+		recoveryLine := fmt.Sprintf(`color(constantLine(%g), "orange")`, d.recovery)
+		targets = append(targets, recoveryLine)
+	}
+
 	targets = append(targets, fmt.Sprintf(`color(%s, "green")`, d.target()))
 
 	args := map[string]string{
@@ -69,8 +93,8 @@ func (d graphiteThresholdDetails) graphURL() string {
 	}
 
 	title := d.seriesName
-	if !math.IsNaN(d.threshold) {
-		title += fmt.Sprintf(" vs %g", d.threshold)
+	if line, ok := d.thresholdLine(); ok {
+		title += fmt.Sprintf(" vs %g", line)
 	}
 	args["title"] = title
 
@@ -84,6 +108,16 @@ func (d graphiteThresholdDetails) graphURL() string {
 	return d.graphite.RenderURL(targets, args)
 }
 
+// thresholdLine returns the value to draw as the threshold on the graph, if
+// any. For percent breaching, that is the breach value rather than the
+// percentage threshold.
+func (d graphiteThresholdDetails) thresholdLine() (float64, bool) {
+	if d.breachValue != nil {
+		return *d.breachValue, true
+	}
+	return d.threshold, !math.IsNaN(d.threshold)
+}
+
 func (d graphiteThresholdDetails) valuesURL() string {
 	return d.graphite.RenderURL([]string{d.target()}, map[string]string{
 		"format": "csv",
@@ -5,9 +5,7 @@ import (
 	"github.com/yext/revere/db"
 )
 
-type graphiteThresholdType struct{}
-
 // TODO: Figure out something better than passing the transaction all the way through
-func (_ graphiteThresholdType) New(tx *db.Tx, config types.JSONText, readingsSink chan<- []Reading) (Probe, error) {
+func (GraphiteThresholdType) New(tx *db.Tx, config types.JSONText, readingsSink chan<- []Reading) (Probe, error) {
 	return newGraphiteThreshold(tx, config, readingsSink)
 }
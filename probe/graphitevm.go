@@ -4,8 +4,6 @@ import (
 	"encoding/json"
 	"strconv"
 
-	"github.com/juju/errors"
-
 	"github.com/yext/revere/db"
 	"github.com/yext/revere/resource"
 	"github.com/yext/revere/util"
@@ -16,18 +14,22 @@ type GraphiteThresholdType struct{}
 type GraphiteThresholdProbe struct {
 	GraphiteThresholdType
 
-	URL               string
-	ResourceID        db.ResourceID
-	Expression        string
-	Thresholds        ThresholdsModel
-	AuditFunction     string
-	CheckPeriod       int64
-	CheckPeriodType   string
-	TriggerIf         string
-	AuditPeriod       int64
-	AuditPeriodType   string
-	IgnoredPeriod     int64
-	IgnoredPeriodType string
+	URL                string
+	ResourceID         db.ResourceID
+	Expression         string
+	Thresholds         ThresholdsModel
+	RecoveryThresholds ThresholdsModel
+	AuditFunction      string
+	CheckPeriod        int64
+	CheckPeriodType    string
+	TriggerIf          string
+	AuditPeriod        int64
+	AuditPeriodType    string
+	IgnoredPeriod      int64
+	IgnoredPeriodType  string
+	BreachValue        *float64
+	NoDataPolicy       string
+	MinNonNullRatio    float64
 }
 
 type ThresholdsModel struct {
@@ -46,7 +48,7 @@ var (
 )
 
 func init() {
-	addType(GraphiteThresholdType{})
+	registerProbeType(GraphiteThresholdType{})
 }
 
 func (GraphiteThresholdType) Id() db.ProbeType {
@@ -77,25 +79,11 @@ func (GraphiteThresholdType) loadFromDb(encodedProbe string, tx *db.Tx) (VM, err
 	auditPeriod, auditPeriodType := util.GetPeriodAndType(g.TimeToAuditMilli)
 	ignoredPeriod, ignoredPeriodType := util.GetPeriodAndType(g.RecentTimeToIgnoreMilli)
 
-	dbds, err := tx.LoadResource(db.ResourceID(g.ResourceID))
-	if err != nil {
-		return nil, err
-	}
-
-	if dbds == nil {
-		return nil, errors.Errorf("no resource found: %d")
-	}
-
-	ds, err := resource.LoadFromDB(resource.GraphiteResource{}.Id(), dbds.Resource)
+	gds, err := loadGraphiteResource(tx, db.ResourceID(g.ResourceID))
 	if err != nil {
 		return nil, err
 	}
 
-	gds, found := ds.(*resource.GraphiteResource)
-	if !found {
-		return nil, errors.New("not a graphite resource")
-	}
-
 	return &GraphiteThresholdProbe{
 		URL:        gds.URL,
 		ResourceID: db.ResourceID(g.ResourceID),
@@ -105,6 +93,11 @@ func (GraphiteThresholdType) loadFromDb(encodedProbe string, tx *db.Tx) (VM, err
 			g.Thresholds.Error,
 			g.Thresholds.Critical,
 		},
+		RecoveryThresholds: ThresholdsModel{
+			g.RecoveryThresholds.Warning,
+			g.RecoveryThresholds.Error,
+			g.RecoveryThresholds.Critical,
+		},
 		AuditFunction:     g.AuditFunction,
 		CheckPeriod:       checkPeriod,
 		CheckPeriodType:   checkPeriodType,
@@ -113,6 +106,9 @@ func (GraphiteThresholdType) loadFromDb(encodedProbe string, tx *db.Tx) (VM, err
 		AuditPeriodType:   auditPeriodType,
 		IgnoredPeriod:     ignoredPeriod,
 		IgnoredPeriodType: ignoredPeriodType,
+		BreachValue:       g.BreachValue,
+		NoDataPolicy:      g.NoDataPolicy,
+		MinNonNullRatio:   g.MinNonNullRatio,
 	}, nil
 }
 
@@ -150,7 +146,7 @@ func (g GraphiteThresholdProbe) HasResource(id db.ResourceID) bool {
 }
 
 func (g GraphiteThresholdProbe) SerializeForFrontend() map[string]string {
-	var warningStr, errorStr, criticalStr string
+	var warningStr, errorStr, criticalStr, breachValueStr string
 	if g.Thresholds.Warning != nil {
 		warningStr = strconv.FormatFloat(*g.Thresholds.Warning, 'f', -1, 64)
 	}
@@ -160,12 +156,17 @@ func (g GraphiteThresholdProbe) SerializeForFrontend() map[string]string {
 	if g.Thresholds.Critical != nil {
 		criticalStr = strconv.FormatFloat(*g.Thresholds.Critical, 'f', -1, 64)
 	}
+	if g.BreachValue != nil {
+		breachValueStr = strconv.FormatFloat(*g.BreachValue, 'f', -1, 64)
+	}
 	return map[string]string{
-		"Expression": g.Expression,
-		"URL":        g.URL,
-		"Warning":    warningStr,
-		"Error":      errorStr,
-		"Critical":   criticalStr,
+		"Expression":    g.Expression,
+		"URL":           g.URL,
+		"Warning":       warningStr,
+		"Error":         errorStr,
+		"Critical":      criticalStr,
+		"AuditFunction": g.AuditFunction,
+		"BreachValue":   breachValueStr,
 	}
 }
 
@@ -182,11 +183,19 @@ func (g GraphiteThresholdProbe) SerializeForDB() (string, error) {
 			Error:    g.Thresholds.Error,
 			Critical: g.Thresholds.Critical,
 		},
-		TriggerIf:               g.TriggerIf,
+		TriggerIf: g.TriggerIf,
+		RecoveryThresholds: GraphiteThresholdThresholdsDBModel{
+			Warning:  g.RecoveryThresholds.Warning,
+			Error:    g.RecoveryThresholds.Error,
+			Critical: g.RecoveryThresholds.Critical,
+		},
 		CheckPeriodMilli:        checkPeriodMilli,
 		TimeToAuditMilli:        auditPeriodMilli,
 		RecentTimeToIgnoreMilli: ignoredPeriodMilli,
 		AuditFunction:           g.AuditFunction,
+		BreachValue:             g.BreachValue,
+		NoDataPolicy:            g.NoDataPolicy,
+		MinNonNullRatio:         g.MinNonNullRatio,
 	}
 
 	gtDBJSON, err := json.Marshal(gtDB)
@@ -232,5 +241,27 @@ func (g GraphiteThresholdProbe) Validate() (errs []string) {
 		errs = append(errs, "Invalid audit period")
 	}
 
+	if !isValidAuditFunction(g.AuditFunction) {
+		errs = append(errs, "Invalid audit function")
+	}
+
+	thresholdsTriggerIf := g.TriggerIf
+	if g.AuditFunction == percentBreachingAuditFunction {
+		thresholdsTriggerIf = ">="
+		if g.BreachValue == nil {
+			errs = append(errs, "A breach value is required for percent of points breaching")
+		}
+		for _, t := range []*float64{g.Thresholds.Warning, g.Thresholds.Error, g.Thresholds.Critical} {
+			if t != nil && (*t < 0 || *t > 100) {
+				errs = append(errs, "Percent of points breaching thresholds must be between 0 and 100")
+				break
+			}
+		}
+	}
+
+	errs = append(errs, validateRecoveryThresholds(g.Thresholds, g.RecoveryThresholds, thresholdsTriggerIf)...)
+
+	errs = append(errs, validateNoDataSettings(g.NoDataPolicy, g.MinNonNullRatio)...)
+
 	return
 }
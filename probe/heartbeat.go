@@ -0,0 +1,91 @@
+package probe
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+)
+
+// Heartbeat implements a dead man's switch probe. Rather than polling the
+// outside world, it waits for jobs to ping Revere and assigns states based on
+// how overdue the most recent ping is. Each heartbeat name sharing the probe's
+// token is reported as its own subprobe.
+type Heartbeat struct {
+	*Polling
+
+	db    *db.DB
+	token string
+
+	interval      time.Duration
+	errorGrace    time.Duration
+	criticalGrace time.Duration
+}
+
+func newHeartbeat(tx *db.Tx, configJSON types.JSONText, readingsSink chan<- []Reading) (Probe, error) {
+	h := Heartbeat{}
+
+	var config HeartbeatDBModel
+	err := configJSON.Unmarshal(&config)
+	if err != nil {
+		return nil, errors.Maskf(err, "deserialize probe config")
+	}
+
+	checkPeriod := time.Duration(config.CheckPeriodMilli) * time.Millisecond
+	h.Polling, err = NewPolling(checkPeriod, &h, readingsSink)
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+
+	if !IsValidHeartbeatToken(config.Token) {
+		return nil, errors.Errorf("invalid heartbeat token: %q", config.Token)
+	}
+
+	h.db = tx.DB()
+	h.token = config.Token
+	h.interval = time.Duration(config.IntervalMilli) * time.Millisecond
+	h.errorGrace = time.Duration(config.ErrorGraceMilli) * time.Millisecond
+	h.criticalGrace = time.Duration(config.CriticalGraceMilli) * time.Millisecond
+
+	return &h, nil
+}
+
+func (h *Heartbeat) Check() []Reading {
+	now := time.Now()
+
+	heartbeats, err := h.db.LoadHeartbeats(h.token)
+	if err != nil {
+		log.WithError(err).Error("Could not load heartbeats.")
+
+		return []Reading{{"_", state.Unknown, now, nil}}
+	}
+
+	readings := make([]Reading, 0, len(heartbeats)+1)
+	for _, hb := range heartbeats {
+		overdue := now.Sub(hb.LastPing) - h.interval
+		r := Reading{hb.Name, h.stateFor(overdue), now, heartbeatDetails{
+			lastPing: hb.LastPing,
+			interval: h.interval,
+			overdue:  overdue,
+		}}
+		readings = append(readings, r)
+	}
+	readings = append(readings, Reading{"_", state.Normal, now, nil})
+
+	return readings
+}
+
+func (h *Heartbeat) stateFor(overdue time.Duration) state.State {
+	switch {
+	case h.criticalGrace > 0 && overdue > h.criticalGrace:
+		return state.Critical
+	case overdue > h.errorGrace:
+		return state.Error
+	default:
+		return state.Normal
+	}
+}
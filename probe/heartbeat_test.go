@@ -0,0 +1,103 @@
+package probe_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/yext/revere/probe"
+	"github.com/yext/revere/test"
+)
+
+var (
+	hbId               = 4
+	hbName             = "Heartbeat"
+	hbProbeType        = HeartbeatType{}
+	validHeartbeatJson = test.DefaultHeartbeatProbeJson
+)
+
+func validHeartbeatProbe() (*HeartbeatProbe, error) {
+	probe, err := LoadFromParams(hbProbeType.Id(), validHeartbeatJson)
+	if err != nil {
+		return nil, err
+	}
+
+	hbProbe, ok := probe.(HeartbeatProbe)
+	if !ok {
+		return nil, fmt.Errorf("Invalid probe loaded for probe type: %s\n", hbProbeType.Name())
+	}
+
+	return &hbProbe, nil
+}
+
+func TestHeartbeatId(t *testing.T) {
+	if int(hbProbeType.Id()) != hbId {
+		t.Errorf("Expected heartbeat probe type id: %d, got %d\n", hbId, hbProbeType.Id())
+	}
+}
+
+func TestHeartbeatName(t *testing.T) {
+	if hbProbeType.Name() != hbName {
+		t.Errorf("Expected heartbeat probe type name: %s, got %s\n", hbName, hbProbeType.Name())
+	}
+}
+
+func TestValidHeartbeat(t *testing.T) {
+	hbProbe, err := validHeartbeatProbe()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if errs := hbProbe.Validate(); errs != nil {
+		t.Errorf("Unexpected errors for valid heartbeat probe: %v\n", errs)
+	}
+
+	hbProbe.CriticalGrace = 0
+	if errs := hbProbe.Validate(); errs != nil {
+		t.Errorf("Unexpected errors for heartbeat probe without critical grace: %v\n", errs)
+	}
+}
+
+func TestInvalidHeartbeat(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*HeartbeatProbe)
+	}{
+		{"token", func(h *HeartbeatProbe) { h.Token = "short" }},
+		{"token characters", func(h *HeartbeatProbe) { h.Token = "0123456789abcdef/../../../etc" }},
+		{"interval", func(h *HeartbeatProbe) { h.Interval = 0 }},
+		{"error grace", func(h *HeartbeatProbe) { h.ErrorGrace = -1 }},
+		{"critical grace", func(h *HeartbeatProbe) { h.CriticalGrace = 5; h.CriticalGraceType = "minute" }},
+		{"check period", func(h *HeartbeatProbe) { h.CheckPeriodType = "" }},
+	}
+
+	for _, tt := range tests {
+		hbProbe, err := validHeartbeatProbe()
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		tt.modify(hbProbe)
+		if errs := hbProbe.Validate(); errs == nil {
+			t.Errorf("Expected error for invalid %s\n", tt.name)
+		}
+	}
+}
+
+func TestHeartbeatBlankToken(t *testing.T) {
+	a, err := Blank(hbProbeType.Id())
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	b, err := Blank(hbProbeType.Id())
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	aToken := a.(*HeartbeatProbe).Token
+	if !IsValidHeartbeatToken(aToken) {
+		t.Errorf("Generated invalid heartbeat token: %s\n", aToken)
+	}
+	if aToken == b.(*HeartbeatProbe).Token {
+		t.Errorf("Expected distinct generated heartbeat tokens, got %s twice\n", aToken)
+	}
+}
@@ -0,0 +1,18 @@
+package probe
+
+// HeartbeatDBModel defines the JSON serialization format for saving heartbeat
+// probes' settings in the database.
+type HeartbeatDBModel struct {
+	Token string
+
+	// IntervalMilli is how often pings are expected to arrive.
+	IntervalMilli int64
+
+	// ErrorGraceMilli and CriticalGraceMilli are how long a ping may be
+	// overdue before the heartbeat enters the Error and Critical states. A
+	// CriticalGraceMilli of zero disables the Critical state.
+	ErrorGraceMilli    int64
+	CriticalGraceMilli int64
+
+	CheckPeriodMilli int64
+}
@@ -0,0 +1,24 @@
+package probe
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yext/revere/durationfmt"
+)
+
+type heartbeatDetails struct {
+	lastPing time.Time
+	interval time.Duration
+	overdue  time.Duration
+}
+
+func (d heartbeatDetails) Text() string {
+	text := fmt.Sprintf("Last ping: %s (expected every %s)",
+		d.lastPing.Format(time.RFC1123), durationfmt.ExactMulti().Format(d.interval))
+	if d.overdue > 0 {
+		text += fmt.Sprintf("\nOverdue by %s",
+			durationfmt.ExactMulti().Format(d.overdue.Round(time.Second)))
+	}
+	return text
+}
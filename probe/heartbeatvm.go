@@ -0,0 +1,190 @@
+package probe
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/util"
+)
+
+type HeartbeatType struct{}
+
+type HeartbeatProbe struct {
+	HeartbeatType
+
+	Token             string
+	Interval          int64
+	IntervalType      string
+	ErrorGrace        int64
+	ErrorGraceType    string
+	CriticalGrace     int64
+	CriticalGraceType string
+	CheckPeriod       int64
+	CheckPeriodType   string
+}
+
+// heartbeatTokenBytes is how many random bytes make up a generated token.
+const heartbeatTokenBytes = 16
+
+var heartbeatTokenRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{16,64}$`)
+
+func init() {
+	registerProbeType(HeartbeatType{})
+}
+
+func (HeartbeatType) Id() db.ProbeType {
+	return 4
+}
+
+func (HeartbeatType) Name() string {
+	return "Heartbeat"
+}
+
+func (HeartbeatType) loadFromParams(probe string) (VM, error) {
+	var h HeartbeatProbe
+	err := json.Unmarshal([]byte(probe), &h)
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (HeartbeatType) loadFromDb(encodedProbe string, tx *db.Tx) (VM, error) {
+	var h HeartbeatDBModel
+	err := json.Unmarshal([]byte(encodedProbe), &h)
+	if err != nil {
+		return nil, err
+	}
+
+	interval, intervalType := util.GetPeriodAndType(h.IntervalMilli)
+	errorGrace, errorGraceType := util.GetPeriodAndType(h.ErrorGraceMilli)
+	criticalGrace, criticalGraceType := util.GetPeriodAndType(h.CriticalGraceMilli)
+	checkPeriod, checkPeriodType := util.GetPeriodAndType(h.CheckPeriodMilli)
+
+	return &HeartbeatProbe{
+		Token:             h.Token,
+		Interval:          interval,
+		IntervalType:      intervalType,
+		ErrorGrace:        errorGrace,
+		ErrorGraceType:    errorGraceType,
+		CriticalGrace:     criticalGrace,
+		CriticalGraceType: criticalGraceType,
+		CheckPeriod:       checkPeriod,
+		CheckPeriodType:   checkPeriodType,
+	}, nil
+}
+
+func (HeartbeatType) blank() (VM, error) {
+	token, err := newHeartbeatToken()
+	if err != nil {
+		return nil, err
+	}
+	return &HeartbeatProbe{Token: token}, nil
+}
+
+func (HeartbeatType) Templates() map[string]string {
+	return map[string]string{
+		"edit": "heartbeat-edit.html",
+		"view": "heartbeat-view.html",
+	}
+}
+
+func (HeartbeatType) Scripts() map[string][]string {
+	return map[string][]string{}
+}
+
+func (HeartbeatType) AcceptedResourceTypes() []db.ResourceType {
+	return []db.ResourceType{}
+}
+
+func (h HeartbeatProbe) HasResource(id db.ResourceID) bool {
+	return false
+}
+
+func (h HeartbeatProbe) SerializeForFrontend() map[string]string {
+	return map[string]string{
+		"Token": h.Token,
+	}
+}
+
+func (h HeartbeatProbe) SerializeForDB() (string, error) {
+	hDB := HeartbeatDBModel{
+		Token:              h.Token,
+		IntervalMilli:      util.GetMs(h.Interval, h.IntervalType),
+		ErrorGraceMilli:    util.GetMs(h.ErrorGrace, h.ErrorGraceType),
+		CriticalGraceMilli: util.GetMs(h.CriticalGrace, h.CriticalGraceType),
+		CheckPeriodMilli:   util.GetMs(h.CheckPeriod, h.CheckPeriodType),
+	}
+
+	hDBJSON, err := json.Marshal(hDB)
+	return string(hDBJSON), err
+}
+
+func (h HeartbeatProbe) Type() VMType {
+	return HeartbeatType{}
+}
+
+func (h HeartbeatProbe) Validate() (errs []string) {
+	if !IsValidHeartbeatToken(h.Token) {
+		errs = append(errs, "Invalid heartbeat token")
+	}
+
+	if util.GetMs(h.Interval, h.IntervalType) <= 0 {
+		errs = append(errs, "Invalid heartbeat interval")
+	}
+
+	errorGrace := util.GetMs(h.ErrorGrace, h.ErrorGraceType)
+	if errorGrace < 0 {
+		errs = append(errs, "Invalid error grace period")
+	}
+
+	criticalGrace := util.GetMs(h.CriticalGrace, h.CriticalGraceType)
+	if criticalGrace < 0 || (criticalGrace > 0 && criticalGrace <= errorGrace) {
+		errs = append(errs, "Critical grace period must be longer than error grace period")
+	}
+
+	if util.GetMs(h.CheckPeriod, h.CheckPeriodType) <= 0 {
+		errs = append(errs, "Invalid check period")
+	}
+
+	return
+}
+
+// IsValidHeartbeatToken returns whether token is acceptable as the shared
+// secret that heartbeat pings are sent to.
+func IsValidHeartbeatToken(token string) bool {
+	return heartbeatTokenRegexp.MatchString(token)
+}
+
+// IsConfiguredHeartbeatToken returns whether token belongs to the heartbeat
+// probe of a monitor that isn't archived.
+func IsConfiguredHeartbeatToken(DB *db.DB, token string) (bool, error) {
+	configs, err := DB.LoadActiveProbesOfType(HeartbeatType{}.Id())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+
+	for _, configJSON := range configs {
+		var config HeartbeatDBModel
+		if err := configJSON.Unmarshal(&config); err != nil {
+			continue
+		}
+		if config.Token == token {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func newHeartbeatToken() (string, error) {
+	b := make([]byte, heartbeatTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,250 @@
+package probe
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+)
+
+// HTTP implements a probe that assigns states based on how HTTP endpoints
+// respond to requests. Each URL checked is reported as its own subprobe.
+type HTTP struct {
+	*Polling
+
+	urls   []string
+	method string
+	client *http.Client
+
+	expectedStatusCodes statusCodeRanges
+	statusState         state.State
+
+	bodyRegexp *regexp.Regexp
+	bodyState  state.State
+
+	thresholds []httpThreshold
+}
+
+type httpThreshold struct {
+	state     state.State
+	threshold time.Duration
+}
+
+// maxHTTPBodyBytes bounds how much of a response body is read when matching
+// it against the body regexp.
+const maxHTTPBodyBytes = 1 << 20
+
+func newHTTP(tx *db.Tx, configJSON types.JSONText, readingsSink chan<- []Reading) (Probe, error) {
+	h := HTTP{}
+
+	var config HTTPDBModel
+	err := configJSON.Unmarshal(&config)
+	if err != nil {
+		return nil, errors.Maskf(err, "deserialize probe config")
+	}
+
+	checkPeriod := time.Duration(config.CheckPeriodMilli) * time.Millisecond
+	h.Polling, err = NewPolling(checkPeriod, &h, readingsSink)
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+
+	if len(config.URLs) == 0 {
+		return nil, errors.New("no URLs to check")
+	}
+	h.urls = config.URLs
+
+	h.method = config.Method
+	if h.method == "" {
+		h.method = http.MethodGet
+	}
+
+	h.client = &http.Client{
+		Timeout: time.Duration(config.TimeoutMilli) * time.Millisecond,
+	}
+
+	h.expectedStatusCodes, err = parseStatusCodeRanges(config.ExpectedStatusCodes)
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+	h.statusState = config.StatusState
+
+	if config.BodyRegexp != "" {
+		h.bodyRegexp, err = regexp.Compile(config.BodyRegexp)
+		if err != nil {
+			return nil, errors.Maskf(err, "compile body regexp")
+		}
+	}
+	h.bodyState = config.BodyState
+
+	// Must be in increasing severity order.
+	t := config.ResponseTimeThresholds
+	if t.Warning != nil {
+		h.thresholds = append(h.thresholds,
+			httpThreshold{state.Warning, time.Duration(*t.Warning) * time.Millisecond})
+	}
+	if t.Error != nil {
+		h.thresholds = append(h.thresholds,
+			httpThreshold{state.Error, time.Duration(*t.Error) * time.Millisecond})
+	}
+	if t.Critical != nil {
+		h.thresholds = append(h.thresholds,
+			httpThreshold{state.Critical, time.Duration(*t.Critical) * time.Millisecond})
+	}
+
+	return &h, nil
+}
+
+func (h *HTTP) Check() []Reading {
+	readings := make([]Reading, len(h.urls))
+
+	var wg sync.WaitGroup
+	for i, url := range h.urls {
+		wg.Add(1)
+		go func(i int, url string) {
+			defer wg.Done()
+			readings[i] = h.check(url)
+		}(i, url)
+	}
+	wg.Wait()
+
+	return readings
+}
+
+func (h *HTTP) check(url string) Reading {
+	now := time.Now()
+
+	d := httpDetails{
+		method:              h.method,
+		url:                 url,
+		expectedStatusCodes: h.expectedStatusCodes.String(),
+	}
+	r := Reading{url, state.Normal, now, nil}
+
+	req, err := http.NewRequest(h.method, url, nil)
+	if err != nil {
+		d.err = errors.Maskf(err, "build request")
+		r.State = h.statusState
+		r.Details = d
+		return r
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		d.err = err
+		r.State = h.statusState
+		r.Details = d
+		return r
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxHTTPBodyBytes))
+	d.responseTime = time.Since(now)
+	d.statusCode = resp.StatusCode
+	if err != nil {
+		d.err = errors.Maskf(err, "read response body")
+		r.State = h.statusState
+		r.Details = d
+		return r
+	}
+
+	if !h.expectedStatusCodes.contains(resp.StatusCode) {
+		d.unexpectedStatus = true
+		r.State = worstState(r.State, h.statusState)
+	}
+
+	if h.bodyRegexp != nil && !h.bodyRegexp.Match(body) {
+		d.bodyRegexp = h.bodyRegexp.String()
+		d.bodyMismatch = true
+		r.State = worstState(r.State, h.bodyState)
+	}
+
+	for _, t := range h.thresholds {
+		if d.responseTime >= t.threshold {
+			d.threshold = t.threshold
+			r.State = worstState(r.State, t.state)
+		}
+	}
+
+	r.Details = d
+	return r
+}
+
+func worstState(a, b state.State) state.State {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// statusCodeRanges is a set of HTTP status codes. Its text form is a
+// comma-separated list of codes and inclusive ranges, like "200-299,304".
+type statusCodeRanges []statusCodeRange
+
+type statusCodeRange struct {
+	low, high int
+}
+
+// defaultStatusCodeRanges accepts any 2xx status code.
+var defaultStatusCodeRanges = statusCodeRanges{{200, 299}}
+
+func parseStatusCodeRanges(s string) (statusCodeRanges, error) {
+	if strings.TrimSpace(s) == "" {
+		return defaultStatusCodeRanges, nil
+	}
+
+	var ranges statusCodeRanges
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		bounds := strings.SplitN(part, "-", 2)
+
+		low, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return nil, errors.Errorf("invalid status code: %s", part)
+		}
+		high := low
+		if len(bounds) == 2 {
+			high, err = strconv.Atoi(strings.TrimSpace(bounds[1]))
+			if err != nil {
+				return nil, errors.Errorf("invalid status code range: %s", part)
+			}
+		}
+
+		if low < 100 || high > 599 || low > high {
+			return nil, errors.Errorf("invalid status code range: %s", part)
+		}
+		ranges = append(ranges, statusCodeRange{low, high})
+	}
+	return ranges, nil
+}
+
+func (ranges statusCodeRanges) contains(code int) bool {
+	for _, r := range ranges {
+		if r.low <= code && code <= r.high {
+			return true
+		}
+	}
+	return false
+}
+
+func (ranges statusCodeRanges) String() string {
+	parts := make([]string, len(ranges))
+	for i, r := range ranges {
+		if r.low == r.high {
+			parts[i] = strconv.Itoa(r.low)
+		} else {
+			parts[i] = strconv.Itoa(r.low) + "-" + strconv.Itoa(r.high)
+		}
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,137 @@
+package probe_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jmoiron/sqlx/types"
+
+	. "github.com/yext/revere/probe"
+	"github.com/yext/revere/state"
+	"github.com/yext/revere/test"
+)
+
+var (
+	httpId        = 2
+	httpName      = "HTTP Health Check"
+	httpProbeType = HTTPType{}
+	validHTTPJson = test.DefaultHTTPProbeJson
+)
+
+func validHTTPProbe() (*HTTPProbe, error) {
+	probe, err := LoadFromParams(httpProbeType.Id(), validHTTPJson)
+	if err != nil {
+		return nil, err
+	}
+
+	httpProbe, ok := probe.(HTTPProbe)
+	if !ok {
+		return nil, fmt.Errorf("Invalid probe loaded for probe type: %s\n", httpProbeType.Name())
+	}
+
+	return &httpProbe, nil
+}
+
+func TestHTTPId(t *testing.T) {
+	if int(httpProbeType.Id()) != httpId {
+		t.Errorf("Expected HTTP probe type id: %d, got %d\n", httpId, httpProbeType.Id())
+	}
+}
+
+func TestHTTPName(t *testing.T) {
+	if httpProbeType.Name() != httpName {
+		t.Errorf("Expected HTTP probe type name: %s, got %s\n", httpName, httpProbeType.Name())
+	}
+}
+
+func TestValidHTTP(t *testing.T) {
+	httpProbe, err := validHTTPProbe()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if errs := httpProbe.Validate(); errs != nil {
+		t.Errorf("Unexpected errors for valid HTTP probe: %v\n", errs)
+	}
+}
+
+func TestInvalidHTTP(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*HTTPProbe)
+	}{
+		{"no URLs", func(h *HTTPProbe) { h.URLs = nil }},
+		{"relative URL", func(h *HTTPProbe) { h.URLs = []string{"/health"} }},
+		{"non-HTTP URL", func(h *HTTPProbe) { h.URLs = []string{"ftp://foo.bar/"} }},
+		{"method", func(h *HTTPProbe) { h.Method = "DELETE" }},
+		{"status codes", func(h *HTTPProbe) { h.ExpectedStatusCodes = "299-200" }},
+		{"body regexp", func(h *HTTPProbe) { h.BodyRegexp = "(" }},
+		{"HEAD with body regexp", func(h *HTTPProbe) { h.Method = "HEAD" }},
+		{"status state", func(h *HTTPProbe) { h.StatusState = state.Normal }},
+		{"threshold", func(h *HTTPProbe) { zero := int64(0); h.Thresholds.Critical = &zero }},
+		{"check period", func(h *HTTPProbe) { h.CheckPeriod = -1 }},
+		{"timeout", func(h *HTTPProbe) { h.TimeoutType = "" }},
+	}
+
+	for _, tt := range tests {
+		httpProbe, err := validHTTPProbe()
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		tt.modify(httpProbe)
+		if errs := httpProbe.Validate(); errs == nil {
+			t.Errorf("Expected error for invalid %s\n", tt.name)
+		}
+	}
+}
+
+func TestHTTPCheck(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "status: ok")
+	})
+	mux.HandleFunc("/wrong-body", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "status: degraded")
+	})
+	mux.HandleFunc("/down", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	expected := map[string]state.State{
+		server.URL + "/ok":         state.Normal,
+		server.URL + "/wrong-body": state.Warning,
+		server.URL + "/down":       state.Critical,
+	}
+
+	config := types.JSONText(fmt.Sprintf(`{
+		"URLs": ["%s/ok", "%s/wrong-body", "%s/down"],
+		"StatusState": %d,
+		"BodyRegexp": "ok$",
+		"BodyState": %d,
+		"CheckPeriodMilli": 60000,
+		"TimeoutMilli": 10000
+	}`, server.URL, server.URL, server.URL, state.Critical, state.Warning))
+
+	p, err := New(nil, httpProbeType.Id(), config, make(chan []Reading))
+	if err != nil {
+		t.Fatalf("Failed to make HTTP probe: %s\n", err.Error())
+	}
+
+	readings := p.(*HTTP).Check()
+	if len(readings) != len(expected) {
+		t.Fatalf("Expected %d readings, got %d\n", len(expected), len(readings))
+	}
+	for _, r := range readings {
+		if r.State != expected[r.Subprobe] {
+			t.Errorf("Expected state %s for %s, got %s\n", expected[r.Subprobe], r.Subprobe, r.State)
+		}
+		if r.Details == nil || r.Details.Text() == "" {
+			t.Errorf("Expected details for %s\n", r.Subprobe)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package probe
+
+import (
+	"github.com/yext/revere/state"
+)
+
+// HTTPDBModel defines the JSON serialization format for saving HTTP probes'
+// settings in the database.
+type HTTPDBModel struct {
+	URLs   []string
+	Method string
+
+	ExpectedStatusCodes string
+	StatusState         state.State
+
+	BodyRegexp string
+	BodyState  state.State
+
+	ResponseTimeThresholds HTTPThresholdsDBModel
+
+	CheckPeriodMilli int64
+	TimeoutMilli     int64
+}
+
+// HTTPThresholdsDBModel defines the JSON serialization format for saving HTTP
+// probes' response time threshold settings in the database. Thresholds are in
+// milliseconds.
+type HTTPThresholdsDBModel struct {
+	Warning  *int64
+	Error    *int64
+	Critical *int64
+}
@@ -0,0 +1,57 @@
+package probe
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/yext/revere/durationfmt"
+)
+
+type httpDetails struct {
+	method string
+	url    string
+
+	statusCode   int
+	responseTime time.Duration
+	err          error
+
+	expectedStatusCodes string
+	unexpectedStatus    bool
+
+	bodyRegexp   string
+	bodyMismatch bool
+
+	threshold time.Duration
+}
+
+func (d httpDetails) Text() string {
+	request := fmt.Sprintf("%s %s", d.method, d.url)
+
+	if d.statusCode == 0 {
+		return fmt.Sprintf("%s: request failed: %v", request, d.err)
+	}
+
+	responseTime := durationfmt.ExactMulti().Format(d.responseTime.Round(time.Millisecond))
+	lines := []string{fmt.Sprintf("%s: %d %s in %s",
+		request, d.statusCode, http.StatusText(d.statusCode), responseTime)}
+
+	if d.err != nil {
+		lines = append(lines, fmt.Sprintf("Error: %v", d.err))
+	}
+	if d.unexpectedStatus {
+		lines = append(lines, fmt.Sprintf(
+			"Status code was not one of: %s", d.expectedStatusCodes))
+	}
+	if d.bodyMismatch {
+		lines = append(lines, fmt.Sprintf(
+			"Response body did not match: %s", d.bodyRegexp))
+	}
+	if d.threshold > 0 {
+		lines = append(lines, fmt.Sprintf(
+			"Response time >= threshold: %s", durationfmt.ExactMulti().Format(d.threshold)))
+	}
+
+	return strings.Join(lines, "\n")
+}
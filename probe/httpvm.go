@@ -0,0 +1,234 @@
+package probe
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+	"github.com/yext/revere/util"
+)
+
+type HTTPType struct{}
+
+type HTTPProbe struct {
+	HTTPType
+
+	URLs                []string
+	Method              string
+	ExpectedStatusCodes string
+	StatusState         state.State
+	BodyRegexp          string
+	BodyState           state.State
+	Thresholds          HTTPThresholdsModel
+	CheckPeriod         int64
+	CheckPeriodType     string
+	Timeout             int64
+	TimeoutType         string
+}
+
+// HTTPThresholdsModel holds response time thresholds in milliseconds.
+type HTTPThresholdsModel struct {
+	Warning  *int64
+	Error    *int64
+	Critical *int64
+}
+
+var (
+	validHTTPMethods = []string{
+		http.MethodGet,
+		http.MethodHead,
+	}
+
+	validHTTPFailureStates = []state.State{
+		state.Warning,
+		state.Error,
+		state.Critical,
+	}
+)
+
+func init() {
+	registerProbeType(HTTPType{})
+}
+
+func (HTTPType) Id() db.ProbeType {
+	return 2
+}
+
+func (HTTPType) Name() string {
+	return "HTTP Health Check"
+}
+
+func (HTTPType) loadFromParams(probe string) (VM, error) {
+	var h HTTPProbe
+	err := json.Unmarshal([]byte(probe), &h)
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (HTTPType) loadFromDb(encodedProbe string, tx *db.Tx) (VM, error) {
+	var h HTTPDBModel
+	err := json.Unmarshal([]byte(encodedProbe), &h)
+	if err != nil {
+		return nil, err
+	}
+
+	checkPeriod, checkPeriodType := util.GetPeriodAndType(h.CheckPeriodMilli)
+	timeout, timeoutType := util.GetPeriodAndType(h.TimeoutMilli)
+
+	return &HTTPProbe{
+		URLs:                h.URLs,
+		Method:              h.Method,
+		ExpectedStatusCodes: h.ExpectedStatusCodes,
+		StatusState:         h.StatusState,
+		BodyRegexp:          h.BodyRegexp,
+		BodyState:           h.BodyState,
+		Thresholds: HTTPThresholdsModel{
+			h.ResponseTimeThresholds.Warning,
+			h.ResponseTimeThresholds.Error,
+			h.ResponseTimeThresholds.Critical,
+		},
+		CheckPeriod:     checkPeriod,
+		CheckPeriodType: checkPeriodType,
+		Timeout:         timeout,
+		TimeoutType:     timeoutType,
+	}, nil
+}
+
+func (HTTPType) blank() (VM, error) {
+	return &HTTPProbe{
+		Method:              http.MethodGet,
+		ExpectedStatusCodes: defaultStatusCodeRanges.String(),
+		StatusState:         state.Error,
+		BodyState:           state.Error,
+	}, nil
+}
+
+func (HTTPType) Templates() map[string]string {
+	return map[string]string{
+		"edit": "http-edit.html",
+		"view": "http-view.html",
+	}
+}
+
+func (HTTPType) Scripts() map[string][]string {
+	return map[string][]string{
+		"edit": []string{
+			"http.js",
+		},
+	}
+}
+
+func (HTTPType) AcceptedResourceTypes() []db.ResourceType {
+	return []db.ResourceType{}
+}
+
+func (h HTTPProbe) HasResource(id db.ResourceID) bool {
+	return false
+}
+
+func (h HTTPProbe) SerializeForFrontend() map[string]string {
+	return map[string]string{
+		"Method": h.Method,
+		"URLs":   strings.Join(h.URLs, "\n"),
+	}
+}
+
+func (h HTTPProbe) SerializeForDB() (string, error) {
+	hDB := HTTPDBModel{
+		URLs:                h.URLs,
+		Method:              h.Method,
+		ExpectedStatusCodes: h.ExpectedStatusCodes,
+		StatusState:         h.StatusState,
+		BodyRegexp:          h.BodyRegexp,
+		BodyState:           h.BodyState,
+		ResponseTimeThresholds: HTTPThresholdsDBModel{
+			Warning:  h.Thresholds.Warning,
+			Error:    h.Thresholds.Error,
+			Critical: h.Thresholds.Critical,
+		},
+		CheckPeriodMilli: util.GetMs(h.CheckPeriod, h.CheckPeriodType),
+		TimeoutMilli:     util.GetMs(h.Timeout, h.TimeoutType),
+	}
+
+	hDBJSON, err := json.Marshal(hDB)
+	return string(hDBJSON), err
+}
+
+func (h HTTPProbe) Type() VMType {
+	return HTTPType{}
+}
+
+func (h HTTPProbe) Validate() (errs []string) {
+	if len(h.URLs) == 0 {
+		errs = append(errs, "At least one URL is required")
+	}
+	for _, u := range h.URLs {
+		parsed, err := url.ParseRequestURI(u)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			errs = append(errs, "Invalid URL: "+u)
+		}
+	}
+
+	isValidMethod := false
+	for _, m := range validHTTPMethods {
+		if h.Method == m {
+			isValidMethod = true
+			break
+		}
+	}
+	if !isValidMethod {
+		errs = append(errs, "Invalid HTTP method")
+	}
+
+	if _, err := parseStatusCodeRanges(h.ExpectedStatusCodes); err != nil {
+		errs = append(errs, "Invalid expected status codes")
+	}
+
+	if h.BodyRegexp != "" {
+		if h.Method == http.MethodHead {
+			errs = append(errs, "Body regexp cannot be used with HEAD requests")
+		}
+		if _, err := regexp.Compile(h.BodyRegexp); err != nil {
+			errs = append(errs, "Invalid body regexp")
+		}
+	}
+
+	if !isValidHTTPFailureState(h.StatusState) {
+		errs = append(errs, "Invalid state for unexpected status codes")
+	}
+	if !isValidHTTPFailureState(h.BodyState) {
+		errs = append(errs, "Invalid state for body mismatches")
+	}
+
+	for _, t := range []*int64{h.Thresholds.Warning, h.Thresholds.Error, h.Thresholds.Critical} {
+		if t != nil && *t <= 0 {
+			errs = append(errs, "Response time thresholds must be positive")
+			break
+		}
+	}
+
+	if util.GetMs(h.CheckPeriod, h.CheckPeriodType) <= 0 {
+		errs = append(errs, "Invalid check period")
+	}
+
+	if util.GetMs(h.Timeout, h.TimeoutType) <= 0 {
+		errs = append(errs, "Invalid timeout")
+	}
+
+	return
+}
+
+func isValidHTTPFailureState(s state.State) bool {
+	for _, vs := range validHTTPFailureStates {
+		if s == vs {
+			return true
+		}
+	}
+	return false
+}
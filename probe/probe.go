@@ -41,10 +41,8 @@ type Details interface {
 // New makes a Probe of the given type and settings. The Probe will send its
 // readings to the provided channel.
 func New(tx *db.Tx, typeID db.ProbeType, config types.JSONText, readingsSink chan<- []Reading) (Probe, error) {
-	// TODO(eefi): Implement Type dictionary system.
-	if typeID != 1 {
-		return nil, errors.Errorf("unknown probe type %d", typeID)
+	if probeType, found := probeTypes[typeID]; found {
+		return probeType.New(tx, config, readingsSink)
 	}
-
-	return graphiteThresholdType{}.New(tx, config, readingsSink)
+	return nil, errors.Errorf("unknown probe type %d", typeID)
 }
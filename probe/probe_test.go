@@ -0,0 +1,27 @@
+package probe_test
+
+import (
+	"testing"
+
+	. "github.com/yext/revere/probe"
+)
+
+func TestNewUnknownType(t *testing.T) {
+	_, err := New(nil, -1, []byte(`{}`), make(chan []Reading))
+	if err == nil {
+		t.Error("Expected error for unknown probe type")
+	}
+}
+
+func TestAllTypesBlank(t *testing.T) {
+	for _, pt := range AllTypes() {
+		probe, err := Blank(pt.Id())
+		if err != nil {
+			t.Errorf("Failed to make blank probe for type %s: %s\n", pt.Name(), err.Error())
+			continue
+		}
+		if probe.Type().Id() != pt.Id() {
+			t.Errorf("Blank probe for type %s reports type %d\n", pt.Name(), probe.Type().Id())
+		}
+	}
+}
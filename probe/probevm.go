@@ -3,13 +3,22 @@ package probe
 import (
 	"fmt"
 
+	"github.com/jmoiron/sqlx/types"
+
 	"github.com/yext/revere/db"
 )
 
-// ProbeVMType and ProbeVM define a common display abstraction for all probes.
+// VMType and VM define a common display abstraction for all probes. VMType is
+// also the entry in the probe type dictionary, so it is what the daemon uses to
+// make running probes of each type.
 type VMType interface {
 	Id() db.ProbeType
 	Name() string
+
+	// New returns a new running instance of a probe of this type. The probe
+	// will send its readings to readingsSink.
+	New(tx *db.Tx, config types.JSONText, readingsSink chan<- []Reading) (Probe, error)
+
 	loadFromParams(probe string) (VM, error)
 	loadFromDb(probe string, tx *db.Tx) (VM, error)
 	blank() (VM, error)
@@ -80,7 +89,9 @@ func getType(id db.ProbeType) (VMType, error) {
 	return probeType, nil
 }
 
-func addType(probeType VMType) {
+// registerProbeType registers a probe type onto the type dictionary shared by
+// the daemon and the web UI.
+func registerProbeType(probeType VMType) {
 	if _, ok := probeTypes[probeType.Id()]; !ok {
 		probeTypes[probeType.Id()] = probeType
 	} else {
@@ -0,0 +1,107 @@
+package probe_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/yext/revere/probe"
+	"github.com/yext/revere/test"
+)
+
+var (
+	ptId                = 3
+	ptName              = "Prometheus Threshold"
+	ptProbeType         = PrometheusThresholdType{}
+	validPrometheusJson = test.DefaultPrometheusProbeJson
+)
+
+func validPrometheusThresholdProbe() (*PrometheusThresholdProbe, error) {
+	probe, err := LoadFromParams(ptProbeType.Id(), validPrometheusJson)
+	if err != nil {
+		return nil, err
+	}
+
+	ptProbe, ok := probe.(PrometheusThresholdProbe)
+	if !ok {
+		return nil, fmt.Errorf("Invalid probe loaded for probe type: %s\n", ptProbeType.Name())
+	}
+
+	return &ptProbe, nil
+}
+
+func TestPrometheusThresholdId(t *testing.T) {
+	if int(ptProbeType.Id()) != ptId {
+		t.Errorf("Expected prometheus threshold probe type id: %d, got %d\n", ptId, ptProbeType.Id())
+	}
+}
+
+func TestPrometheusThresholdName(t *testing.T) {
+	if ptProbeType.Name() != ptName {
+		t.Errorf("Expected prometheus threshold probe type name: %s, got %s\n", ptName, ptProbeType.Name())
+	}
+}
+
+func TestValidPrometheusThreshold(t *testing.T) {
+	ptProbe, err := validPrometheusThresholdProbe()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if errs := ptProbe.Validate(); errs != nil {
+		t.Errorf("Unexpected errors for valid prometheus threshold probe: %v\n", errs)
+	}
+}
+
+func TestInvalidPrometheusThreshold(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*PrometheusThresholdProbe)
+	}{
+		{"query", func(p *PrometheusThresholdProbe) { p.Query = "" }},
+		{"audit function", func(p *PrometheusThresholdProbe) { p.AuditFunction = "median" }},
+		{"trigger if", func(p *PrometheusThresholdProbe) { p.TriggerIf = "!=" }},
+		{"check period", func(p *PrometheusThresholdProbe) { p.CheckPeriod = 0 }},
+		{"audit period", func(p *PrometheusThresholdProbe) { p.AuditPeriodType = "" }},
+		{"step", func(p *PrometheusThresholdProbe) { p.Step = -1 }},
+		{"recovery threshold", func(p *PrometheusThresholdProbe) {
+			recovery := float64(6)
+			p.RecoveryThresholds.Error = &recovery
+		}},
+	}
+
+	for _, tt := range tests {
+		ptProbe, err := validPrometheusThresholdProbe()
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+
+		tt.modify(ptProbe)
+		if errs := ptProbe.Validate(); errs == nil {
+			t.Errorf("Expected error for invalid %s\n", tt.name)
+		}
+	}
+}
+
+func TestPrometheusThresholdRecoveryThresholds(t *testing.T) {
+	ptProbe, err := validPrometheusThresholdProbe()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	recovery := float64(4)
+	ptProbe.RecoveryThresholds.Error = &recovery
+	if errs := ptProbe.Validate(); errs != nil {
+		t.Errorf("Unexpected errors for valid recovery threshold: %v\n", errs)
+	}
+}
+
+func TestPrometheusThresholdSerializeForDB(t *testing.T) {
+	ptProbe, err := validPrometheusThresholdProbe()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if _, err := ptProbe.SerializeForDB(); err != nil {
+		t.Errorf("Unexpected error serializing prometheus threshold probe: %s\n", err.Error())
+	}
+}
@@ -0,0 +1,169 @@
+package probe
+
+import (
+	"math"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/resource"
+	"github.com/yext/revere/state"
+)
+
+// PrometheusThreshold implements a probe that assigns states based on whether
+// the series returned by a PromQL query are above or below various constant
+// values. Each series' label set is reported as its own subprobe.
+type PrometheusThreshold struct {
+	*Polling
+
+	prometheus         resource.PrometheusDaemon
+	resourceID         db.ResourceID
+	query              string
+	timeToAudit        time.Duration
+	recentTimeToIgnore time.Duration
+	step               time.Duration
+
+	thresholds      []stateThreshold
+	summarizeValues func(values []float64) float64
+	triggersOn      func(summaryValue, threshold float64) bool
+
+	auditFunctionName string
+	triggerIfText     string
+
+	// lastStates holds the state each subprobe was reported in on the
+	// last check, for applying recovery thresholds.
+	lastStates map[string]state.State
+}
+
+func newPrometheusThreshold(tx *db.Tx, configJSON types.JSONText, readingsSink chan<- []Reading) (Probe, error) {
+	pt := PrometheusThreshold{}
+
+	var config PrometheusThresholdDBModel
+	err := configJSON.Unmarshal(&config)
+	if err != nil {
+		return nil, errors.Maskf(err, "deserialize probe config")
+	}
+
+	checkPeriod := time.Duration(config.CheckPeriodMilli) * time.Millisecond
+	pt.Polling, err = NewPolling(checkPeriod, &pt, readingsSink)
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+
+	pds, err := loadPrometheusResource(tx, db.ResourceID(config.ResourceID))
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+
+	pt.prometheus = resource.PrometheusDaemon{Base: pds.URL}
+	pt.resourceID = db.ResourceID(config.ResourceID)
+	pt.query = config.Query
+	pt.timeToAudit = time.Duration(config.TimeToAuditMilli) * time.Millisecond
+	pt.recentTimeToIgnore = time.Duration(config.RecentTimeToIgnoreMilli) * time.Millisecond
+	pt.step = time.Duration(config.StepMilli) * time.Millisecond
+	if pt.step <= 0 {
+		return nil, errors.Errorf("cannot query with nonpositive step %s", pt.step)
+	}
+
+	pt.thresholds = newStateThresholds(
+		config.Thresholds.Warning, config.Thresholds.Error, config.Thresholds.Critical)
+	setRecoveryThresholds(pt.thresholds, config.RecoveryThresholds.Warning,
+		config.RecoveryThresholds.Error, config.RecoveryThresholds.Critical)
+	pt.lastStates = make(map[string]state.State)
+
+	var ok bool
+
+	pt.summarizeValues, ok = auditFunctions[config.AuditFunction]
+	if !ok {
+		return nil, errors.Errorf("unknown audit function: %s", config.AuditFunction)
+	}
+
+	pt.triggersOn, ok = triggerIfFunctions[config.TriggerIf]
+	if !ok {
+		return nil, errors.Errorf("unknown trigger if: %s", config.TriggerIf)
+	}
+
+	pt.auditFunctionName = config.AuditFunction
+	pt.triggerIfText = config.TriggerIf
+
+	return &pt, nil
+}
+
+func loadPrometheusResource(tx *db.Tx, id db.ResourceID) (*resource.PrometheusResource, error) {
+	dbds, err := tx.LoadResource(id)
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+
+	if dbds == nil {
+		return nil, errors.Errorf("no resource found: %d", id)
+	}
+
+	ds, err := resource.LoadFromDB(resource.Prometheus{}.Id(), dbds.Resource)
+	if err != nil {
+		return nil, errors.Mask(err)
+	}
+
+	pds, found := ds.(*resource.PrometheusResource)
+	if !found {
+		return nil, errors.New("not a prometheus resource")
+	}
+
+	return pds, nil
+}
+
+func (pt *PrometheusThreshold) Resource() db.ResourceID {
+	return pt.resourceID
+}
+
+func (pt *PrometheusThreshold) Check() []Reading {
+	now := time.Now()
+
+	auditEnd := now.Add(-pt.recentTimeToIgnore)
+
+	series, err := pt.prometheus.QueryRange(pt.query, auditEnd.Add(-pt.timeToAudit), auditEnd, pt.step)
+	if err != nil {
+		log.WithError(err).Error("Could not query Prometheus.")
+
+		return unreachableReadings(now, err)
+	}
+
+	readings := make([]Reading, 0, len(series)+1)
+	for _, s := range series {
+		summaryValue := pt.summarizeValues(s.Values)
+		if math.IsNaN(summaryValue) {
+			// Series was all NaNs.
+			continue
+		}
+
+		r := Reading{s.Name(), state.Normal, now, nil}
+
+		var triggeredThreshold, recovery float64
+		r.State, triggeredThreshold, recovery = applyThresholdsWithRecovery(
+			summaryValue, pt.thresholds, pt.triggersOn, pt.lastStates[r.Subprobe])
+
+		r.Details = prometheusThresholdDetails{
+			auditFunction: pt.auditFunctionName,
+			timeToAudit:   pt.timeToAudit,
+			triggerIf:     pt.triggerIfText,
+
+			measured:  summaryValue,
+			threshold: triggeredThreshold,
+			recovery:  recovery,
+
+			prometheus:  pt.prometheus,
+			query:       pt.query,
+			measuredEnd: auditEnd,
+		}
+
+		readings = append(readings, r)
+	}
+	readings = append(readings, Reading{"_", state.Normal, now, nil})
+
+	pt.lastStates = readingStates(readings)
+
+	return readings
+}
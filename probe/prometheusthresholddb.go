@@ -0,0 +1,30 @@
+package probe
+
+// PrometheusThresholdDBModel defines the JSON serialization format for saving
+// Prometheus threshold probes' settings in the database.
+type PrometheusThresholdDBModel struct {
+	ResourceID int64
+	Query      string
+
+	Thresholds PrometheusThresholdThresholdsDBModel
+	TriggerIf  string
+
+	// RecoveryThresholds are what a subprobe must get back past to leave the
+	// state of the corresponding threshold. Unset ones use the threshold.
+	RecoveryThresholds PrometheusThresholdThresholdsDBModel
+
+	CheckPeriodMilli int64
+
+	TimeToAuditMilli        int64
+	RecentTimeToIgnoreMilli int64
+	StepMilli               int64
+	AuditFunction           string
+}
+
+// PrometheusThresholdThresholdsDBModel defines the JSON serialization format
+// for saving Prometheus threshold probes' threshold settings in the database.
+type PrometheusThresholdThresholdsDBModel struct {
+	Warning  *float64
+	Error    *float64
+	Critical *float64
+}
@@ -0,0 +1,207 @@
+package probe
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/resource"
+	"github.com/yext/revere/util"
+)
+
+type PrometheusThresholdType struct{}
+
+type PrometheusThresholdProbe struct {
+	PrometheusThresholdType
+
+	URL                string
+	ResourceID         db.ResourceID
+	Query              string
+	Thresholds         ThresholdsModel
+	RecoveryThresholds ThresholdsModel
+	AuditFunction      string
+	CheckPeriod        int64
+	CheckPeriodType    string
+	TriggerIf          string
+	AuditPeriod        int64
+	AuditPeriodType    string
+	IgnoredPeriod      int64
+	IgnoredPeriodType  string
+	Step               int64
+	StepType           string
+}
+
+func init() {
+	registerProbeType(PrometheusThresholdType{})
+}
+
+func (PrometheusThresholdType) Id() db.ProbeType {
+	return 3
+}
+
+func (PrometheusThresholdType) Name() string {
+	return "Prometheus Threshold"
+}
+
+func (PrometheusThresholdType) loadFromParams(probe string) (VM, error) {
+	var p PrometheusThresholdProbe
+	err := json.Unmarshal([]byte(probe), &p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (PrometheusThresholdType) loadFromDb(encodedProbe string, tx *db.Tx) (VM, error) {
+	var p PrometheusThresholdDBModel
+	err := json.Unmarshal([]byte(encodedProbe), &p)
+	if err != nil {
+		return nil, err
+	}
+
+	checkPeriod, checkPeriodType := util.GetPeriodAndType(p.CheckPeriodMilli)
+	auditPeriod, auditPeriodType := util.GetPeriodAndType(p.TimeToAuditMilli)
+	ignoredPeriod, ignoredPeriodType := util.GetPeriodAndType(p.RecentTimeToIgnoreMilli)
+	step, stepType := util.GetPeriodAndType(p.StepMilli)
+
+	pds, err := loadPrometheusResource(tx, db.ResourceID(p.ResourceID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrometheusThresholdProbe{
+		URL:        pds.URL,
+		ResourceID: db.ResourceID(p.ResourceID),
+		Query:      p.Query,
+		Thresholds: ThresholdsModel{
+			p.Thresholds.Warning,
+			p.Thresholds.Error,
+			p.Thresholds.Critical,
+		},
+		RecoveryThresholds: ThresholdsModel{
+			p.RecoveryThresholds.Warning,
+			p.RecoveryThresholds.Error,
+			p.RecoveryThresholds.Critical,
+		},
+		AuditFunction:     p.AuditFunction,
+		CheckPeriod:       checkPeriod,
+		CheckPeriodType:   checkPeriodType,
+		TriggerIf:         p.TriggerIf,
+		AuditPeriod:       auditPeriod,
+		AuditPeriodType:   auditPeriodType,
+		IgnoredPeriod:     ignoredPeriod,
+		IgnoredPeriodType: ignoredPeriodType,
+		Step:              step,
+		StepType:          stepType,
+	}, nil
+}
+
+func (PrometheusThresholdType) blank() (VM, error) {
+	return &PrometheusThresholdProbe{}, nil
+}
+
+func (PrometheusThresholdType) Templates() map[string]string {
+	return map[string]string{
+		"edit": "prometheus-edit.html",
+		"view": "prometheus-view.html",
+	}
+}
+
+func (PrometheusThresholdType) Scripts() map[string][]string {
+	return map[string][]string{
+		"edit": []string{
+			"prometheus-threshold.js",
+			"graphite-resource-loader.js",
+		},
+	}
+}
+
+func (PrometheusThresholdType) AcceptedResourceTypes() []db.ResourceType {
+	return []db.ResourceType{
+		resource.Prometheus{}.Id(),
+	}
+}
+
+func (p PrometheusThresholdProbe) HasResource(id db.ResourceID) bool {
+	return p.ResourceID == id
+}
+
+func (p PrometheusThresholdProbe) SerializeForFrontend() map[string]string {
+	var warningStr, errorStr, criticalStr string
+	if p.Thresholds.Warning != nil {
+		warningStr = strconv.FormatFloat(*p.Thresholds.Warning, 'f', -1, 64)
+	}
+	if p.Thresholds.Error != nil {
+		errorStr = strconv.FormatFloat(*p.Thresholds.Error, 'f', -1, 64)
+	}
+	if p.Thresholds.Critical != nil {
+		criticalStr = strconv.FormatFloat(*p.Thresholds.Critical, 'f', -1, 64)
+	}
+	return map[string]string{
+		"Query":    p.Query,
+		"URL":      p.URL,
+		"Warning":  warningStr,
+		"Error":    errorStr,
+		"Critical": criticalStr,
+	}
+}
+
+func (p PrometheusThresholdProbe) SerializeForDB() (string, error) {
+	ptDB := PrometheusThresholdDBModel{
+		ResourceID: int64(p.ResourceID),
+		Query:      p.Query,
+		Thresholds: PrometheusThresholdThresholdsDBModel{
+			Warning:  p.Thresholds.Warning,
+			Error:    p.Thresholds.Error,
+			Critical: p.Thresholds.Critical,
+		},
+		TriggerIf: p.TriggerIf,
+		RecoveryThresholds: PrometheusThresholdThresholdsDBModel{
+			Warning:  p.RecoveryThresholds.Warning,
+			Error:    p.RecoveryThresholds.Error,
+			Critical: p.RecoveryThresholds.Critical,
+		},
+		CheckPeriodMilli:        util.GetMs(p.CheckPeriod, p.CheckPeriodType),
+		TimeToAuditMilli:        util.GetMs(p.AuditPeriod, p.AuditPeriodType),
+		RecentTimeToIgnoreMilli: util.GetMs(p.IgnoredPeriod, p.IgnoredPeriodType),
+		StepMilli:               util.GetMs(p.Step, p.StepType),
+		AuditFunction:           p.AuditFunction,
+	}
+
+	ptDBJSON, err := json.Marshal(ptDB)
+	return string(ptDBJSON), err
+}
+
+func (p PrometheusThresholdProbe) Type() VMType {
+	return PrometheusThresholdType{}
+}
+
+func (p PrometheusThresholdProbe) Validate() (errs []string) {
+	if p.Query == "" {
+		errs = append(errs, "PromQL query is required")
+	}
+
+	if _, ok := auditFunctions[p.AuditFunction]; !ok {
+		errs = append(errs, "Invalid audit function")
+	}
+
+	if _, ok := triggerIfFunctions[p.TriggerIf]; !ok {
+		errs = append(errs, "Invalid trigger if")
+	}
+
+	errs = append(errs, validateRecoveryThresholds(p.Thresholds, p.RecoveryThresholds, p.TriggerIf)...)
+
+	if util.GetMs(p.CheckPeriod, p.CheckPeriodType) <= 0 {
+		errs = append(errs, "Invalid check period")
+	}
+
+	if util.GetMs(p.AuditPeriod, p.AuditPeriodType) <= 0 {
+		errs = append(errs, "Invalid audit period")
+	}
+
+	if util.GetMs(p.Step, p.StepType) <= 0 {
+		errs = append(errs, "Invalid query step")
+	}
+
+	return
+}
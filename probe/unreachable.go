@@ -0,0 +1,42 @@
+package probe
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+)
+
+// ResourceReader is implemented by probes that read from a resource, such as
+// a Graphite server. Revere uses it to tell when many monitors are failing
+// because one resource is down.
+type ResourceReader interface {
+	Resource() db.ResourceID
+}
+
+// unreachableDetails explains the reading of a probe that couldn't query its
+// resource.
+type unreachableDetails struct {
+	err error
+}
+
+func (d unreachableDetails) Text() string {
+	return fmt.Sprintf("Could not query resource: %s", d.err)
+}
+
+// unreachableReadings returns the readings of a probe that couldn't query its
+// resource because of err.
+func unreachableReadings(now time.Time, err error) []Reading {
+	return []Reading{{"_", state.Unknown, now, unreachableDetails{err}}}
+}
+
+// Unreachable returns whether readings are from a probe that couldn't query
+// its resource.
+func Unreachable(readings []Reading) bool {
+	if len(readings) != 1 {
+		return false
+	}
+	_, ok := readings[0].Details.(unreachableDetails)
+	return ok
+}
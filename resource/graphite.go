@@ -78,6 +78,10 @@ func (g GraphiteResource) Type() ResourceType {
 	return Graphite{}
 }
 
+func (g GraphiteResource) Label() string {
+	return "Graphite " + g.URL
+}
+
 func (g GraphiteResource) Validate() []string {
 	var errs []string
 	if g.URL == "" {
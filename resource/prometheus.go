@@ -0,0 +1,95 @@
+package resource
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/yext/revere/db"
+)
+
+type Prometheus struct{}
+
+type PrometheusResource struct {
+	Prometheus
+	URL string
+}
+
+// Eventually implemented in DB layer
+type PrometheusResourceDBModel struct {
+	URL string
+}
+
+func init() {
+	addType(Prometheus{})
+}
+
+func (Prometheus) Id() db.ResourceType {
+	return 1
+}
+
+func (Prometheus) Name() string {
+	return "Prometheus"
+}
+
+func (Prometheus) loadFromParams(ds string) (Resource, error) {
+	var p PrometheusResource
+	err := json.Unmarshal([]byte(ds), &p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (Prometheus) loadFromDB(ds string) (Resource, error) {
+	var p PrometheusResourceDBModel
+	err := json.Unmarshal([]byte(ds), &p)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrometheusResource{
+		URL: p.URL,
+	}, nil
+}
+
+func (Prometheus) blank() (Resource, error) {
+	return &PrometheusResource{}, nil
+}
+
+func (Prometheus) Templates() string {
+	return "prometheus-resource.html"
+}
+
+func (Prometheus) Scripts() []string {
+	return []string{
+		"prometheus-resource.js",
+	}
+}
+
+func (p PrometheusResource) Serialize() (string, error) {
+	pDB := PrometheusResourceDBModel{
+		URL: p.URL,
+	}
+
+	pDBJSON, err := json.Marshal(pDB)
+	return string(pDBJSON), err
+}
+
+func (p PrometheusResource) Type() ResourceType {
+	return Prometheus{}
+}
+
+func (p PrometheusResource) Label() string {
+	return "Prometheus " + p.URL
+}
+
+func (p PrometheusResource) Validate() []string {
+	var errs []string
+	if p.URL == "" {
+		errs = append(errs, "Url is required")
+	} else if u, err := url.ParseRequestURI(p.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		errs = append(errs, "Prometheus url must be a full http(s) url, e.g. http://prometheus:9090")
+	}
+
+	return errs
+}
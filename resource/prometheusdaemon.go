@@ -0,0 +1,164 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// PrometheusDaemon represents a remote Prometheus server. For more
+// information, see https://prometheus.io/docs/prometheus/latest/querying/api/ .
+type PrometheusDaemon struct {
+	// Base is the URL where the server can be found, such as
+	// http://prometheus:9090. The HTTP API is expected to be at
+	// Base + "/api/v1".
+	Base string
+}
+
+// PrometheusSeries encapsulates the data returned by Prometheus for a
+// particular series of a range query. Times[i] is the time of Values[i].
+type PrometheusSeries struct {
+	Labels map[string]string
+	Times  []time.Time
+	Values []float64
+}
+
+// Name returns the series' label set formatted the way Prometheus displays it,
+// e.g. http_requests_total{code="500",job="api"}. Labels are sorted so that
+// the name is stable across queries.
+func (s PrometheusSeries) Name() string {
+	keys := make([]string, 0, len(s.Labels))
+	for k := range s.Labels {
+		if k != "__name__" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = fmt.Sprintf("%s=%q", k, s.Labels[k])
+	}
+	return s.Labels["__name__"] + "{" + strings.Join(pairs, ",") + "}"
+}
+
+type prometheusResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string   `json:"metric"`
+			Values [][]json.RawMessage `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// QueryRange evaluates a PromQL expression over the given time period,
+// sampling every step.
+func (p PrometheusDaemon) QueryRange(query string, start, end time.Time, step time.Duration) ([]PrometheusSeries, error) {
+	u := p.APIURL("query_range", url.Values{
+		"query": []string{query},
+		"start": []string{PrometheusTimestamp(start)},
+		"end":   []string{PrometheusTimestamp(end)},
+		"step":  []string{strconv.FormatFloat(step.Seconds(), 'f', -1, 64)},
+	})
+
+	r, err := http.Get(u)
+	if err != nil {
+		return nil, errors.Maskf(err, "query Prometheus")
+	}
+	defer r.Body.Close()
+
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Annotatef(err, "Get %s", u)
+	}
+
+	var resp prometheusResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, errors.Errorf(
+			"Get %s: unparseable response with HTTP status code %d", u, r.StatusCode)
+	}
+	if resp.Status != "success" {
+		return nil, errors.Errorf("Get %s: %s: %s", u, resp.ErrorType, resp.Error)
+	}
+	if resp.Data.ResultType != "matrix" {
+		return nil, errors.Errorf("unexpected Prometheus result type: %s", resp.Data.ResultType)
+	}
+
+	series := make([]PrometheusSeries, 0, len(resp.Data.Result))
+	for _, result := range resp.Data.Result {
+		s := PrometheusSeries{
+			Labels: result.Metric,
+			Times:  make([]time.Time, len(result.Values)),
+			Values: make([]float64, len(result.Values)),
+		}
+		for i, pair := range result.Values {
+			s.Times[i], s.Values[i], err = parsePrometheusSample(pair)
+			if err != nil {
+				return nil, errors.Mask(err)
+			}
+		}
+		series = append(series, s)
+	}
+
+	return series, nil
+}
+
+func parsePrometheusSample(pair []json.RawMessage) (time.Time, float64, error) {
+	if len(pair) != 2 {
+		return time.Time{}, 0, errors.Errorf("malformed sample: %s", pair)
+	}
+
+	var ts float64
+	if err := json.Unmarshal(pair[0], &ts); err != nil {
+		return time.Time{}, 0, errors.Errorf("could not parse sample time: %s", pair[0])
+	}
+
+	var valueString string
+	if err := json.Unmarshal(pair[1], &valueString); err != nil {
+		return time.Time{}, 0, errors.Errorf("could not parse sample value: %s", pair[1])
+	}
+	value, err := strconv.ParseFloat(valueString, 64)
+	if err != nil {
+		return time.Time{}, 0, errors.Errorf("could not parse sample value: %s", valueString)
+	}
+
+	sec := int64(ts)
+	nsec := int64((ts - float64(sec)) * float64(time.Second))
+	return time.Unix(sec, nsec), value, nil
+}
+
+// APIURL builds a Prometheus HTTP API URL for the given endpoint and query
+// arguments.
+func (p PrometheusDaemon) APIURL(endpoint string, args url.Values) string {
+	return strings.TrimRight(p.Base, "/") + "/api/v1/" + endpoint + "?" + args.Encode()
+}
+
+// GraphURL builds a URL to the Prometheus expression browser graphing expr
+// over the given time range.
+func (p PrometheusDaemon) GraphURL(expr string, end time.Time, rangeDuration time.Duration) string {
+	args := url.Values{
+		"g0.expr":        []string{expr},
+		"g0.tab":         []string{"0"},
+		"g0.range_input": []string{fmt.Sprintf("%ds", int64(rangeDuration/time.Second))},
+		"g0.end_input":   []string{end.UTC().Format("2006-01-02 15:04:05")},
+	}
+	return strings.TrimRight(p.Base, "/") + "/graph?" + args.Encode()
+}
+
+// PrometheusTimestamp converts t to a value suitable for use as a time
+// argument to the Prometheus HTTP API.
+func PrometheusTimestamp(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', 3, 64)
+}
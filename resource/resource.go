@@ -37,6 +37,8 @@ type Resource interface {
 	Serialize() (string, error)
 	Type() ResourceType
 	Validate() []string
+	// Label describes the resource to people, such as in notices about it.
+	Label() string
 }
 
 const (
@@ -96,6 +98,23 @@ func LoadFromDB(id db.ResourceType, dsJson string) (Resource, error) {
 	return dsType.loadFromDB(dsJson)
 }
 
+// LoadLabel loads the label of the resource with the given ID.
+func LoadLabel(tx *db.Tx, id db.ResourceID) (string, error) {
+	ds, err := tx.LoadResource(id)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if ds == nil {
+		return "", errors.Errorf("no resource with ID %d", id)
+	}
+
+	resource, err := LoadFromDB(ds.ResourceType, ds.Resource)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return resource.Label(), nil
+}
+
 func Blank(id db.ResourceType) (Resource, error) {
 	dsType, err := getType(id)
 	if err != nil {
@@ -118,7 +137,7 @@ func addType(resourceType ResourceType) {
 	if _, ok := types[resourceType.Id()]; !ok {
 		types[resourceType.Id()] = resourceType
 	} else {
-		panic(fmt.Sprintf("A resource type with id %d already exists", resourceType.Id))
+		panic(fmt.Sprintf("A resource type with id %d already exists", resourceType.Id()))
 	}
 }
 
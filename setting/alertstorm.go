@@ -0,0 +1,136 @@
+package setting
+
+import (
+	"encoding/json"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/yext/revere/db"
+)
+
+type AlertStorm struct{}
+
+// AlertStormSetting protects people from floods of alerts. Each email
+// address and Slack channel gets at most MaxAlerts alerts per WindowMinutes;
+// later ones are counted and summarized once the window ends. When at least
+// OutagePercent of the monitors reading from a resource can't reach it, their
+// alerts are replaced by one notice to OutageEmail. Zero values turn the
+// protections off.
+type AlertStormSetting struct {
+	AlertStorm
+	MaxAlerts     int
+	WindowMinutes int
+	OutagePercent int
+	OutageEmail   string
+}
+
+type AlertStormSettingDBModel struct {
+	MaxAlerts     int
+	WindowMinutes int
+	OutagePercent int
+	OutageEmail   string
+}
+
+func init() {
+	addType(AlertStorm{})
+}
+
+func (AlertStorm) Id() db.SettingType {
+	return 4
+}
+
+func (AlertStorm) Name() string {
+	return "Alert Storm Protection"
+}
+
+func (AlertStorm) loadFromParams(s string) (Setting, error) {
+	var as AlertStormSetting
+	err := json.Unmarshal([]byte(s), &as)
+	if err != nil {
+		return nil, err
+	}
+	return &as, nil
+}
+
+func (AlertStorm) loadFromDB(s string) (Setting, error) {
+	var as AlertStormSettingDBModel
+	err := json.Unmarshal([]byte(s), &as)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AlertStormSetting{
+		MaxAlerts:     as.MaxAlerts,
+		WindowMinutes: as.WindowMinutes,
+		OutagePercent: as.OutagePercent,
+		OutageEmail:   as.OutageEmail,
+	}, nil
+}
+
+func (AlertStorm) blank() (Setting, error) {
+	return &AlertStormSetting{}, nil
+}
+
+func (AlertStorm) Template() string {
+	return "_alert-storm.html"
+}
+
+func (AlertStorm) Scripts() []string {
+	return []string{
+		"alert-storm.js",
+	}
+}
+
+func (as *AlertStormSetting) Serialize() (string, error) {
+	asDB := AlertStormSettingDBModel{
+		MaxAlerts:     as.MaxAlerts,
+		WindowMinutes: as.WindowMinutes,
+		OutagePercent: as.OutagePercent,
+		OutageEmail:   strings.TrimSpace(as.OutageEmail),
+	}
+
+	asDBJSON, err := json.Marshal(asDB)
+	return string(asDBJSON), err
+}
+
+func (*AlertStormSetting) Type() SettingType {
+	return AlertStorm{}
+}
+
+func (as *AlertStormSetting) Validate() []string {
+	var errs []string
+
+	if as.MaxAlerts < 0 {
+		errs = append(errs, "Maximum alerts can't be negative")
+	}
+	if as.MaxAlerts > 0 && as.WindowMinutes <= 0 {
+		errs = append(errs, "A window of at least one minute is required to limit alerts")
+	}
+
+	if as.OutagePercent < 0 || as.OutagePercent > 100 {
+		errs = append(errs, "Outage percentage must be between 0 and 100")
+	}
+	if as.OutagePercent > 0 {
+		if _, err := mail.ParseAddress(strings.TrimSpace(as.OutageEmail)); err != nil {
+			errs = append(errs, "A valid email address is required for outage notices")
+		}
+	}
+
+	return errs
+}
+
+// Limits returns whether alerts are limited.
+func (as *AlertStormSetting) Limits() bool {
+	return as.MaxAlerts > 0 && as.WindowMinutes > 0
+}
+
+// Window returns how long each limit on alerts lasts.
+func (as *AlertStormSetting) Window() time.Duration {
+	return time.Duration(as.WindowMinutes) * time.Minute
+}
+
+// DetectsOutages returns whether resource outages are alerted on as one.
+func (as *AlertStormSetting) DetectsOutages() bool {
+	return as.OutagePercent > 0 && as.OutageEmail != ""
+}
@@ -0,0 +1,111 @@
+package setting
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/yext/revere/db"
+)
+
+type Jira struct{}
+
+type JiraSetting struct {
+	Jira
+	BaseURL  string
+	Username string
+	APIToken string
+}
+
+type JiraSettingDBModel struct {
+	BaseURL  string
+	Username string
+	APIToken string
+}
+
+func init() {
+	addType(Jira{})
+}
+
+func (Jira) Id() db.SettingType {
+	return 3
+}
+
+func (Jira) Name() string {
+	return "Jira Configuration"
+}
+
+func (Jira) loadFromParams(s string) (Setting, error) {
+	var js JiraSetting
+	err := json.Unmarshal([]byte(s), &js)
+	if err != nil {
+		return nil, err
+	}
+	return &js, nil
+}
+
+func (Jira) loadFromDB(s string) (Setting, error) {
+	var js JiraSettingDBModel
+	err := json.Unmarshal([]byte(s), &js)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JiraSetting{
+		BaseURL:  js.BaseURL,
+		Username: js.Username,
+		APIToken: js.APIToken,
+	}, nil
+}
+
+func (Jira) blank() (Setting, error) {
+	return &JiraSetting{}, nil
+}
+
+func (Jira) Template() string {
+	return "_jira.html"
+}
+
+func (Jira) Scripts() []string {
+	return []string{
+		"jira.js",
+	}
+}
+
+func (js *JiraSetting) Serialize() (string, error) {
+	jsDB := JiraSettingDBModel{
+		BaseURL:  js.BaseURL,
+		Username: js.Username,
+		APIToken: js.APIToken,
+	}
+
+	jsDBJSON, err := json.Marshal(jsDB)
+	return string(jsDBJSON), err
+}
+
+func (*JiraSetting) Type() SettingType {
+	return Jira{}
+}
+
+func (js *JiraSetting) Validate() []string {
+	var errs []string
+
+	// Jira is optional, so it can be left blank.
+	if js.BaseURL == "" && js.Username == "" && js.APIToken == "" {
+		return errs
+	}
+
+	u, err := url.ParseRequestURI(js.BaseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		errs = append(errs,
+			"Invalid Jira URL. Should be formatted as: https://your-company.atlassian.net")
+	}
+
+	if js.Username == "" {
+		errs = append(errs, "Jira username is required")
+	}
+
+	if js.APIToken == "" {
+		errs = append(errs, "Jira API token is required")
+	}
+	return errs
+}
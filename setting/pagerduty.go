@@ -0,0 +1,106 @@
+package setting
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/yext/revere/db"
+)
+
+// DefaultPagerDutyAPIURL is where PagerDuty events are sent when no API URL
+// is configured.
+const DefaultPagerDutyAPIURL = "https://events.pagerduty.com"
+
+type PagerDuty struct{}
+
+type PagerDutySetting struct {
+	PagerDuty
+	APIURL string
+}
+
+type PagerDutySettingDBModel struct {
+	APIURL string
+}
+
+func init() {
+	addType(PagerDuty{})
+}
+
+func (PagerDuty) Id() db.SettingType {
+	return 2
+}
+
+func (PagerDuty) Name() string {
+	return "PagerDuty Configuration"
+}
+
+func (PagerDuty) loadFromParams(s string) (Setting, error) {
+	var pd PagerDutySetting
+	err := json.Unmarshal([]byte(s), &pd)
+	if err != nil {
+		return nil, err
+	}
+	return &pd, nil
+}
+
+func (PagerDuty) loadFromDB(s string) (Setting, error) {
+	var pd PagerDutySettingDBModel
+	err := json.Unmarshal([]byte(s), &pd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PagerDutySetting{
+		APIURL: pd.APIURL,
+	}, nil
+}
+
+func (PagerDuty) blank() (Setting, error) {
+	return &PagerDutySetting{}, nil
+}
+
+func (PagerDuty) Template() string {
+	return "_pagerduty.html"
+}
+
+func (PagerDuty) Scripts() []string {
+	return []string{
+		"pagerduty.js",
+	}
+}
+
+func (pd *PagerDutySetting) Serialize() (string, error) {
+	pdDB := PagerDutySettingDBModel{
+		APIURL: pd.APIURL,
+	}
+
+	pdDBJSON, err := json.Marshal(pdDB)
+	return string(pdDBJSON), err
+}
+
+func (*PagerDutySetting) Type() SettingType {
+	return PagerDuty{}
+}
+
+func (pd *PagerDutySetting) Validate() []string {
+	var errs []string
+
+	// A blank API URL means PagerDuty's own Events API.
+	if pd.APIURL != "" {
+		if _, err := url.ParseRequestURI(pd.APIURL); err != nil {
+			errs = append(errs,
+				"Invalid API URL. Should be formatted as: "+DefaultPagerDutyAPIURL)
+		}
+	}
+	return errs
+}
+
+// EventsURL returns the Events API v2 endpoint to send events to.
+func (pd *PagerDutySetting) EventsURL() string {
+	base := pd.APIURL
+	if base == "" {
+		base = DefaultPagerDutyAPIURL
+	}
+	return strings.TrimRight(base, "/") + "/v2/enqueue"
+}
@@ -90,12 +90,14 @@ func (ss *SlackSetting) Validate() []string {
 	var errs []string
 
 	// TODO(psingh): Better validation, check if valid with slack
-	_, err := url.ParseRequestURI(ss.WebhookURL)
-	if err != nil {
-		errs = append(errs,
-			"Invalid Webhook URL. Should be formatted as: "+
-				"https://hooks.slack.com/services/"+
-				"T00000000/B00000000/XXXXXXXXXXXXXXXXXXXXXXXX")
+	// Alerts are posted with the API token, so the webhook is optional.
+	if ss.WebhookURL != "" {
+		if _, err := url.ParseRequestURI(ss.WebhookURL); err != nil {
+			errs = append(errs,
+				"Invalid Webhook URL. Should be formatted as: "+
+					"https://hooks.slack.com/services/"+
+					"T00000000/B00000000/XXXXXXXXXXXXXXXXXXXXXXXX")
+		}
 	}
 
 	if ss.APIToken == "" {
@@ -1,6 +1,7 @@
 package target
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/yext/revere/db"
@@ -27,5 +28,49 @@ type Alert struct {
 
 	Details probe.Details
 
+	// Flapping is set on the one alert sent when a subprobe starts flapping.
+	// Alerts on its state changes are suppressed until it stabilizes.
+	Flapping bool
+
+	// Grouped holds the alerts on each of several subprobes of the monitor
+	// when they are sent together as one. The other fields are those of the
+	// first of them.
+	Grouped []*Alert
+
 	Host string
 }
+
+// GroupTitle names what a is about: the subprobe, or the monitor and how many
+// of its subprobes changed for a grouped alert.
+func (a *Alert) GroupTitle() string {
+	if len(a.Grouped) > 0 {
+		return fmt.Sprintf("%s: %d subprobes", a.MonitorName, len(a.Grouped))
+	}
+	return fmt.Sprintf("%s/%s", a.MonitorName, a.SubprobeName)
+}
+
+// AckURL returns the web UI page for acknowledging the alerting subprobe.
+func (a *Alert) AckURL() string {
+	return fmt.Sprintf("%s/monitors/%d/subprobes/%d/ack", a.Host, a.MonitorID, a.SubprobeID)
+}
+
+// SampleAlert returns a made-up alert for previewing how targets format
+// alerts.
+func SampleAlert() *Alert {
+	recorded := time.Date(2016, time.March, 7, 9, 30, 0, 0, time.UTC)
+	return &Alert{
+		MonitorID:    1,
+		MonitorName:  "Sample Monitor",
+		SubprobeID:   2,
+		SubprobeName: "sample.subprobe",
+		Description:  "Checks that the sample service is healthy.",
+		Response:     "Restart the sample service.",
+		OldState:     state.Warning,
+		NewState:     state.Error,
+		Recorded:     recorded,
+		EnteredState: recorded,
+		LastNormal:   recorded.Add(-10 * time.Minute),
+		Details:      textDetails("Value 42 exceeded the error threshold of 40."),
+		Host:         "https://revere.example.com",
+	}
+}
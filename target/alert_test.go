@@ -0,0 +1,52 @@
+package target_test
+
+import (
+	"testing"
+
+	"github.com/yext/revere/state"
+	. "github.com/yext/revere/target"
+)
+
+func groupedSampleAlert() *Alert {
+	first := SampleAlert()
+	second := SampleAlert()
+	second.SubprobeID = 3
+	second.SubprobeName = "other.subprobe"
+	second.OldState = state.Error
+	second.NewState = state.Normal
+
+	grouped := *first
+	grouped.Grouped = []*Alert{first, second}
+	return &grouped
+}
+
+func TestAlertGroupTitle(t *testing.T) {
+	if title := SampleAlert().GroupTitle(); title != "Sample Monitor/sample.subprobe" {
+		t.Errorf("Unexpected title for single alert: %s", title)
+	}
+	if title := groupedSampleAlert().GroupTitle(); title != "Sample Monitor: 2 subprobes" {
+		t.Errorf("Unexpected title for grouped alert: %s", title)
+	}
+}
+
+func TestGroupedAlertOutbox(t *testing.T) {
+	encoded, err := EncodeAlert(groupedSampleAlert())
+	if err != nil {
+		t.Fatalf("Unexpected error encoding alert: %v", err)
+	}
+	a, err := DecodeAlert(encoded)
+	if err != nil {
+		t.Fatalf("Unexpected error decoding alert: %v", err)
+	}
+
+	if len(a.Grouped) != 2 {
+		t.Fatalf("Expected 2 grouped alerts, got %d", len(a.Grouped))
+	}
+	second := a.Grouped[1]
+	if second.SubprobeName != "other.subprobe" || second.OldState != state.Error || second.NewState != state.Normal {
+		t.Errorf("Unexpected second grouped alert: %+v", second)
+	}
+	if second.Details == nil || second.Details.Text() != SampleAlert().Details.Text() {
+		t.Error("Expected details of grouped alert to be kept")
+	}
+}
@@ -39,13 +39,13 @@ func validEmailTarget() (*EmailTarget, error) {
 
 func TestEmailId(t *testing.T) {
 	if int(emailTargetType.Id()) != emailId {
-		t.Errorf("Expected email target type id: %d, got %d\n", emailId, emailTargetType.Id)
+		t.Errorf("Expected email target type id: %d, got %d\n", emailId, emailTargetType.Id())
 	}
 }
 
 func TestEmailName(t *testing.T) {
 	if emailTargetType.Name() != emailName {
-		t.Errorf("Expected email target type name: %s, got %s\n", emailName, emailTargetType.Name)
+		t.Errorf("Expected email target type name: %s, got %s\n", emailName, emailTargetType.Name())
 	}
 }
 
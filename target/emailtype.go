@@ -30,6 +30,12 @@ func (_ emailType) New(config types.JSONText) (Target, error) {
 	return newEmail(config)
 }
 
+func (_ emailType) groupsAlerts() {}
+
+func (_ emailType) sendSummary(Db *db.DB, address string, s LimitSummary) error {
+	return EmailNotice(Db, []string{address}, "Alerts suppressed", s.Text())
+}
+
 func (_ emailType) Alert(Db *db.DB, a *Alert, toAlert map[db.TriggerID]Target, inactive []Target) []ErrorAndTriggerIDs {
 	triggerIDs := make([]db.TriggerID, 0, len(toAlert))
 	for id := range toAlert {
@@ -54,73 +60,138 @@ func (_ emailType) Alert(Db *db.DB, a *Alert, toAlert map[db.TriggerID]Target, i
 		return nil
 	}
 
-	// TODO(eefi): Respect line length limits. Encode headers and body to
-	// avoid UTF-8 causing breaks.
+	err := sendEmail(Db, a, to, replyTo)
+	if err != nil {
+		return []ErrorAndTriggerIDs{{
+			Err: errors.Trace(err),
+			IDs: triggerIDs,
+		}}
+	}
 
+	return nil
+}
+
+// sendEmail emails a to the given addresses using the outgoing email
+// settings. Addresses that have had too many alerts lately are left out.
+func sendEmail(Db *db.DB, a *Alert, to []string, replyTo []string) error {
+	to = limiter.allow(emailType{}.ID(), to, a.Host, time.Now())
+	if len(to) == 0 {
+		return nil
+	}
+
+	emailSettings, err := loadOutgoingEmailSetting(Db)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	subject := fmt.Sprintf("[%s] %s", emailSettings.SubjectLinePrefix, a.GroupTitle())
+	if a.Flapping && len(a.Grouped) == 0 {
+		subject += " is flapping"
+	}
+
+	var body bytes.Buffer
+	err = emailTmpl.Execute(&body, a)
+	if err != nil {
+		return errors.Maskf(err, "render email")
+	}
+
+	return sendEmailMessage(emailSettings, to, replyTo, subject, body.String())
+}
+
+// EmailNotice emails text to the given addresses using the outgoing email
+// settings. It is for notices about Revere as a whole rather than about one
+// subprobe, and isn't limited like alerts are.
+func EmailNotice(Db *db.DB, to []string, subject string, text string) error {
+	emailSettings, err := loadOutgoingEmailSetting(Db)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	subject = fmt.Sprintf("[%s] %s", emailSettings.SubjectLinePrefix, subject)
+	return sendEmailMessage(emailSettings, to, nil, subject, "\n"+text+"\n")
+}
+
+func loadOutgoingEmailSetting(Db *db.DB) (*setting.OutgoingEmailSetting, error) {
 	emailSetting := setting.OutgoingEmailSetting{}
 
 	dbSettings, err := Db.LoadSettingsOfType(emailSetting.Type().Id())
-	if err != nil || len(dbSettings) == 0 {
-		return []ErrorAndTriggerIDs{{
-			Err: errors.Maskf(err, "getting settings from db"),
-			IDs: triggerIDs,
-		}}
+	if err != nil {
+		return nil, errors.Maskf(err, "getting settings from db")
+	}
+	if len(dbSettings) == 0 {
+		return nil, errors.New("no outgoing email settings")
 	}
 
 	settingsFromDB, err := setting.LoadFromDB(emailSetting.Type().Id(), dbSettings[0].Setting)
 	if err != nil {
-		return []ErrorAndTriggerIDs{{
-			Err: errors.Maskf(err, "unparsing db settings"),
-			IDs: triggerIDs,
-		}}
+		return nil, errors.Maskf(err, "unparsing db settings")
 	}
 
 	emailSettings, found := settingsFromDB.(*setting.OutgoingEmailSetting)
 	if !found {
-		return []ErrorAndTriggerIDs{{
-			Err: errors.Maskf(err, "extracting email settings"),
-			IDs: triggerIDs,
-		}}
+		return nil, errors.New("extracting email settings")
 	}
+	return emailSettings, nil
+}
+
+func sendEmailMessage(emailSettings *setting.OutgoingEmailSetting, to []string, replyTo []string, subject string, body string) error {
+	// TODO(eefi): Respect line length limits. Encode headers and body to
+	// avoid UTF-8 causing breaks.
 
 	var b bytes.Buffer
 	b.WriteString(fmt.Sprintf(
 		"Date: %s\n", time.Now().UTC().Format(time.RFC822Z)))
 	b.WriteString(fmt.Sprintf(
 		"From: %s <%s>\n", emailSettings.FromName, emailSettings.FromEmail))
-	b.WriteString(fmt.Sprintf(
-		"Reply-To: %s\n", strings.Join(replyTo, ", ")))
+	if len(replyTo) > 0 {
+		b.WriteString(fmt.Sprintf(
+			"Reply-To: %s\n", strings.Join(replyTo, ", ")))
+	}
 	b.WriteString(fmt.Sprintf(
 		"To: %s\n", strings.Join(to, ", ")))
-	b.WriteString(fmt.Sprintf(
-		"Subject: [%s] %s/%s\n", emailSettings.SubjectLinePrefix, a.MonitorName, a.SubprobeName))
-
-	err = emailTmpl.Execute(&b, a)
-	if err != nil {
-		return []ErrorAndTriggerIDs{{
-			Err: errors.Maskf(err, "render email"),
-			IDs: triggerIDs,
-		}}
-	}
+	b.WriteString(fmt.Sprintf("Subject: %s\n", subject))
+	b.WriteString(body)
 
 	msg := []byte(strings.Replace(b.String(), "\n", "\r\n", -1))
 
-	err = smtp.SendMail(emailSettings.SmtpServer, nil, emailSettings.FromEmail, to, msg)
+	err := smtp.SendMail(emailSettings.SmtpServer, nil, emailSettings.FromEmail, to, msg)
 	if err != nil {
-		return []ErrorAndTriggerIDs{{
-			Err: errors.Maskf(err, "send email"),
-			IDs: triggerIDs,
-		}}
+		return errors.Maskf(err, "send email")
 	}
 
 	return nil
 }
 
 const emailText = `
+{{- if .Grouped}}
+{{len .Grouped}} subprobes of {{.MonitorName}} changed state as of {{time .Recorded}}.
+
+{{.Host}}/monitors/{{.MonitorID}}
+{{range .Grouped}}
+{{.SubprobeName}}: {{if ne .OldState .NewState}}{{.OldState}}->{{.NewState}}{{else}}{{.NewState}}{{end}}
+{{- if .Flapping}} (flapping){{end}}
+  {{.Host}}/monitors/{{.MonitorID}}/subprobes/{{.SubprobeID}}
+{{- if not (isNormal .NewState)}}
+  Acknowledge: {{.AckURL}}
+{{- end}}
+{{end}}
+{{- if .Description}}
+Description: {{.Description}}
+{{end -}}
+{{if .Response}}
+Suggested response: {{.Response}}
+{{end -}}
+{{else}}
 {{.NewState}} is the state of {{.MonitorName}}/{{.SubprobeName}} as of {{time .Recorded}}.
 
 {{.Host}}/monitors/{{.MonitorID}}/subprobes/{{.SubprobeID}}
-
+{{- if not (isNormal .NewState)}}
+Acknowledge: {{.AckURL}}
+{{- end}}
+{{if .Flapping}}
+This subprobe is changing state too often and is now flapping. Alerts on its
+state changes are suppressed until it stabilizes.
+{{end}}
 {{if ne .OldState .NewState -}}
 State change: {{.OldState}}->{{.NewState}}
 {{- else -}}
@@ -139,6 +210,7 @@ Suggested response: {{.Response}}
 Probe reading details:
 
 {{.Details.Text}}
+{{end -}}
 {{end -}}`
 
 var emailTmpl = template.Must(template.New("email").Funcs(template.FuncMap{
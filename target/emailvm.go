@@ -106,6 +106,11 @@ func (EmailTarget) Type() VMType {
 	return EmailType{}
 }
 
+// IsValidEmail returns whether address looks like an email address.
+func IsValidEmail(address string) bool {
+	return emailRegex.MatchString(address)
+}
+
 func (et EmailTarget) Validate() (errs []string) {
 	for _, e := range et.Addresses {
 		if !emailRegex.MatchString(e.To) {
@@ -0,0 +1,29 @@
+package target
+
+import (
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+)
+
+// EscalationPolicy stands in for the targets of an escalation policy's steps.
+// The daemon alerts the steps' targets itself as an incident goes
+// unacknowledged, so escalation policies are never alerted directly.
+type EscalationPolicy struct {
+	PolicyID db.EscalationPolicyID
+}
+
+func newEscalationPolicy(configJSON types.JSONText) (Target, error) {
+	var config EscalationPolicyDBModel
+	err := configJSON.Unmarshal(&config)
+	if err != nil {
+		return nil, errors.Maskf(err, "deserialize target config")
+	}
+
+	return &EscalationPolicy{PolicyID: db.EscalationPolicyID(config.PolicyID)}, nil
+}
+
+func (EscalationPolicy) Type() Type {
+	return escalationPolicyType{}
+}
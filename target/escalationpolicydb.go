@@ -0,0 +1,22 @@
+package target
+
+import (
+	"encoding/json"
+
+	"github.com/jmoiron/sqlx/types"
+
+	"github.com/yext/revere/db"
+)
+
+// EscalationPolicyDBModel defines the JSON serialization format for saving
+// escalation policy targets' settings in the database.
+type EscalationPolicyDBModel struct {
+	PolicyID int64
+}
+
+// EscalationPolicyTargetJSON returns how triggers targeting the given
+// escalation policy store their target in the database.
+func EscalationPolicyTargetJSON(id db.EscalationPolicyID) (types.JSONText, error) {
+	epDBJSON, err := json.Marshal(EscalationPolicyDBModel{PolicyID: int64(id)})
+	return types.JSONText(epDBJSON), err
+}
@@ -0,0 +1,35 @@
+package target
+
+import (
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+)
+
+type escalationPolicyType struct{}
+
+func init() {
+	registerTargetType(escalationPolicyType{})
+}
+
+func (escalationPolicyType) ID() db.TargetType {
+	return 3
+}
+
+func (escalationPolicyType) New(config types.JSONText) (Target, error) {
+	return newEscalationPolicy(config)
+}
+
+func (escalationPolicyType) Alert(
+	Db *db.DB, a *Alert, toAlert map[db.TriggerID]Target, inactive []Target) []ErrorAndTriggerIDs {
+	triggerIDs := make([]db.TriggerID, 0, len(toAlert))
+	for id := range toAlert {
+		triggerIDs = append(triggerIDs, id)
+	}
+
+	return []ErrorAndTriggerIDs{{
+		Err: errors.New("escalation policies alert through their steps' targets"),
+		IDs: triggerIDs,
+	}}
+}
@@ -0,0 +1,78 @@
+package target
+
+import (
+	"encoding/json"
+
+	"github.com/yext/revere/db"
+)
+
+type EscalationPolicyType struct{}
+
+type EscalationPolicyTarget struct {
+	EscalationPolicyType
+	PolicyID int64
+}
+
+func init() {
+	addType(EscalationPolicyType{})
+}
+
+func (EscalationPolicyType) Id() db.TargetType {
+	return 3
+}
+
+func (EscalationPolicyType) Name() string {
+	return "Escalation Policy"
+}
+
+func (EscalationPolicyType) loadFromParams(target string) (VM, error) {
+	var ep EscalationPolicyTarget
+	err := json.Unmarshal([]byte(target), &ep)
+	if err != nil {
+		return nil, err
+	}
+	return ep, nil
+}
+
+func (EscalationPolicyType) loadFromDb(encodedTarget string) (VM, error) {
+	var ep EscalationPolicyDBModel
+	err := json.Unmarshal([]byte(encodedTarget), &ep)
+	if err != nil {
+		return nil, err
+	}
+
+	return EscalationPolicyTarget{
+		PolicyID: ep.PolicyID,
+	}, nil
+}
+
+func (EscalationPolicyType) blank() VM {
+	return EscalationPolicyTarget{}
+}
+
+func (EscalationPolicyType) Templates() map[string]string {
+	return map[string]string{
+		"edit": "escalation-policy-edit.html",
+		"view": "escalation-policy-view.html",
+	}
+}
+
+func (EscalationPolicyType) Scripts() map[string][]string {
+	return map[string][]string{}
+}
+
+func (ept EscalationPolicyTarget) Serialize() (string, error) {
+	eptDBJSON, err := EscalationPolicyTargetJSON(db.EscalationPolicyID(ept.PolicyID))
+	return string(eptDBJSON), err
+}
+
+func (EscalationPolicyTarget) Type() VMType {
+	return EscalationPolicyType{}
+}
+
+func (ept EscalationPolicyTarget) Validate() (errs []string) {
+	if ept.PolicyID <= 0 {
+		errs = append(errs, "Escalation policy is required.")
+	}
+	return
+}
@@ -0,0 +1,49 @@
+package target
+
+import (
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/state"
+)
+
+// Jira opens an issue in a Jira project for each subprobe incident, comments on
+// it for later alerts and closes it when the subprobe recovers.
+type Jira struct {
+	Project   string
+	IssueType string
+	// CloseTransition is the name of the workflow transition that closes
+	// issues.
+	CloseTransition string
+
+	// Level is the level of the trigger the target belongs to.
+	Level state.State
+}
+
+func newJira(configJSON types.JSONText) (Target, error) {
+	var config JiraDBModel
+	err := configJSON.Unmarshal(&config)
+	if err != nil {
+		return nil, errors.Maskf(err, "deserialize target config")
+	}
+
+	return &Jira{
+		Project:         config.Project,
+		IssueType:       config.IssueType,
+		CloseTransition: config.CloseTransition,
+	}, nil
+}
+
+func (Jira) Type() Type {
+	return jiraType{}
+}
+
+func (j *Jira) setLevel(level state.State) {
+	j.Level = level
+}
+
+// Closes returns whether a reports the end of the incident j opened an issue
+// for, which happens when its trigger alerts on exit.
+func (j *Jira) Closes(a *Alert) bool {
+	return endsIncident(j.Level, a)
+}
@@ -0,0 +1,62 @@
+package target_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+	. "github.com/yext/revere/target"
+)
+
+func TestJiraTargetValidate(t *testing.T) {
+	blank, err := Blank(JiraType{}.Id())
+	if err != nil {
+		t.Fatalf("Unexpected error making blank target: %v\n", err)
+	}
+	valid := blank.(JiraTarget)
+	valid.Project = "OPS"
+	if errs := valid.Validate(); errs != nil {
+		t.Errorf("Unexpected errors for valid target: %v\n", errs)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(j *JiraTarget)
+	}{
+		{"missing project", func(j *JiraTarget) { j.Project = "" }},
+		{"lower case project", func(j *JiraTarget) { j.Project = "ops" }},
+		{"missing issue type", func(j *JiraTarget) { j.IssueType = " " }},
+		{"missing close transition", func(j *JiraTarget) { j.CloseTransition = "" }},
+	}
+	for _, tt := range tests {
+		invalid := valid
+		tt.modify(&invalid)
+		if errs := invalid.Validate(); errs == nil {
+			t.Errorf("Expected error for %s\n", tt.name)
+		}
+	}
+}
+
+func TestJiraCloses(t *testing.T) {
+	config, _ := json.Marshal(JiraDBModel{Project: "OPS", IssueType: "Task", CloseTransition: "Done"})
+	tgt, err := NewForTrigger(&db.Trigger{
+		Level:      state.Error,
+		TargetType: JiraType{}.Id(),
+		Target:     config,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error making target: %v\n", err)
+	}
+	j := tgt.(*Jira)
+
+	if j.Closes(&Alert{OldState: state.Normal, NewState: state.Error}) {
+		t.Errorf("Expected reaching the trigger level to open an issue\n")
+	}
+	if j.Closes(&Alert{OldState: state.Error, NewState: state.Error}) {
+		t.Errorf("Expected repeats to comment on the issue\n")
+	}
+	if !j.Closes(&Alert{OldState: state.Error, NewState: state.Warning}) {
+		t.Errorf("Expected dropping below the trigger level to close the issue\n")
+	}
+}
@@ -0,0 +1,9 @@
+package target
+
+// JiraDBModel defines the JSON serialization format for saving Jira targets'
+// settings in the database.
+type JiraDBModel struct {
+	Project         string
+	IssueType       string
+	CloseTransition string
+}
@@ -0,0 +1,297 @@
+package target
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/setting"
+)
+
+// Jira's limit on the length of issue summaries.
+const jiraMaxSummary = 255
+
+var jiraHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+type jiraNotifier struct {
+	alert  *Alert
+	client jiraClient
+	issues jiraIssueStore
+}
+
+// jiraIssueStore keeps the issues of open incidents. *db.DB is one.
+type jiraIssueStore interface {
+	LoadJiraIssue(subprobeID db.SubprobeID, project string) (*db.JiraIssue, error)
+	SaveJiraIssue(i db.JiraIssue) error
+	DeleteJiraIssue(subprobeID db.SubprobeID, project string) error
+}
+
+// send opens, comments on or closes the issue for the alerting subprobe in
+// j's project. The issue is remembered in the database so that later alerts,
+// even after restarts, find it.
+func (n jiraNotifier) send(j *Jira, closes bool) error {
+	a := n.alert
+	key, err := n.issueKey(j.Project)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if closes {
+		if key == "" {
+			return nil
+		}
+		if err := n.client.comment(key, n.comment()); err != nil {
+			return errors.Trace(err)
+		}
+		if err := n.client.transition(key, j.CloseTransition); err != nil {
+			return errors.Trace(err)
+		}
+		err = n.issues.DeleteJiraIssue(a.SubprobeID, j.Project)
+		return errors.Maskf(err, "deleting jira issue %s", key)
+	}
+
+	if key != "" {
+		return errors.Trace(n.client.comment(key, n.comment()))
+	}
+
+	summary := fmt.Sprintf("%s/%s is %s", a.MonitorName, a.SubprobeName, a.NewState)
+	if len(summary) > jiraMaxSummary {
+		summary = summary[:jiraMaxSummary]
+	}
+	key, err = n.client.createIssue(j.Project, j.IssueType, n.label(), summary, n.description())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(n.saveIssue(j.Project, key))
+}
+
+// issueKey returns the key of the open issue for the alerting subprobe in
+// project, or "" if there isn't one. An issue missing from the database
+// because saving it failed is found in Jira by its label, so that retries
+// don't open duplicates.
+func (n jiraNotifier) issueKey(project string) (string, error) {
+	a := n.alert
+	issue, err := n.issues.LoadJiraIssue(a.SubprobeID, project)
+	if err != nil {
+		return "", errors.Maskf(err, "loading jira issue for %s", project)
+	}
+	if issue != nil {
+		return issue.IssueKey, nil
+	}
+
+	key, err := n.client.findOpenIssue(project, n.label())
+	if err != nil || key == "" {
+		return "", errors.Trace(err)
+	}
+	return key, errors.Trace(n.saveIssue(project, key))
+}
+
+func (n jiraNotifier) saveIssue(project, key string) error {
+	err := n.issues.SaveJiraIssue(db.JiraIssue{
+		SubprobeID: n.alert.SubprobeID,
+		Project:    project,
+		IssueKey:   key,
+		Created:    time.Now().UTC(),
+	})
+	return errors.Maskf(err, "saving jira issue %s", key)
+}
+
+// label returns the label marking issues opened for the alerting subprobe.
+func (n jiraNotifier) label() string {
+	return fmt.Sprintf("revere-subprobe-%d", n.alert.SubprobeID)
+}
+
+func (n jiraNotifier) description() string {
+	a := n.alert
+	lines := []string{
+		alertStateText(a),
+		"",
+		fmt.Sprintf("Subprobe: %s/monitors/%d/subprobes/%d", a.Host, a.MonitorID, a.SubprobeID),
+		fmt.Sprintf("Acknowledge: %s", a.AckURL()),
+	}
+	if a.Description != "" {
+		lines = append(lines, "", "h3. Description", a.Description)
+	}
+	if a.Response != "" {
+		lines = append(lines, "", "h3. Response", a.Response)
+	}
+	if a.Details != nil {
+		lines = append(lines, "", "h3. Details", "{noformat}", a.Details.Text(), "{noformat}")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (n jiraNotifier) comment() string {
+	a := n.alert
+	text := alertStateText(a)
+	if a.Details != nil {
+		text = fmt.Sprintf("%s\n{noformat}\n%s\n{noformat}", text, a.Details.Text())
+	}
+	return text
+}
+
+// jiraClient calls the Jira REST API.
+type jiraClient struct {
+	baseURL  string
+	username string
+	apiToken string
+}
+
+func newJiraClient(s *setting.JiraSetting) jiraClient {
+	return jiraClient{
+		baseURL:  strings.TrimRight(s.BaseURL, "/"),
+		username: s.Username,
+		apiToken: s.APIToken,
+	}
+}
+
+type jiraIssue struct {
+	Fields jiraIssueFields `json:"fields"`
+}
+
+type jiraIssueFields struct {
+	Project     jiraKey  `json:"project"`
+	IssueType   jiraName `json:"issuetype"`
+	Summary     string   `json:"summary"`
+	Description string   `json:"description"`
+	Labels      []string `json:"labels"`
+}
+
+type jiraKey struct {
+	Key string `json:"key"`
+}
+
+type jiraName struct {
+	Name string `json:"name"`
+}
+
+type jiraTransition struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+func (c jiraClient) createIssue(project, issueType, label, summary, description string) (string, error) {
+	issue := jiraIssue{Fields: jiraIssueFields{
+		Project:     jiraKey{Key: project},
+		IssueType:   jiraName{Name: issueType},
+		Summary:     summary,
+		Description: description,
+		Labels:      []string{"revere", label},
+	}}
+
+	var created jiraKey
+	if err := c.do("POST", "/rest/api/2/issue", issue, &created); err != nil {
+		return "", errors.Maskf(err, "creating jira issue in %s", project)
+	}
+	return created.Key, nil
+}
+
+// findOpenIssue returns the key of the most recent unresolved issue in project
+// with the given label, or "" if there isn't one.
+func (c jiraClient) findOpenIssue(project, label string) (string, error) {
+	jql := fmt.Sprintf(`project = %s AND labels = %s AND statusCategory != Done ORDER BY created DESC`,
+		jqlString(project), jqlString(label))
+	query := url.Values{
+		"jql":        {jql},
+		"fields":     {"key"},
+		"maxResults": {"1"},
+	}
+	var found struct {
+		Issues []jiraKey `json:"issues"`
+	}
+	if err := c.do("GET", "/rest/api/2/search?"+query.Encode(), nil, &found); err != nil {
+		return "", errors.Maskf(err, "searching for jira issue labelled %s", label)
+	}
+	if len(found.Issues) == 0 {
+		return "", nil
+	}
+	return found.Issues[0].Key, nil
+}
+
+// jqlReplacer escapes the characters that are special inside a quoted JQL
+// string.
+var jqlReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// jqlString quotes s for use as a value in a JQL query, so quotes and
+// reserved words in it can't change the query.
+func jqlString(s string) string {
+	return `"` + jqlReplacer.Replace(s) + `"`
+}
+
+func (c jiraClient) comment(key, body string) error {
+	err := c.do("POST", fmt.Sprintf("/rest/api/2/issue/%s/comment", key),
+		map[string]string{"body": body}, nil)
+	return errors.Maskf(err, "commenting on jira issue %s", key)
+}
+
+// transition moves an issue through the workflow transition with the given
+// name.
+func (c jiraClient) transition(key, name string) error {
+	path := fmt.Sprintf("/rest/api/2/issue/%s/transitions", key)
+	var available struct {
+		Transitions []jiraTransition `json:"transitions"`
+	}
+	if err := c.do("GET", path, nil, &available); err != nil {
+		return errors.Maskf(err, "loading transitions of jira issue %s", key)
+	}
+
+	names := make([]string, 0, len(available.Transitions))
+	for _, t := range available.Transitions {
+		if strings.EqualFold(t.Name, name) {
+			err := c.do("POST", path,
+				map[string]jiraTransition{"transition": {ID: t.ID}}, nil)
+			return errors.Maskf(err, "transitioning jira issue %s", key)
+		}
+		names = append(names, t.Name)
+	}
+	return errors.Errorf("jira issue %s has no transition %q, only %v", key, name, names)
+}
+
+// do calls a Jira REST API method, sending body as JSON if it isn't nil and
+// decoding the response into result if it isn't nil.
+func (c jiraClient) do(method, path string, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		reader = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.SetBasicAuth(c.username, c.apiToken)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := jiraHTTPClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("not-OK HTTP status code: %d, when calling %s %s",
+			resp.StatusCode, method, path)
+	}
+
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return errors.Maskf(err, "decoding response of %s %s", method, path)
+		}
+	}
+	return nil
+}
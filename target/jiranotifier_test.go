@@ -0,0 +1,205 @@
+package target
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+)
+
+type fakeJiraIssues struct {
+	issues    map[string]db.JiraIssue
+	saveError error
+}
+
+func newFakeJiraIssues() *fakeJiraIssues {
+	return &fakeJiraIssues{issues: make(map[string]db.JiraIssue)}
+}
+
+func (f *fakeJiraIssues) LoadJiraIssue(subprobeID db.SubprobeID, project string) (*db.JiraIssue, error) {
+	if i, ok := f.issues[project]; ok && i.SubprobeID == subprobeID {
+		return &i, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeJiraIssues) SaveJiraIssue(i db.JiraIssue) error {
+	if f.saveError != nil {
+		return f.saveError
+	}
+	f.issues[i.Project] = i
+	return nil
+}
+
+func (f *fakeJiraIssues) DeleteJiraIssue(subprobeID db.SubprobeID, project string) error {
+	delete(f.issues, project)
+	return nil
+}
+
+// fakeJira serves the Jira REST API calls the notifier makes, keeping the
+// labels of the issues it creates so searches find them while they're open.
+type fakeJira struct {
+	calls   []string
+	created int
+	open    map[string][]string
+	server  *httptest.Server
+}
+
+func newFakeJira(t *testing.T) *fakeJira {
+	j := &fakeJira{open: make(map[string][]string)}
+	j.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		j.calls = append(j.calls, req.Method+" "+req.URL.Path)
+		switch {
+		case req.URL.Path == "/rest/api/2/issue":
+			var issue jiraIssue
+			json.NewDecoder(req.Body).Decode(&issue)
+			j.created++
+			key := "OPS-" + strconv.Itoa(j.created)
+			j.open[key] = issue.Fields.Labels
+			json.NewEncoder(w).Encode(jiraKey{Key: key})
+		case req.URL.Path == "/rest/api/2/search":
+			var found struct {
+				Issues []jiraKey `json:"issues"`
+			}
+			jql := req.URL.Query().Get("jql")
+			for key, labels := range j.open {
+				for _, label := range labels {
+					if label != "revere" && strings.Contains(jql, `labels = "`+label+`"`) {
+						found.Issues = append(found.Issues, jiraKey{Key: key})
+					}
+				}
+			}
+			json.NewEncoder(w).Encode(found)
+		case strings.HasSuffix(req.URL.Path, "/transitions") && req.Method == "GET":
+			w.Write([]byte(`{"transitions": [{"id": "31", "name": "Done"}]}`))
+		case strings.HasSuffix(req.URL.Path, "/transitions"):
+			key := strings.Split(req.URL.Path, "/")[5]
+			delete(j.open, key)
+		case strings.HasSuffix(req.URL.Path, "/comment"):
+		default:
+			t.Errorf("Unexpected Jira call %s %s\n", req.Method, req.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return j
+}
+
+func (j *fakeJira) notifier(a *Alert, issues jiraIssueStore) jiraNotifier {
+	return jiraNotifier{
+		alert:  a,
+		client: jiraClient{baseURL: j.server.URL, username: "revere", apiToken: "token"},
+		issues: issues,
+	}
+}
+
+func (j *fakeJira) expectCalls(t *testing.T, expected ...string) {
+	if strings.Join(j.calls, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("Expected Jira calls %v, got %v\n", expected, j.calls)
+	}
+	j.calls = nil
+}
+
+var testJira = &Jira{Project: "OPS", IssueType: "Task", CloseTransition: "Done"}
+
+func TestJiraOpensIssue(t *testing.T) {
+	j := newFakeJira(t)
+	defer j.server.Close()
+
+	issues := newFakeJiraIssues()
+	if err := j.notifier(SampleAlert(), issues).send(testJira, false); err != nil {
+		t.Fatalf("Unexpected error sending alert: %v\n", err)
+	}
+	j.expectCalls(t, "GET /rest/api/2/search", "POST /rest/api/2/issue")
+	if labels := j.open["OPS-1"]; len(labels) != 2 || labels[1] != "revere-subprobe-2" {
+		t.Errorf("Expected the issue labelled with its subprobe, got %v\n", labels)
+	}
+	if i := issues.issues["OPS"]; i.IssueKey != "OPS-1" || i.SubprobeID != 2 {
+		t.Errorf("Expected the issue to be saved, got %+v\n", i)
+	}
+
+	// Later alerts comment on the saved issue.
+	if err := j.notifier(SampleAlert(), issues).send(testJira, false); err != nil {
+		t.Fatalf("Unexpected error sending alert: %v\n", err)
+	}
+	j.expectCalls(t, "POST /rest/api/2/issue/OPS-1/comment")
+}
+
+func TestJiraRetryAfterSaveFailsFindsIssue(t *testing.T) {
+	j := newFakeJira(t)
+	defer j.server.Close()
+
+	issues := newFakeJiraIssues()
+	issues.saveError = errors.New("database is down")
+	if err := j.notifier(SampleAlert(), issues).send(testJira, false); err == nil {
+		t.Fatalf("Expected an error when the issue can't be saved\n")
+	}
+	j.expectCalls(t, "GET /rest/api/2/search", "POST /rest/api/2/issue")
+
+	// The retry finds the issue it opened instead of opening another.
+	issues.saveError = nil
+	if err := j.notifier(SampleAlert(), issues).send(testJira, false); err != nil {
+		t.Fatalf("Unexpected error retrying alert: %v\n", err)
+	}
+	j.expectCalls(t, "GET /rest/api/2/search", "POST /rest/api/2/issue/OPS-1/comment")
+	if len(j.open) != 1 {
+		t.Errorf("Expected one issue, got %v\n", j.open)
+	}
+	if i := issues.issues["OPS"]; i.IssueKey != "OPS-1" {
+		t.Errorf("Expected the found issue to be saved, got %+v\n", i)
+	}
+}
+
+func TestJiraClosesUnsavedIssue(t *testing.T) {
+	j := newFakeJira(t)
+	defer j.server.Close()
+
+	issues := newFakeJiraIssues()
+	issues.saveError = errors.New("database is down")
+	j.notifier(SampleAlert(), issues).send(testJira, false)
+	j.calls = nil
+	issues.saveError = nil
+
+	a := SampleAlert()
+	a.OldState = state.Error
+	a.NewState = state.Normal
+	if err := j.notifier(a, issues).send(testJira, true); err != nil {
+		t.Fatalf("Unexpected error sending recovery: %v\n", err)
+	}
+	j.expectCalls(t, "GET /rest/api/2/search",
+		"POST /rest/api/2/issue/OPS-1/comment",
+		"GET /rest/api/2/issue/OPS-1/transitions",
+		"POST /rest/api/2/issue/OPS-1/transitions")
+	if len(j.open) != 0 || len(issues.issues) != 0 {
+		t.Errorf("Expected the issue to be closed and forgotten, got %v, %v\n", j.open, issues.issues)
+	}
+
+	// Nothing left to close.
+	if err := j.notifier(a, issues).send(testJira, true); err != nil {
+		t.Fatalf("Unexpected error sending recovery: %v\n", err)
+	}
+	j.expectCalls(t, "GET /rest/api/2/search")
+}
+
+func TestJQLString(t *testing.T) {
+	for _, c := range []struct {
+		value    string
+		expected string
+	}{
+		{"OPS", `"OPS"`},
+		{"revere-subprobe-42", `"revere-subprobe-42"`},
+		{`OPS" OR project = "SECRET`, `"OPS\" OR project = \"SECRET"`},
+		{`a\b`, `"a\\b"`},
+		{"a\nb", `"a\nb"`},
+		{"ORDER", `"ORDER"`},
+	} {
+		if actual := jqlString(c.value); actual != c.expected {
+			t.Errorf("Expected %s for %q, got %s\n", c.expected, c.value, actual)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package target
+
+import (
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/setting"
+)
+
+type jiraType struct{}
+
+func init() {
+	registerTargetType(jiraType{})
+}
+
+func (jiraType) ID() db.TargetType {
+	return 8
+}
+
+func (jiraType) New(config types.JSONText) (Target, error) {
+	return newJira(config)
+}
+
+func (jiraType) Alert(
+	Db *db.DB, a *Alert, toAlert map[db.TriggerID]Target, inactive []Target) []ErrorAndTriggerIDs {
+	// Triggers for the same project share an issue, which is only closed
+	// once all of them are closing it.
+	projects := make(map[string]*Jira)
+	closes := make(map[string]bool)
+	triggerIDs := make(map[string][]db.TriggerID)
+	var allIDs []db.TriggerID
+	for id, target := range toAlert {
+		target := target.(*Jira)
+		if _, seen := projects[target.Project]; !seen {
+			projects[target.Project] = target
+			closes[target.Project] = true
+		}
+		closes[target.Project] = closes[target.Project] && target.Closes(a)
+		triggerIDs[target.Project] = append(triggerIDs[target.Project], id)
+		allIDs = append(allIDs, id)
+	}
+
+	jiraSettings, err := loadJiraSetting(Db)
+	if err != nil {
+		return []ErrorAndTriggerIDs{{
+			Err: errors.Trace(err),
+			IDs: allIDs,
+		}}
+	}
+
+	notifier := jiraNotifier{
+		alert:  a,
+		client: newJiraClient(jiraSettings),
+		issues: Db,
+	}
+
+	var errs []ErrorAndTriggerIDs
+	for project, target := range projects {
+		if err := notifier.send(target, closes[project]); err != nil {
+			errs = append(errs, ErrorAndTriggerIDs{
+				Err: errors.Trace(err),
+				IDs: triggerIDs[project],
+			})
+		}
+	}
+	return errs
+}
+
+func loadJiraSetting(Db *db.DB) (*setting.JiraSetting, error) {
+	jiraSetting := setting.JiraSetting{}
+	dbSettings, err := Db.LoadSettingsOfType(jiraSetting.Type().Id())
+	if err != nil {
+		return nil, errors.Maskf(err, "getting settings from db")
+	}
+	if len(dbSettings) == 0 {
+		return nil, errors.New("no jira settings")
+	}
+
+	settingFromDB, err := setting.LoadFromDB(jiraSetting.Type().Id(), dbSettings[0].Setting)
+	if err != nil {
+		return nil, errors.Maskf(err, "unmarshalling db settings")
+	}
+
+	jiraSettings, found := settingFromDB.(*setting.JiraSetting)
+	if !found {
+		return nil, errors.New("extracting jira settings")
+	}
+	if jiraSettings.BaseURL == "" {
+		return nil, errors.New("no jira url in settings")
+	}
+	return jiraSettings, nil
+}
@@ -0,0 +1,105 @@
+package target
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/yext/revere/db"
+)
+
+type JiraType struct{}
+
+type JiraTarget struct {
+	JiraType
+	Project         string
+	IssueType       string
+	CloseTransition string
+}
+
+var (
+	jiraProjectRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_]+$`)
+)
+
+func init() {
+	addType(JiraType{})
+}
+
+func (JiraType) Id() db.TargetType {
+	return 8
+}
+
+func (JiraType) Name() string {
+	return "Jira"
+}
+
+func (JiraType) loadFromParams(target string) (VM, error) {
+	var j JiraTarget
+	err := json.Unmarshal([]byte(target), &j)
+	if err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func (JiraType) loadFromDb(encodedTarget string) (VM, error) {
+	var j JiraDBModel
+	err := json.Unmarshal([]byte(encodedTarget), &j)
+	if err != nil {
+		return nil, err
+	}
+
+	return JiraTarget{
+		Project:         j.Project,
+		IssueType:       j.IssueType,
+		CloseTransition: j.CloseTransition,
+	}, nil
+}
+
+func (JiraType) blank() VM {
+	return JiraTarget{
+		IssueType:       "Task",
+		CloseTransition: "Done",
+	}
+}
+
+func (JiraType) Templates() map[string]string {
+	return map[string]string{
+		"edit": "jira-edit.html",
+		"view": "jira-view.html",
+	}
+}
+
+func (JiraType) Scripts() map[string][]string {
+	return map[string][]string{}
+}
+
+func (jt JiraTarget) Serialize() (string, error) {
+	jtDB := JiraDBModel{
+		Project:         strings.TrimSpace(jt.Project),
+		IssueType:       strings.TrimSpace(jt.IssueType),
+		CloseTransition: strings.TrimSpace(jt.CloseTransition),
+	}
+
+	jtDBJSON, err := json.Marshal(jtDB)
+	return string(jtDBJSON), err
+}
+
+func (JiraTarget) Type() VMType {
+	return JiraType{}
+}
+
+func (jt JiraTarget) Validate() (errs []string) {
+	if !jiraProjectRegex.MatchString(strings.TrimSpace(jt.Project)) {
+		errs = append(errs, "Jira project key should be capital letters, digits and underscores, like OPS.")
+	}
+
+	if strings.TrimSpace(jt.IssueType) == "" {
+		errs = append(errs, "Jira issue type is required.")
+	}
+
+	if strings.TrimSpace(jt.CloseTransition) == "" {
+		errs = append(errs, "Jira close transition is required.")
+	}
+	return
+}
@@ -0,0 +1,168 @@
+package target
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yext/revere/db"
+)
+
+// limiter limits alerts to each email address and Slack channel across all of
+// Revere's monitors, so that a storm of alerts, like every monitor going
+// Unknown when Graphite is down, doesn't bury anyone.
+var limiter = newAlertLimiter()
+
+// limitedType is implemented by target types whose alerts are limited per
+// destination, such as an email address or Slack channel.
+type limitedType interface {
+	// sendSummary tells destination about the alerts it didn't get.
+	sendSummary(Db *db.DB, destination string, s LimitSummary) error
+}
+
+// LimitSummary describes the alerts a destination didn't get because it had
+// reached its limit.
+type LimitSummary struct {
+	Suppressed int
+	Since      time.Time
+	Host       string
+}
+
+// Text describes s in one line.
+func (s LimitSummary) Text() string {
+	alerts := "alerts"
+	if s.Suppressed == 1 {
+		alerts = "alert"
+	}
+	return fmt.Sprintf("%d more %s suppressed since %s, see Revere: %s",
+		s.Suppressed, alerts, s.Since.UTC().Format(timeFormat), s.Host)
+}
+
+type alertLimiter struct {
+	sync.Mutex
+
+	max    int
+	window time.Duration
+
+	destinations map[limitedDestination]*destinationLimit
+}
+
+type limitedDestination struct {
+	targetType  db.TargetType
+	destination string
+}
+
+// destinationLimit counts the alerts a destination got and didn't get in the
+// window starting with its first alert.
+type destinationLimit struct {
+	windowStart time.Time
+	sent        int
+	suppressed  int
+	host        string
+}
+
+func newAlertLimiter() *alertLimiter {
+	return &alertLimiter{
+		destinations: make(map[limitedDestination]*destinationLimit),
+	}
+}
+
+// SetAlertLimit limits each email address and Slack channel to max alerts per
+// window. A max of 0 turns limiting off.
+func SetAlertLimit(max int, window time.Duration) {
+	limiter.set(max, window)
+}
+
+func (l *alertLimiter) set(max int, window time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.max = max
+	l.window = window
+}
+
+// allow returns the destinations of the given target type that may be alerted
+// at now, counting the alert as suppressed for the others. host is where
+// people can see the alerts they missed.
+func (l *alertLimiter) allow(targetType db.TargetType, destinations []string, host string, now time.Time) []string {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.max <= 0 {
+		return destinations
+	}
+
+	allowed := make([]string, 0, len(destinations))
+	for _, destination := range destinations {
+		key := limitedDestination{targetType, destination}
+		limit := l.destinations[key]
+		// A window that has ended with alerts suppressed lasts until
+		// its summary is sent.
+		if limit == nil || (!now.Before(limit.windowStart.Add(l.window)) && limit.suppressed == 0) {
+			limit = &destinationLimit{windowStart: now}
+			l.destinations[key] = limit
+		}
+		limit.host = host
+
+		if limit.sent < l.max {
+			limit.sent++
+			allowed = append(allowed, destination)
+			continue
+		}
+
+		limit.suppressed++
+		if limit.suppressed == 1 {
+			log.WithFields(log.Fields{
+				"targetType":  targetType,
+				"destination": destination,
+			}).Warn("Alert limit reached. Suppressing alerts.")
+		}
+	}
+	return allowed
+}
+
+// summaries removes the destinations whose windows have ended by now,
+// returning summaries for those that had alerts suppressed.
+func (l *alertLimiter) summaries(now time.Time) map[limitedDestination]LimitSummary {
+	l.Lock()
+	defer l.Unlock()
+
+	summaries := make(map[limitedDestination]LimitSummary)
+	for key, limit := range l.destinations {
+		if now.Before(limit.windowStart.Add(l.window)) {
+			continue
+		}
+		delete(l.destinations, key)
+
+		if limit.suppressed > 0 {
+			summaries[key] = LimitSummary{
+				Suppressed: limit.suppressed,
+				Since:      limit.windowStart,
+				Host:       limit.host,
+			}
+		}
+	}
+	return summaries
+}
+
+// SendLimitSummaries tells each destination whose limit window has ended how
+// many alerts it didn't get. Call it periodically; the next window for a
+// destination starts with its next alert.
+func SendLimitSummaries(Db *db.DB) {
+	for key, summary := range limiter.summaries(time.Now()) {
+		err := errors.Errorf("target type %d doesn't send summaries", key.targetType)
+		if t, ok := daemonTargetTypes[key.targetType].(limitedType); ok {
+			err = t.sendSummary(Db, key.destination, summary)
+		}
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"targetType":  key.targetType,
+				"destination": key.destination,
+				"suppressed":  summary.Suppressed,
+			}).Error("Could not send summary of suppressed alerts.")
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package target
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var limiterStart = time.Date(2016, time.March, 7, 9, 30, 0, 0, time.UTC)
+
+const limiterHost = "https://revere.example.com"
+
+func newTestLimiter() *alertLimiter {
+	l := newAlertLimiter()
+	l.set(2, time.Hour)
+	return l
+}
+
+func expectAllowed(t *testing.T, got []string, expected ...string) {
+	if len(got) == 0 && len(expected) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v allowed, got %v\n", expected, got)
+	}
+}
+
+func TestLimiterCountsPerDestination(t *testing.T) {
+	l := newTestLimiter()
+	email, slack := emailType{}.ID(), slackType{}.ID()
+
+	expectAllowed(t, l.allow(email, []string{"a", "b"}, limiterHost, limiterStart), "a", "b")
+	expectAllowed(t, l.allow(email, []string{"a"}, limiterHost, limiterStart), "a")
+	expectAllowed(t, l.allow(email, []string{"a", "b"}, limiterHost, limiterStart), "b")
+	expectAllowed(t, l.allow(email, []string{"a", "b"}, limiterHost, limiterStart))
+
+	// The same destination of another target type has its own limit.
+	expectAllowed(t, l.allow(slack, []string{"a"}, limiterHost, limiterStart), "a")
+}
+
+func TestLimiterWindowRollsOver(t *testing.T) {
+	l := newTestLimiter()
+	email := emailType{}.ID()
+
+	l.allow(email, []string{"a"}, limiterHost, limiterStart)
+	l.allow(email, []string{"a"}, limiterHost, limiterStart.Add(10*time.Minute))
+	expectAllowed(t, l.allow(email, []string{"a"}, limiterHost, limiterStart.Add(59*time.Minute)))
+
+	// The window ended with an alert suppressed, so it lasts until its
+	// summary is sent.
+	expectAllowed(t, l.allow(email, []string{"a"}, limiterHost, limiterStart.Add(61*time.Minute)))
+
+	summaries := l.summaries(limiterStart.Add(62 * time.Minute))
+	expected := LimitSummary{Suppressed: 2, Since: limiterStart, Host: limiterHost}
+	if s := summaries[limitedDestination{email, "a"}]; len(summaries) != 1 || s != expected {
+		t.Errorf("Expected summary %+v, got %+v\n", expected, summaries)
+	}
+
+	// The next window starts with the next alert.
+	expectAllowed(t, l.allow(email, []string{"a"}, limiterHost, limiterStart.Add(90*time.Minute)), "a")
+	expectAllowed(t, l.allow(email, []string{"a"}, limiterHost, limiterStart.Add(91*time.Minute)), "a")
+	expectAllowed(t, l.allow(email, []string{"a"}, limiterHost, limiterStart.Add(149*time.Minute)))
+}
+
+func TestLimiterSummaries(t *testing.T) {
+	l := newTestLimiter()
+	email := emailType{}.ID()
+
+	for i := 0; i < 5; i++ {
+		l.allow(email, []string{"a", "b"}, limiterHost, limiterStart)
+	}
+	l.allow(email, []string{"c"}, limiterHost, limiterStart.Add(30*time.Minute))
+	l.allow(email, []string{"d"}, limiterHost, limiterStart.Add(30*time.Minute))
+
+	if summaries := l.summaries(limiterStart.Add(59 * time.Minute)); len(summaries) != 0 {
+		t.Errorf("Expected no summaries before windows end, got %+v\n", summaries)
+	}
+
+	summaries := l.summaries(limiterStart.Add(time.Hour))
+	expected := map[limitedDestination]LimitSummary{
+		{email, "a"}: {Suppressed: 3, Since: limiterStart, Host: limiterHost},
+		{email, "b"}: {Suppressed: 3, Since: limiterStart, Host: limiterHost},
+	}
+	if !reflect.DeepEqual(summaries, expected) {
+		t.Errorf("Expected summaries %+v, got %+v\n", expected, summaries)
+	}
+	if summaries := l.summaries(limiterStart.Add(time.Hour)); len(summaries) != 0 {
+		t.Errorf("Expected summaries to be sent once, got %+v\n", summaries)
+	}
+
+	// Destinations that weren't limited end their windows without a
+	// summary.
+	if summaries := l.summaries(limiterStart.Add(90 * time.Minute)); len(summaries) != 0 {
+		t.Errorf("Expected no summaries for destinations under the limit, got %+v\n", summaries)
+	}
+	if len(l.destinations) != 0 {
+		t.Errorf("Expected ended windows to be removed, got %+v\n", l.destinations)
+	}
+}
+
+func TestLimiterOff(t *testing.T) {
+	l := newAlertLimiter()
+	email := emailType{}.ID()
+
+	for i := 0; i < 5; i++ {
+		expectAllowed(t, l.allow(email, []string{"a"}, limiterHost, limiterStart), "a")
+	}
+	if summaries := l.summaries(limiterStart.Add(24 * time.Hour)); len(summaries) != 0 {
+		t.Errorf("Expected no summaries with limiting off, got %+v\n", summaries)
+	}
+}
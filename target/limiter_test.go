@@ -0,0 +1,24 @@
+package target_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/yext/revere/target"
+)
+
+func TestLimitSummaryText(t *testing.T) {
+	since := time.Date(2016, time.March, 7, 9, 30, 0, 0, time.UTC)
+	for _, c := range []struct {
+		suppressed int
+		expected   string
+	}{
+		{1, "1 more alert suppressed since Mon Mar 7 2016 09:30:00 UTC, see Revere: https://revere.example.com"},
+		{37, "37 more alerts suppressed since Mon Mar 7 2016 09:30:00 UTC, see Revere: https://revere.example.com"},
+	} {
+		s := LimitSummary{Suppressed: c.suppressed, Since: since, Host: "https://revere.example.com"}
+		if text := s.Text(); text != c.expected {
+			t.Errorf("Expected %q, got %q", c.expected, text)
+		}
+	}
+}
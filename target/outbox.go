@@ -0,0 +1,114 @@
+package target
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+)
+
+// outboxAlert defines the JSON serialization format for saving alerts in the
+// outbox. Details are saved as their text, which is all targets use of them.
+type outboxAlert struct {
+	MonitorID    db.MonitorID
+	MonitorName  string
+	SubprobeID   db.SubprobeID
+	SubprobeName string
+
+	Description string
+	Response    string
+
+	OldState state.State
+	NewState state.State
+
+	Recorded     time.Time
+	EnteredState time.Time
+	LastNormal   time.Time
+
+	Details string
+
+	Flapping bool
+
+	Grouped []outboxAlert `json:",omitempty"`
+
+	Host string
+}
+
+// textDetails is the probe.Details of alerts loaded from the outbox.
+type textDetails string
+
+func (d textDetails) Text() string {
+	return string(d)
+}
+
+// EncodeAlert serializes a to be saved in the outbox.
+func EncodeAlert(a *Alert) (types.JSONText, error) {
+	encoded, err := json.Marshal(newOutboxAlert(a))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return types.JSONText(encoded), nil
+}
+
+func newOutboxAlert(a *Alert) outboxAlert {
+	o := outboxAlert{
+		MonitorID:    a.MonitorID,
+		MonitorName:  a.MonitorName,
+		SubprobeID:   a.SubprobeID,
+		SubprobeName: a.SubprobeName,
+		Description:  a.Description,
+		Response:     a.Response,
+		OldState:     a.OldState,
+		NewState:     a.NewState,
+		Recorded:     a.Recorded,
+		EnteredState: a.EnteredState,
+		LastNormal:   a.LastNormal,
+		Flapping:     a.Flapping,
+		Host:         a.Host,
+	}
+	if a.Details != nil {
+		o.Details = a.Details.Text()
+	}
+	for _, g := range a.Grouped {
+		o.Grouped = append(o.Grouped, newOutboxAlert(g))
+	}
+	return o
+}
+
+// DecodeAlert deserializes an alert saved in the outbox by EncodeAlert.
+func DecodeAlert(encoded types.JSONText) (*Alert, error) {
+	var o outboxAlert
+	if err := encoded.Unmarshal(&o); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return o.alert(), nil
+}
+
+func (o outboxAlert) alert() *Alert {
+	a := &Alert{
+		MonitorID:    o.MonitorID,
+		MonitorName:  o.MonitorName,
+		SubprobeID:   o.SubprobeID,
+		SubprobeName: o.SubprobeName,
+		Description:  o.Description,
+		Response:     o.Response,
+		OldState:     o.OldState,
+		NewState:     o.NewState,
+		Recorded:     o.Recorded,
+		EnteredState: o.EnteredState,
+		LastNormal:   o.LastNormal,
+		Flapping:     o.Flapping,
+		Host:         o.Host,
+	}
+	if o.Details != "" {
+		a.Details = textDetails(o.Details)
+	}
+	for _, g := range o.Grouped {
+		a.Grouped = append(a.Grouped, g.alert())
+	}
+	return a
+}
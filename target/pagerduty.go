@@ -0,0 +1,50 @@
+package target
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/state"
+)
+
+// PagerDuty sends events to a PagerDuty service through its Events API v2
+// integration.
+type PagerDuty struct {
+	RoutingKey string
+
+	// Level is the level of the trigger the target belongs to.
+	Level state.State
+}
+
+func newPagerDuty(configJSON types.JSONText) (Target, error) {
+	var config PagerDutyDBModel
+	err := configJSON.Unmarshal(&config)
+	if err != nil {
+		return nil, errors.Maskf(err, "deserialize target config")
+	}
+
+	return &PagerDuty{RoutingKey: config.RoutingKey}, nil
+}
+
+func (PagerDuty) Type() Type {
+	return pagerDutyType{}
+}
+
+func (p *PagerDuty) setLevel(level state.State) {
+	p.Level = level
+}
+
+// Resolves returns whether a reports the end of the incident p was triggered
+// for, which happens when its trigger alerts on exit.
+func (p *PagerDuty) Resolves(a *Alert) bool {
+	return endsIncident(p.Level, a)
+}
+
+// PagerDutyDedupKey returns the key that ties together all of the events sent
+// for a subprobe, so that PagerDuty keeps one incident open for it until it is
+// resolved.
+func PagerDutyDedupKey(a *Alert) string {
+	return fmt.Sprintf("revere-monitor-%d-subprobe-%d", a.MonitorID, a.SubprobeID)
+}
@@ -0,0 +1,74 @@
+package target_test
+
+import (
+	"testing"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+	. "github.com/yext/revere/target"
+)
+
+func TestPagerDutyResolves(t *testing.T) {
+	tgt, err := NewForTrigger(&db.Trigger{
+		Level:      state.Error,
+		TargetType: PagerDutyType{}.Id(),
+		Target:     []byte(`{"RoutingKey":"abc"}`),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error making target: %v\n", err)
+	}
+	pd := tgt.(*PagerDuty)
+	if pd.RoutingKey != "abc" || pd.Level != state.Error {
+		t.Fatalf("Expected routing key abc at level Error, got %+v\n", pd)
+	}
+
+	tests := []struct {
+		old, new state.State
+		flapping bool
+		resolves bool
+	}{
+		{state.Normal, state.Error, false, false},
+		{state.Error, state.Critical, false, false},
+		{state.Critical, state.Error, false, false},
+		{state.Error, state.Warning, false, true},
+		{state.Critical, state.Normal, false, true},
+		{state.Error, state.Normal, true, false},
+	}
+	for _, tt := range tests {
+		a := &Alert{OldState: tt.old, NewState: tt.new, Flapping: tt.flapping}
+		if got := pd.Resolves(a); got != tt.resolves {
+			t.Errorf("%s->%s (flapping %t): expected resolve %t, got %t\n",
+				tt.old, tt.new, tt.flapping, tt.resolves, got)
+		}
+	}
+}
+
+func TestPagerDutyDedupKeyIsPerSubprobe(t *testing.T) {
+	a := &Alert{MonitorID: 1, SubprobeID: 2, NewState: state.Error}
+	b := &Alert{MonitorID: 1, SubprobeID: 2, NewState: state.Normal}
+	c := &Alert{MonitorID: 1, SubprobeID: 3, NewState: state.Error}
+	if PagerDutyDedupKey(a) != PagerDutyDedupKey(b) {
+		t.Errorf("Expected the same dedup key for the same subprobe\n")
+	}
+	if PagerDutyDedupKey(a) == PagerDutyDedupKey(c) {
+		t.Errorf("Expected different dedup keys for different subprobes\n")
+	}
+}
+
+func TestPagerDutyTargetValidate(t *testing.T) {
+	valid, err := LoadFromParams(PagerDutyType{}.Id(), `{"RoutingKey":"abc"}`)
+	if err != nil {
+		t.Fatalf("Unexpected error loading target: %v\n", err)
+	}
+	if errs := valid.Validate(); errs != nil {
+		t.Errorf("Unexpected errors for valid target: %v\n", errs)
+	}
+
+	blank, err := LoadFromParams(PagerDutyType{}.Id(), `{"RoutingKey":"  "}`)
+	if err != nil {
+		t.Fatalf("Unexpected error loading target: %v\n", err)
+	}
+	if errs := blank.Validate(); errs == nil {
+		t.Errorf("Expected error for blank routing key\n")
+	}
+}
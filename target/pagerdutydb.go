@@ -0,0 +1,7 @@
+package target
+
+// PagerDutyDBModel defines the JSON serialization format for saving PagerDuty
+// targets' settings in the database.
+type PagerDutyDBModel struct {
+	RoutingKey string
+}
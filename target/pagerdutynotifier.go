@@ -0,0 +1,135 @@
+package target
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/state"
+)
+
+// PagerDuty's limit on the length of event summaries.
+const pagerDutyMaxSummary = 1024
+
+var (
+	pagerDutyClient = &http.Client{Timeout: 30 * time.Second}
+
+	pagerDutySeverities = map[state.State]string{
+		state.Normal:   "info",
+		state.Warning:  "warning",
+		state.Unknown:  "error",
+		state.Error:    "error",
+		state.Critical: "critical",
+	}
+)
+
+type pagerDutyNotifier struct {
+	alert *Alert
+	url   string
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+	Links       []pagerDutyLink   `json:"links,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Timestamp     string            `json:"timestamp"`
+	Component     string            `json:"component"`
+	CustomDetails map[string]string `json:"custom_details"`
+}
+
+type pagerDutyLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+func (p pagerDutyNotifier) send(routingKey string, resolve bool) error {
+	event, err := p.formatEvent(routingKey, resolve)
+	if err != nil {
+		return errors.Maskf(err, "formatting pagerduty event")
+	}
+
+	resp, err := pagerDutyClient.Post(p.url, "application/json", event)
+	if err != nil {
+		return errors.Maskf(err, "sending pagerduty event")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf(
+			"not-OK HTTP status code: %d, when sending pagerduty event",
+			resp.StatusCode)
+	}
+	return nil
+}
+
+func (p pagerDutyNotifier) formatEvent(routingKey string, resolve bool) (io.Reader, error) {
+	a := p.alert
+	event := pagerDutyEvent{
+		RoutingKey:  routingKey,
+		EventAction: "trigger",
+		DedupKey:    PagerDutyDedupKey(a),
+	}
+
+	if resolve {
+		event.EventAction = "resolve"
+	} else {
+		summary := fmt.Sprintf("%s/%s is %s", a.MonitorName, a.SubprobeName, a.NewState)
+		if a.Flapping {
+			summary = fmt.Sprintf("%s/%s is flapping", a.MonitorName, a.SubprobeName)
+		}
+		if len(summary) > pagerDutyMaxSummary {
+			summary = summary[:pagerDutyMaxSummary]
+		}
+
+		details := map[string]string{
+			"State":          a.NewState.String(),
+			"Previous State": a.OldState.String(),
+			"Entered State":  a.EnteredState.UTC().Format(timeFormat),
+			"Last Normal":    a.LastNormal.UTC().Format(timeFormat),
+			"Description":    a.Description,
+			"Response":       a.Response,
+		}
+		if a.Details != nil {
+			details["Details"] = a.Details.Text()
+		}
+
+		event.Payload = &pagerDutyPayload{
+			Summary:       summary,
+			Source:        a.SubprobeName,
+			Severity:      pagerDutySeverities[a.NewState],
+			Timestamp:     a.Recorded.UTC().Format(time.RFC3339),
+			Component:     a.MonitorName,
+			CustomDetails: details,
+		}
+		event.Links = []pagerDutyLink{
+			{
+				Href: fmt.Sprintf("%s/monitors/%d/subprobes/%d",
+					a.Host, a.MonitorID, a.SubprobeID),
+				Text: "View in Revere",
+			},
+			{
+				Href: a.AckURL(),
+				Text: "Acknowledge in Revere",
+			},
+		}
+	}
+
+	buf, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewBuffer(buf), nil
+}
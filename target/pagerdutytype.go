@@ -0,0 +1,90 @@
+package target
+
+import (
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/setting"
+)
+
+type pagerDutyType struct{}
+
+func init() {
+	registerTargetType(pagerDutyType{})
+}
+
+func (pagerDutyType) ID() db.TargetType {
+	return 5
+}
+
+func (pagerDutyType) New(config types.JSONText) (Target, error) {
+	return newPagerDuty(config)
+}
+
+func (pagerDutyType) Alert(
+	Db *db.DB, a *Alert, toAlert map[db.TriggerID]Target, inactive []Target) []ErrorAndTriggerIDs {
+	// Triggers that share a routing key share an incident, which is only
+	// resolved once all of them are resolving.
+	triggerIDs := make(map[string][]db.TriggerID)
+	resolve := make(map[string]bool)
+	for id, target := range toAlert {
+		target := target.(*PagerDuty)
+		resolves, seen := resolve[target.RoutingKey]
+		resolve[target.RoutingKey] = target.Resolves(a) && (resolves || !seen)
+		triggerIDs[target.RoutingKey] = append(triggerIDs[target.RoutingKey], id)
+	}
+
+	pagerDutySetting, err := loadPagerDutySetting(Db)
+	if err != nil {
+		var ids []db.TriggerID
+		for id := range toAlert {
+			ids = append(ids, id)
+		}
+		return []ErrorAndTriggerIDs{{
+			Err: errors.Trace(err),
+			IDs: ids,
+		}}
+	}
+
+	notifier := pagerDutyNotifier{
+		alert: a,
+		url:   pagerDutySetting.EventsURL(),
+	}
+
+	var errs []ErrorAndTriggerIDs
+	for routingKey, ids := range triggerIDs {
+		err := notifier.send(routingKey, resolve[routingKey])
+		if err != nil {
+			errs = append(errs, ErrorAndTriggerIDs{
+				Err: errors.Trace(err),
+				IDs: ids,
+			})
+		}
+	}
+	return errs
+}
+
+// loadPagerDutySetting returns the saved PagerDuty setting, or a blank one
+// sending to PagerDuty itself if there is none.
+func loadPagerDutySetting(Db *db.DB) (*setting.PagerDutySetting, error) {
+	pagerDutySetting := setting.PagerDutySetting{}
+	dbSettings, err := Db.LoadSettingsOfType(pagerDutySetting.Type().Id())
+	if err != nil {
+		return nil, errors.Maskf(err, "getting settings from db")
+	}
+	if len(dbSettings) == 0 {
+		return &pagerDutySetting, nil
+	}
+
+	settingFromDB, err := setting.LoadFromDB(pagerDutySetting.Type().Id(), dbSettings[0].Setting)
+	if err != nil {
+		return nil, errors.Maskf(err, "unmarshalling db settings")
+	}
+
+	pagerDutySettings, found := settingFromDB.(*setting.PagerDutySetting)
+	if !found {
+		return nil, errors.New("extracting pagerduty settings")
+	}
+	return pagerDutySettings, nil
+}
@@ -0,0 +1,83 @@
+package target
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/yext/revere/db"
+)
+
+type PagerDutyType struct{}
+
+type PagerDutyTarget struct {
+	PagerDutyType
+	RoutingKey string
+}
+
+func init() {
+	addType(PagerDutyType{})
+}
+
+func (PagerDutyType) Id() db.TargetType {
+	return 5
+}
+
+func (PagerDutyType) Name() string {
+	return "PagerDuty"
+}
+
+func (PagerDutyType) loadFromParams(target string) (VM, error) {
+	var p PagerDutyTarget
+	err := json.Unmarshal([]byte(target), &p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (PagerDutyType) loadFromDb(encodedTarget string) (VM, error) {
+	var p PagerDutyDBModel
+	err := json.Unmarshal([]byte(encodedTarget), &p)
+	if err != nil {
+		return nil, err
+	}
+
+	return PagerDutyTarget{
+		RoutingKey: p.RoutingKey,
+	}, nil
+}
+
+func (PagerDutyType) blank() VM {
+	return PagerDutyTarget{}
+}
+
+func (PagerDutyType) Templates() map[string]string {
+	return map[string]string{
+		"edit": "pagerduty-edit.html",
+		"view": "pagerduty-view.html",
+	}
+}
+
+func (PagerDutyType) Scripts() map[string][]string {
+	return map[string][]string{}
+}
+
+func (pt PagerDutyTarget) Serialize() (string, error) {
+	ptDB := PagerDutyDBModel{
+		RoutingKey: strings.TrimSpace(pt.RoutingKey),
+	}
+
+	ptDBJSON, err := json.Marshal(ptDB)
+	return string(ptDBJSON), err
+}
+
+func (PagerDutyTarget) Type() VMType {
+	return PagerDutyType{}
+}
+
+func (pt PagerDutyTarget) Validate() (errs []string) {
+	if strings.TrimSpace(pt.RoutingKey) == "" {
+		errs = append(errs, "Routing key is required.")
+	}
+	return
+}
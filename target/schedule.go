@@ -0,0 +1,89 @@
+package target
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+)
+
+// Schedule implements a target that emails whoever is on call for an on-call
+// schedule at the time of the alert.
+type Schedule struct {
+	ScheduleID db.ScheduleID
+}
+
+func newSchedule(configJSON types.JSONText) (Target, error) {
+	var config ScheduleDBModel
+	err := configJSON.Unmarshal(&config)
+	if err != nil {
+		return nil, errors.Maskf(err, "deserialize target config")
+	}
+
+	return &Schedule{ScheduleID: db.ScheduleID(config.ScheduleID)}, nil
+}
+
+func (*Schedule) Type() Type {
+	return scheduleType{}
+}
+
+// onCall loads the schedule and returns who is on call for it at t.
+func (s *Schedule) onCall(Db *db.DB, t time.Time) ([]string, error) {
+	layers, err := Db.LoadScheduleLayers(s.ScheduleID)
+	if err != nil {
+		return nil, errors.Maskf(err, "load layers of schedule %d", s.ScheduleID)
+	}
+
+	overrides, err := Db.LoadScheduleOverrides(s.ScheduleID, t)
+	if err != nil {
+		return nil, errors.Maskf(err, "load overrides of schedule %d", s.ScheduleID)
+	}
+
+	return OnCall(layers, overrides, t)
+}
+
+// OnCall returns the email addresses of who is on call at t for a schedule
+// with the given layers and overrides. Overrides in effect at t replace
+// whoever the layers put on call; otherwise each layer that has started puts
+// its current member on call.
+func OnCall(layers []*db.ScheduleLayer, overrides []*db.ScheduleOverride, t time.Time) ([]string, error) {
+	onCall := newEmailListBuilder()
+	for _, o := range overrides {
+		if !t.Before(o.Start) && t.Before(o.End) {
+			onCall.add(o.Email)
+		}
+	}
+	if len(onCall) > 0 {
+		return onCall.build(), nil
+	}
+
+	for _, l := range layers {
+		member, err := layerOnCall(l, t)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if member != "" {
+			onCall.add(member)
+		}
+	}
+	return onCall.build(), nil
+}
+
+// layerOnCall returns the member of l on call at t, or "" if l has not started
+// or has no members.
+func layerOnCall(l *db.ScheduleLayer, t time.Time) (string, error) {
+	var members []string
+	if err := l.Members.Unmarshal(&members); err != nil {
+		return "", errors.Maskf(err, "deserialize members of schedule layer %d", l.LayerID)
+	}
+
+	rotation := time.Duration(l.RotationMilli) * time.Millisecond
+	if len(members) == 0 || rotation <= 0 || t.Before(l.Start) {
+		return "", nil
+	}
+
+	handoffs := int64(t.Sub(l.Start) / rotation)
+	return members[handoffs%int64(len(members))], nil
+}
@@ -0,0 +1,67 @@
+package target_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+
+	"github.com/yext/revere/db"
+	. "github.com/yext/revere/target"
+)
+
+var scheduleStart = time.Date(2016, time.March, 7, 9, 0, 0, 0, time.UTC)
+
+func weeklyLayer(members string) *db.ScheduleLayer {
+	return &db.ScheduleLayer{
+		Start:         scheduleStart,
+		RotationMilli: int64(7 * 24 * time.Hour / time.Millisecond),
+		Members:       types.JSONText(members),
+	}
+}
+
+func TestOnCall(t *testing.T) {
+	primary := weeklyLayer(`["a@ex.com", "b@ex.com", "c@ex.com"]`)
+	secondary := weeklyLayer(`["d@ex.com"]`)
+	override := &db.ScheduleOverride{
+		Email: "e@ex.com",
+		Start: scheduleStart.Add(24 * time.Hour),
+		End:   scheduleStart.Add(48 * time.Hour),
+	}
+	week := 7 * 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		expected []string
+	}{
+		{"before start", scheduleStart.Add(-time.Minute), []string{}},
+		{"at start", scheduleStart, []string{"a@ex.com", "d@ex.com"}},
+		{"just before handoff", scheduleStart.Add(week - time.Minute), []string{"a@ex.com", "d@ex.com"}},
+		{"at handoff", scheduleStart.Add(week), []string{"b@ex.com", "d@ex.com"}},
+		{"wrapped around", scheduleStart.Add(3*week + time.Hour), []string{"a@ex.com", "d@ex.com"}},
+		{"during override", scheduleStart.Add(36 * time.Hour), []string{"e@ex.com"}},
+		{"at override end", override.End, []string{"a@ex.com", "d@ex.com"}},
+	}
+
+	layers := []*db.ScheduleLayer{primary, secondary}
+	overrides := []*db.ScheduleOverride{override}
+	for _, test := range tests {
+		onCall, err := OnCall(layers, overrides, test.t)
+		if err != nil {
+			t.Errorf("Unexpected error %s: %s\n", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(onCall, test.expected) {
+			t.Errorf("Expected %v on call %s, got %v\n", test.expected, test.name, onCall)
+		}
+	}
+}
+
+func TestOnCallInvalidMembers(t *testing.T) {
+	layers := []*db.ScheduleLayer{weeklyLayer(`{`)}
+	if _, err := OnCall(layers, nil, scheduleStart); err == nil {
+		t.Error("Expected error for invalid layer members")
+	}
+}
@@ -0,0 +1,7 @@
+package target
+
+// ScheduleDBModel defines the JSON serialization format for saving schedule
+// targets' settings in the database.
+type ScheduleDBModel struct {
+	ScheduleID int64
+}
@@ -0,0 +1,69 @@
+package target
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+)
+
+type scheduleType struct{}
+
+func init() {
+	registerTargetType(scheduleType{})
+}
+
+func (scheduleType) ID() db.TargetType {
+	return 4
+}
+
+func (scheduleType) New(config types.JSONText) (Target, error) {
+	return newSchedule(config)
+}
+
+func (scheduleType) groupsAlerts() {}
+
+// Alert resolves who is on call for each schedule now and emails them. Targets
+// whose schedule can't be resolved or has nobody on call fail on their own.
+func (scheduleType) Alert(
+	Db *db.DB, a *Alert, toAlert map[db.TriggerID]Target, inactive []Target) []ErrorAndTriggerIDs {
+	var errs []ErrorAndTriggerIDs
+	var triggerIDs []db.TriggerID
+
+	now := time.Now()
+	toBuilder := newEmailListBuilder()
+	for id, target := range toAlert {
+		target := target.(*Schedule)
+		onCall, err := target.onCall(Db, now)
+		if err == nil && len(onCall) == 0 {
+			err = errors.Errorf("nobody is on call for schedule %d", target.ScheduleID)
+		}
+		if err != nil {
+			errs = append(errs, ErrorAndTriggerIDs{
+				Err: errors.Trace(err),
+				IDs: []db.TriggerID{id},
+			})
+			continue
+		}
+
+		toBuilder.addSlice(onCall)
+		triggerIDs = append(triggerIDs, id)
+	}
+	to := toBuilder.build()
+
+	if len(to) == 0 {
+		return errs
+	}
+
+	err := sendEmail(Db, a, to, to)
+	if err != nil {
+		errs = append(errs, ErrorAndTriggerIDs{
+			Err: errors.Trace(err),
+			IDs: triggerIDs,
+		})
+	}
+
+	return errs
+}
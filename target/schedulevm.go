@@ -0,0 +1,82 @@
+package target
+
+import (
+	"encoding/json"
+
+	"github.com/yext/revere/db"
+)
+
+type ScheduleType struct{}
+
+type ScheduleTarget struct {
+	ScheduleType
+	ScheduleID int64
+}
+
+func init() {
+	addType(ScheduleType{})
+}
+
+func (ScheduleType) Id() db.TargetType {
+	return 4
+}
+
+func (ScheduleType) Name() string {
+	return "On-call Schedule"
+}
+
+func (ScheduleType) loadFromParams(target string) (VM, error) {
+	var st ScheduleTarget
+	err := json.Unmarshal([]byte(target), &st)
+	if err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+func (ScheduleType) loadFromDb(encodedTarget string) (VM, error) {
+	var st ScheduleDBModel
+	err := json.Unmarshal([]byte(encodedTarget), &st)
+	if err != nil {
+		return nil, err
+	}
+
+	return ScheduleTarget{
+		ScheduleID: st.ScheduleID,
+	}, nil
+}
+
+func (ScheduleType) blank() VM {
+	return ScheduleTarget{}
+}
+
+func (ScheduleType) Templates() map[string]string {
+	return map[string]string{
+		"edit": "schedule-edit.html",
+		"view": "schedule-view.html",
+	}
+}
+
+func (ScheduleType) Scripts() map[string][]string {
+	return map[string][]string{}
+}
+
+func (st ScheduleTarget) Serialize() (string, error) {
+	stDB := ScheduleDBModel{
+		ScheduleID: st.ScheduleID,
+	}
+
+	stDBJSON, err := json.Marshal(stDB)
+	return string(stDBJSON), err
+}
+
+func (ScheduleTarget) Type() VMType {
+	return ScheduleType{}
+}
+
+func (st ScheduleTarget) Validate() (errs []string) {
+	if st.ScheduleID <= 0 {
+		errs = append(errs, "On-call schedule is required.")
+	}
+	return
+}
@@ -0,0 +1,186 @@
+package target
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+)
+
+var (
+	// slackAPIURL is where Slack Web API methods are called.
+	slackAPIURL = "https://slack.com/api"
+
+	slackAPIClient = &http.Client{Timeout: 30 * time.Second}
+)
+
+// slackAPINotifier posts alerts with Slack's Web API, keeping each subprobe
+// incident in one thread per channel. The first alert of an incident starts
+// the thread, and later alerts, including the recovery, are replies to it.
+// The message that started the thread is updated to show the incident's
+// current state.
+type slackAPINotifier struct {
+	alert   *Alert
+	name    string
+	token   string
+	threads slackThreadStore
+}
+
+// slackThreadStore keeps the threads of open incidents. *db.DB is one.
+type slackThreadStore interface {
+	LoadSlackThread(subprobeID db.SubprobeID, channel string) (*db.SlackThread, error)
+	SaveSlackThread(t db.SlackThread) error
+	DeleteSlackThread(subprobeID db.SubprobeID, channel string) error
+}
+
+type slackMessage struct {
+	Channel     string       `json:"channel"`
+	TS          string       `json:"ts,omitempty"`
+	ThreadTS    string       `json:"thread_ts,omitempty"`
+	Username    string       `json:"username,omitempty"`
+	Text        string       `json:"text,omitempty"`
+	Attachments []attachment `json:"attachments"`
+}
+
+type slackResponse struct {
+	OK      bool   `json:"ok"`
+	Error   string `json:"error"`
+	Channel string `json:"channel"`
+	TS      string `json:"ts"`
+}
+
+func (s slackAPINotifier) sendAll(channels map[string]struct{}) error {
+	var (
+		failedChannelNames []string
+		err                error
+	)
+
+	for channel, _ := range channels {
+		if sendErr := s.send(channel); sendErr != nil {
+			err = sendErr
+			failedChannelNames = append(failedChannelNames, channel)
+		}
+	}
+
+	if len(failedChannelNames) > 0 {
+		return errors.Maskf(err, "sending slack notifications to %v", failedChannelNames)
+	}
+	return nil
+}
+
+func (s slackAPINotifier) send(channel string) error {
+	a := s.alert
+
+	// Grouped alerts span incidents on several subprobes, so they aren't part
+	// of any one thread.
+	if len(a.Grouped) > 0 {
+		_, err := s.call("chat.postMessage", s.message(channel))
+		return errors.Maskf(err, "posting slack message to %s", channel)
+	}
+
+	thread, err := s.threads.LoadSlackThread(a.SubprobeID, channel)
+	if err != nil {
+		return errors.Maskf(err, "loading slack thread for %s", channel)
+	}
+
+	recovered := a.NewState == state.Normal && !a.Flapping
+
+	// Subprobes stay away from Normal for the whole of an incident, so the
+	// time they were last Normal tells incidents apart. A recovery has been
+	// Normal since it was recorded, so it goes to whichever incident is open.
+	if thread != nil && !recovered && !thread.LastNormal.Equal(a.LastNormal) {
+		thread = nil
+	}
+
+	if thread == nil {
+		resp, err := s.call("chat.postMessage", s.message(channel))
+		if err != nil {
+			return errors.Maskf(err, "posting slack message to %s", channel)
+		}
+		if recovered {
+			return nil
+		}
+
+		err = s.threads.SaveSlackThread(db.SlackThread{
+			SubprobeID: a.SubprobeID,
+			Channel:    channel,
+			ChannelID:  resp.Channel,
+			TS:         resp.TS,
+			LastNormal: a.LastNormal,
+		})
+		return errors.Maskf(err, "saving slack thread for %s", channel)
+	}
+
+	reply := s.message(thread.ChannelID)
+	reply.ThreadTS = thread.TS
+	if _, err := s.call("chat.postMessage", reply); err != nil {
+		return errors.Maskf(err, "posting slack reply to %s", channel)
+	}
+
+	if a.OldState != a.NewState {
+		parent := s.message(thread.ChannelID)
+		parent.TS = thread.TS
+		if recovered {
+			parent.Text = fmt.Sprintf("Resolved at %s", a.Recorded.UTC().Format(timeFormat))
+		}
+		if _, err := s.call("chat.update", parent); err != nil {
+			return errors.Maskf(err, "updating slack message in %s", channel)
+		}
+	}
+
+	if recovered {
+		err = s.threads.DeleteSlackThread(a.SubprobeID, channel)
+		return errors.Maskf(err, "deleting slack thread for %s", channel)
+	}
+	return nil
+}
+
+func (s slackAPINotifier) message(channel string) slackMessage {
+	return slackMessage{
+		Channel:     channel,
+		Username:    s.name,
+		Attachments: []attachment{slackAttachment(s.alert)},
+	}
+}
+
+// call calls a Slack Web API method, returning an error unless Slack reports
+// success.
+func (s slackAPINotifier) call(method string, message slackMessage) (*slackResponse, error) {
+	buf, err := json.Marshal(message)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	req, err := http.NewRequest("POST", slackAPIURL+"/"+method, bytes.NewReader(buf))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+s.token)
+
+	resp, err := slackAPIClient.Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("not-OK HTTP status code: %d, when calling %s",
+			resp.StatusCode, method)
+	}
+
+	var result slackResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, errors.Maskf(err, "decoding %s response", method)
+	}
+	if !result.OK {
+		return nil, errors.Errorf("%s failed: %s", method, result.Error)
+	}
+	return &result, nil
+}
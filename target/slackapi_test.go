@@ -0,0 +1,211 @@
+package target
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+)
+
+type fakeSlackThreads map[string]db.SlackThread
+
+func (f fakeSlackThreads) LoadSlackThread(subprobeID db.SubprobeID, channel string) (*db.SlackThread, error) {
+	if t, ok := f[channel]; ok && t.SubprobeID == subprobeID {
+		return &t, nil
+	}
+	return nil, nil
+}
+
+func (f fakeSlackThreads) SaveSlackThread(t db.SlackThread) error {
+	f[t.Channel] = t
+	return nil
+}
+
+func (f fakeSlackThreads) DeleteSlackThread(subprobeID db.SubprobeID, channel string) error {
+	delete(f, channel)
+	return nil
+}
+
+type slackCall struct {
+	method  string
+	message slackMessage
+}
+
+// fakeSlackAPI serves Slack Web API calls, answering each with a new message
+// timestamp, or with failure as the error if failure is set.
+func fakeSlackAPI(t *testing.T, failure string) (calls *[]slackCall, done func()) {
+	calls = new([]slackCall)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if auth := req.Header.Get("Authorization"); auth != "Bearer xoxb-token" {
+			t.Errorf("Expected bot token authorization, got %q\n", auth)
+		}
+
+		var call slackCall
+		call.method = strings.TrimPrefix(req.URL.Path, "/")
+		json.NewDecoder(req.Body).Decode(&call.message)
+		*calls = append(*calls, call)
+
+		resp := slackResponse{OK: failure == "", Error: failure}
+		if resp.OK {
+			resp.Channel = "C123"
+			resp.TS = "1000." + strconv.Itoa(len(*calls))
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+
+	oldURL := slackAPIURL
+	slackAPIURL = server.URL
+	return calls, func() {
+		slackAPIURL = oldURL
+		server.Close()
+	}
+}
+
+func newTestSlackAPINotifier(a *Alert, threads fakeSlackThreads) slackAPINotifier {
+	return slackAPINotifier{
+		alert:   a,
+		name:    "revere",
+		token:   "xoxb-token",
+		threads: threads,
+	}
+}
+
+func TestSlackAPIStartsThread(t *testing.T) {
+	calls, done := fakeSlackAPI(t, "")
+	defer done()
+
+	threads := make(fakeSlackThreads)
+	a := SampleAlert()
+	if err := newTestSlackAPINotifier(a, threads).send("#ops"); err != nil {
+		t.Fatalf("Unexpected error sending alert: %v\n", err)
+	}
+
+	if len(*calls) != 1 || (*calls)[0].method != "chat.postMessage" {
+		t.Fatalf("Expected one chat.postMessage call, got %v\n", *calls)
+	}
+	if m := (*calls)[0].message; m.Channel != "#ops" || m.ThreadTS != "" {
+		t.Errorf("Expected a new message in #ops, got %+v\n", m)
+	}
+
+	thread, ok := threads["#ops"]
+	if !ok {
+		t.Fatalf("Expected the thread to be saved\n")
+	}
+	if thread.SubprobeID != a.SubprobeID || thread.ChannelID != "C123" ||
+		thread.TS != "1000.1" || !thread.LastNormal.Equal(a.LastNormal) {
+		t.Errorf("Unexpected saved thread: %+v\n", thread)
+	}
+}
+
+func TestSlackAPIRepliesInThread(t *testing.T) {
+	calls, done := fakeSlackAPI(t, "")
+	defer done()
+
+	a := SampleAlert()
+	a.OldState = a.NewState
+	threads := fakeSlackThreads{"#ops": {
+		SubprobeID: a.SubprobeID,
+		Channel:    "#ops",
+		ChannelID:  "C123",
+		TS:         "999.1",
+		LastNormal: a.LastNormal,
+	}}
+	if err := newTestSlackAPINotifier(a, threads).send("#ops"); err != nil {
+		t.Fatalf("Unexpected error sending alert: %v\n", err)
+	}
+
+	// A repeat doesn't change the state, so the thread's first message is
+	// left alone.
+	if len(*calls) != 1 || (*calls)[0].method != "chat.postMessage" {
+		t.Fatalf("Expected one chat.postMessage call, got %v\n", *calls)
+	}
+	if m := (*calls)[0].message; m.Channel != "C123" || m.ThreadTS != "999.1" {
+		t.Errorf("Expected a reply in the thread, got %+v\n", m)
+	}
+	if _, ok := threads["#ops"]; !ok {
+		t.Errorf("Expected the thread to stay open\n")
+	}
+}
+
+func TestSlackAPINewIncidentStartsNewThread(t *testing.T) {
+	calls, done := fakeSlackAPI(t, "")
+	defer done()
+
+	a := SampleAlert()
+	threads := fakeSlackThreads{"#ops": {
+		SubprobeID: a.SubprobeID,
+		Channel:    "#ops",
+		ChannelID:  "C123",
+		TS:         "999.1",
+		LastNormal: a.LastNormal.Add(-time.Hour),
+	}}
+	if err := newTestSlackAPINotifier(a, threads).send("#ops"); err != nil {
+		t.Fatalf("Unexpected error sending alert: %v\n", err)
+	}
+
+	if len(*calls) != 1 || (*calls)[0].message.ThreadTS != "" {
+		t.Fatalf("Expected one new message, got %v\n", *calls)
+	}
+	if threads["#ops"].TS != "1000.1" {
+		t.Errorf("Expected the new thread to replace the old one, got %+v\n", threads["#ops"])
+	}
+}
+
+func TestSlackAPIRecoveryResolvesThread(t *testing.T) {
+	calls, done := fakeSlackAPI(t, "")
+	defer done()
+
+	a := SampleAlert()
+	a.OldState = state.Error
+	a.NewState = state.Normal
+	threads := fakeSlackThreads{"#ops": {
+		SubprobeID: a.SubprobeID,
+		Channel:    "#ops",
+		ChannelID:  "C123",
+		TS:         "999.1",
+		LastNormal: a.LastNormal.Add(-time.Hour),
+	}}
+	if err := newTestSlackAPINotifier(a, threads).send("#ops"); err != nil {
+		t.Fatalf("Unexpected error sending alert: %v\n", err)
+	}
+
+	if len(*calls) != 2 {
+		t.Fatalf("Expected a reply and an update, got %v\n", *calls)
+	}
+	if c := (*calls)[0]; c.method != "chat.postMessage" || c.message.ThreadTS != "999.1" {
+		t.Errorf("Expected a reply in the thread, got %+v\n", c)
+	}
+	c := (*calls)[1]
+	if c.method != "chat.update" || c.message.TS != "999.1" || c.message.Channel != "C123" {
+		t.Errorf("Expected the thread's first message to be updated, got %+v\n", c)
+	}
+	if !strings.HasPrefix(c.message.Text, "Resolved at ") {
+		t.Errorf("Expected the update to mark the incident resolved, got %q\n", c.message.Text)
+	}
+	if _, ok := threads["#ops"]; ok {
+		t.Errorf("Expected the thread to be deleted\n")
+	}
+}
+
+func TestSlackAPIError(t *testing.T) {
+	_, done := fakeSlackAPI(t, "channel_not_found")
+	defer done()
+
+	threads := make(fakeSlackThreads)
+	err := newTestSlackAPINotifier(SampleAlert(), threads).send("#missing")
+	if err == nil {
+		t.Fatalf("Expected an error when Slack reports failure\n")
+	}
+	if !strings.Contains(err.Error(), "channel_not_found") {
+		t.Errorf("Expected the error to include Slack's, got %v\n", err)
+	}
+	if len(threads) != 0 {
+		t.Errorf("Expected no thread to be saved, got %v\n", threads)
+	}
+}
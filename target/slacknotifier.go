@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/yext/revere/state"
@@ -69,7 +70,12 @@ func (s slackNotifier) sendAll(channels map[string]struct{}) error {
 }
 
 func (s slackNotifier) send(channel string) error {
-	message, err := s.formatMessage(channel)
+	return s.post(channel, slackAttachment(s.alert))
+}
+
+// post posts an attachment to channel with the webhook.
+func (s slackNotifier) post(channel string, a attachment) error {
+	message, err := s.formatMessage(channel, a)
 	if err != nil {
 		return errors.Maskf(err, "formatting slack message")
 	}
@@ -87,40 +93,94 @@ func (s slackNotifier) send(channel string) error {
 	return nil
 }
 
-func (s slackNotifier) formatMessage(channel string) (io.Reader, error) {
+func (s slackNotifier) formatMessage(channel string, a attachment) (io.Reader, error) {
+	payload := payload{
+		Username:    s.name,
+		Channel:     channel,
+		Attachments: []attachment{a},
+	}
+
+	buf, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewBuffer(buf), nil
+}
+
+// slackAttachment formats a as a Slack message attachment.
+func slackAttachment(a *Alert) attachment {
+	if len(a.Grouped) > 0 {
+		return groupedSlackAttachment(a)
+	}
+
+	text := alertStateText(a)
+	if a.NewState != state.Normal {
+		text = fmt.Sprintf("%s\n<%s|Acknowledge>", text, a.AckURL())
+	}
+
+	return attachment{
+		Title: fmt.Sprintf("%s/%s", a.MonitorName, a.SubprobeName),
+		TitleLink: fmt.Sprintf("%s/monitors/%d/subprobes/%d",
+			a.Host, a.MonitorID, a.SubprobeID),
+		Fallback: fmt.Sprintf("%s/%s entered state: %s",
+			a.MonitorName, a.SubprobeName, a.NewState),
+		Color:     stateColors[a.NewState],
+		Text:      text,
+		Timestamp: a.Recorded.Unix(),
+	}
+}
+
+// groupedSlackAttachment formats grouped alert a as one Slack message
+// attachment with a line for each subprobe. It takes the color of the worst
+// new state.
+func groupedSlackAttachment(a *Alert) attachment {
+	var lines []string
+	worst := state.Normal
+	for _, g := range a.Grouped {
+		change := g.NewState.String()
+		if g.OldState != g.NewState {
+			change = fmt.Sprintf("%s->%s", g.OldState, g.NewState)
+		}
+		line := fmt.Sprintf("<%s/monitors/%d/subprobes/%d|%s>: %s",
+			g.Host, g.MonitorID, g.SubprobeID, g.SubprobeName, change)
+		if g.NewState != state.Normal {
+			line = fmt.Sprintf("%s (<%s|Acknowledge>)", line, g.AckURL())
+		}
+		lines = append(lines, line)
+		if g.NewState > worst {
+			worst = g.NewState
+		}
+	}
+
+	return attachment{
+		Title:     a.GroupTitle(),
+		TitleLink: fmt.Sprintf("%s/monitors/%d", a.Host, a.MonitorID),
+		Fallback: fmt.Sprintf("%d subprobes of %s changed state",
+			len(a.Grouped), a.MonitorName),
+		Color:     stateColors[worst],
+		Text:      strings.Join(lines, "\n"),
+		Timestamp: a.Recorded.Unix(),
+	}
+}
+
+// alertStateText describes the state a's subprobe is in, one fact per line,
+// for chat messages.
+func alertStateText(a *Alert) string {
 	var text string
-	if s.alert.OldState != s.alert.NewState {
-		text = fmt.Sprintf("State change: %s->%s", s.alert.OldState, s.alert.NewState)
+	if a.OldState != a.NewState {
+		text = fmt.Sprintf("State change: %s->%s", a.OldState, a.NewState)
 	} else {
 		text = fmt.Sprintf("Has been %s since: %s",
-			s.alert.NewState, s.alert.EnteredState.UTC().Format(timeFormat))
+			a.NewState, a.EnteredState.UTC().Format(timeFormat))
 	}
 
-	if s.alert.NewState != state.Normal {
+	if a.NewState != state.Normal {
 		text = fmt.Sprintf("%s\nWas last Normal at: %s",
-			text, s.alert.LastNormal.UTC().Format(timeFormat))
-	}
-
-	payload := payload{
-		Username: s.name,
-		Channel:  channel,
-		Attachments: []attachment{
-			{
-				Title: fmt.Sprintf("%s/%s", s.alert.MonitorName, s.alert.SubprobeName),
-				TitleLink: fmt.Sprintf("%s/monitors/%d/subprobes/%d",
-					s.alert.Host, s.alert.MonitorID, s.alert.SubprobeID),
-				Fallback: fmt.Sprintf("%s/%s entered state: %s",
-					s.alert.MonitorName, s.alert.SubprobeName, s.alert.NewState),
-				Color:     stateColors[s.alert.NewState],
-				Text:      text,
-				Timestamp: s.alert.Recorded.Unix(),
-			},
-		},
+			text, a.LastNormal.UTC().Format(timeFormat))
 	}
 
-	buf, err := json.Marshal(payload)
-	if err != nil {
-		return nil, err
+	if a.Flapping {
+		text = fmt.Sprintf("Flapping: state change alerts are suppressed until it stabilizes.\n%s", text)
 	}
-	return bytes.NewBuffer(buf), nil
+	return text
 }
@@ -1,11 +1,14 @@
 package target
 
 import (
+	"time"
+
 	"github.com/jmoiron/sqlx/types"
 	"github.com/juju/errors"
 
 	"github.com/yext/revere/db"
 	"github.com/yext/revere/setting"
+	"github.com/yext/revere/state"
 )
 
 type slackType struct{}
@@ -22,6 +25,8 @@ func (slackType) New(config types.JSONText) (Target, error) {
 	return newSlack(config)
 }
 
+func (slackType) groupsAlerts() {}
+
 func (slackType) Alert(
 	Db *db.DB, a *Alert, toAlert map[db.TriggerID]Target, inactive []Target) []ErrorAndTriggerIDs {
 	triggerIDs := make([]db.TriggerID, 0, len(toAlert))
@@ -35,43 +40,116 @@ func (slackType) Alert(
 		channels[target.Channel] = struct{}{}
 	}
 
-	slackSetting := setting.SlackSetting{}
-	dbSettings, err := Db.LoadSettingsOfType(slackSetting.Type().Id())
-	if err != nil || len(dbSettings) == 0 {
-		return []ErrorAndTriggerIDs{{
-			Err: errors.Maskf(err, "getting settings from db"),
-			IDs: triggerIDs,
-		}}
+	channels = limitSlackChannels(channels, a.Host)
+	if len(channels) == 0 {
+		return nil
 	}
 
-	settingsFromDB, err := setting.LoadFromDB(slackSetting.Type().Id(), dbSettings[0].Setting)
+	slackSettings, err := loadSlackSetting(Db)
 	if err != nil {
 		return []ErrorAndTriggerIDs{{
-			Err: errors.Maskf(err, "unmarshalling db settings"),
+			Err: errors.Trace(err),
 			IDs: triggerIDs,
 		}}
 	}
 
-	slackSettings, found := settingsFromDB.(*setting.SlackSetting)
-	if !found {
+	// Settings saved before alerts were threaded may only have a webhook.
+	if slackSettings.APIToken != "" {
+		notifier := slackAPINotifier{
+			alert:   a,
+			name:    slackSettings.BotName,
+			token:   slackSettings.APIToken,
+			threads: Db,
+		}
+		err = notifier.sendAll(channels)
+	} else {
+		notifier := slackNotifier{
+			alert: a,
+			name:  slackSettings.BotName,
+			url:   slackSettings.WebhookURL,
+		}
+		err = notifier.sendAll(channels)
+	}
+	if err != nil {
 		return []ErrorAndTriggerIDs{{
-			Err: errors.Maskf(err, "extracting slack settings"),
+			Err: errors.Trace(err),
 			IDs: triggerIDs,
 		}}
 	}
 
+	return nil
+}
+
+// limitSlackChannels returns the channels that haven't had too many alerts
+// lately.
+func limitSlackChannels(channels map[string]struct{}, host string) map[string]struct{} {
+	names := make([]string, 0, len(channels))
+	for channel := range channels {
+		names = append(names, channel)
+	}
+
+	allowed := make(map[string]struct{})
+	for _, channel := range limiter.allow(slackType{}.ID(), names, host, time.Now()) {
+		allowed[channel] = struct{}{}
+	}
+	return allowed
+}
+
+// sendSummary posts s to channel the way alerts are posted.
+func (slackType) sendSummary(Db *db.DB, channel string, s LimitSummary) error {
+	slackSettings, err := loadSlackSetting(Db)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	summary := attachment{
+		Title:     "Alerts suppressed",
+		TitleLink: s.Host,
+		Fallback:  s.Text(),
+		Color:     stateColors[state.Unknown],
+		Text:      s.Text(),
+		Timestamp: time.Now().Unix(),
+	}
+
+	if slackSettings.APIToken != "" {
+		notifier := slackAPINotifier{
+			name:    slackSettings.BotName,
+			token:   slackSettings.APIToken,
+			threads: Db,
+		}
+		_, err = notifier.call("chat.postMessage", slackMessage{
+			Channel:     channel,
+			Username:    slackSettings.BotName,
+			Attachments: []attachment{summary},
+		})
+		return errors.Trace(err)
+	}
+
 	notifier := slackNotifier{
-		alert: a,
-		name:  slackSettings.BotName,
-		url:   slackSettings.WebhookURL,
+		name: slackSettings.BotName,
+		url:  slackSettings.WebhookURL,
 	}
-	err = notifier.sendAll(channels)
+	return errors.Trace(notifier.post(channel, summary))
+}
+
+func loadSlackSetting(Db *db.DB) (*setting.SlackSetting, error) {
+	slackSetting := setting.SlackSetting{}
+	dbSettings, err := Db.LoadSettingsOfType(slackSetting.Type().Id())
 	if err != nil {
-		return []ErrorAndTriggerIDs{{
-			Err: errors.Trace(err),
-			IDs: triggerIDs,
-		}}
+		return nil, errors.Maskf(err, "getting settings from db")
+	}
+	if len(dbSettings) == 0 {
+		return nil, errors.New("no slack settings")
 	}
 
-	return nil
+	settingsFromDB, err := setting.LoadFromDB(slackSetting.Type().Id(), dbSettings[0].Setting)
+	if err != nil {
+		return nil, errors.Maskf(err, "unmarshalling db settings")
+	}
+
+	slackSettings, found := settingsFromDB.(*setting.SlackSetting)
+	if !found {
+		return nil, errors.New("extracting slack settings")
+	}
+	return slackSettings, nil
 }
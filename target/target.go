@@ -8,6 +8,7 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
 )
 
 var (
@@ -27,6 +28,35 @@ func New(typeID db.TargetType, config types.JSONText) (Target, error) {
 	return nil, errors.Errorf("unknown target type %d", typeID)
 }
 
+// leveled is implemented by targets whose alerts depend on the level of the
+// trigger they belong to.
+type leveled interface {
+	setLevel(level state.State)
+}
+
+// endsIncident returns whether a is about its subprobe going back below the
+// level of a trigger, rather than about it being at or above the level.
+// Triggers at Normal, like escalation steps, end incidents when the subprobe
+// is Normal again.
+func endsIncident(level state.State, a *Alert) bool {
+	if a.Flapping {
+		return false
+	}
+	return a.NewState == state.Normal || a.NewState < level
+}
+
+// NewForTrigger makes the Target of trigger t.
+func NewForTrigger(t *db.Trigger) (Target, error) {
+	target, err := New(t.TargetType, t.Target)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if target, ok := target.(leveled); ok {
+		target.setLevel(t.Level)
+	}
+	return target, nil
+}
+
 // registerTargetType registers a target type onto a type dictionary
 func registerTargetType(t Type) {
 	if _, exists := daemonTargetTypes[t.ID()]; !exists {
@@ -0,0 +1,25 @@
+package target
+
+import (
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+)
+
+// Teams posts alerts to a Microsoft Teams channel through an incoming webhook.
+type Teams struct {
+	WebhookURL string
+}
+
+func newTeams(configJSON types.JSONText) (Target, error) {
+	var config TeamsDBModel
+	err := configJSON.Unmarshal(&config)
+	if err != nil {
+		return nil, errors.Maskf(err, "deserialize target config")
+	}
+
+	return &Teams{WebhookURL: config.WebhookURL}, nil
+}
+
+func (Teams) Type() Type {
+	return teamsType{}
+}
@@ -0,0 +1,69 @@
+package target_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yext/revere/db"
+	. "github.com/yext/revere/target"
+)
+
+func TestTeamsValidate(t *testing.T) {
+	tests := []struct {
+		url   string
+		valid bool
+	}{
+		{"https://example.webhook.office.com/webhookb2/abc", true},
+		{"http://example.webhook.office.com/webhookb2/abc", false},
+		{"example.webhook.office.com", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		params, _ := json.Marshal(TeamsTarget{WebhookURL: tt.url})
+		target, err := LoadFromParams(TeamsType{}.Id(), string(params))
+		if err != nil {
+			t.Fatalf("Unexpected error loading target: %v\n", err)
+		}
+		if errs := target.Validate(); (errs == nil) != tt.valid {
+			t.Errorf("%q: expected valid %t, got errors %v\n", tt.url, tt.valid, errs)
+		}
+	}
+}
+
+func TestTeamsAlert(t *testing.T) {
+	var (
+		requests int
+		card     map[string]interface{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		json.NewDecoder(req.Body).Decode(&card)
+	}))
+	defer server.Close()
+
+	config, _ := json.Marshal(TeamsDBModel{WebhookURL: server.URL})
+	tgt1, err := New(TeamsType{}.Id(), config)
+	if err != nil {
+		t.Fatalf("Unexpected error making target: %v\n", err)
+	}
+	tgt2, _ := New(TeamsType{}.Id(), config)
+
+	a := SampleAlert()
+	toAlert := map[db.TriggerID]Target{1: tgt1, 2: tgt2}
+	if errs := tgt1.Type().Alert(nil, a, toAlert, nil); errs != nil {
+		t.Fatalf("Unexpected errors sending to teams: %v\n", errs)
+	}
+
+	if requests != 1 {
+		t.Errorf("Expected one message for targets sharing a webhook, got %d\n", requests)
+	}
+	if card["@type"] != "MessageCard" || card["title"] != "Sample Monitor/sample.subprobe" {
+		t.Errorf("Unexpected card: %v\n", card)
+	}
+	if text, _ := card["text"].(string); !strings.Contains(text, "State change: Warning->ERROR") {
+		t.Errorf("Expected state change in card text, got %q\n", text)
+	}
+}
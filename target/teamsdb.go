@@ -0,0 +1,7 @@
+package target
+
+// TeamsDBModel defines the JSON serialization format for saving Teams targets'
+// settings in the database.
+type TeamsDBModel struct {
+	WebhookURL string
+}
@@ -0,0 +1,117 @@
+package target
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/state"
+)
+
+var (
+	teamsClient = &http.Client{Timeout: 30 * time.Second}
+
+	teamsStateColors = map[state.State]string{
+		// Green
+		state.Normal: "2EB886",
+		// Yellow
+		state.Warning: "DAA038",
+		// Red
+		state.Error: "A30200",
+		// Black
+		state.Critical: "000000",
+		// Grey
+		state.Unknown: "808080",
+	}
+)
+
+type teamsNotifier struct {
+	alert *Alert
+}
+
+// teamsCard is an Office 365 connector MessageCard, the format Teams incoming
+// webhooks accept.
+type teamsCard struct {
+	Type            string        `json:"@type"`
+	Context         string        `json:"@context"`
+	Summary         string        `json:"summary"`
+	ThemeColor      string        `json:"themeColor"`
+	Title           string        `json:"title"`
+	Text            string        `json:"text"`
+	PotentialAction []teamsAction `json:"potentialAction"`
+}
+
+type teamsAction struct {
+	Type    string        `json:"@type"`
+	Name    string        `json:"name"`
+	Targets []teamsTarget `json:"targets"`
+}
+
+type teamsTarget struct {
+	OS  string `json:"os"`
+	URI string `json:"uri"`
+}
+
+func (t teamsNotifier) send(url string) error {
+	message, err := t.formatMessage()
+	if err != nil {
+		return errors.Maskf(err, "formatting teams message")
+	}
+
+	resp, err := teamsClient.Post(url, "application/json", message)
+	if err != nil {
+		return errors.Maskf(err, "sending teams notification")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf(
+			"not-OK HTTP status code: %d, when sending teams notification",
+			resp.StatusCode)
+	}
+	return nil
+}
+
+func (t teamsNotifier) formatMessage() (io.Reader, error) {
+	a := t.alert
+	actions := []teamsAction{
+		openURIAction("View Subprobe", fmt.Sprintf("%s/monitors/%d/subprobes/%d",
+			a.Host, a.MonitorID, a.SubprobeID)),
+	}
+	if a.NewState != state.Normal {
+		actions = append(actions, openURIAction("Acknowledge", a.AckURL()))
+	}
+
+	card := teamsCard{
+		Type:    "MessageCard",
+		Context: "https://schema.org/extensions",
+		Summary: fmt.Sprintf("%s/%s entered state: %s",
+			a.MonitorName, a.SubprobeName, a.NewState),
+		ThemeColor: teamsStateColors[a.NewState],
+		Title:      fmt.Sprintf("%s/%s", a.MonitorName, a.SubprobeName),
+		// Teams renders the text as Markdown, which needs blank lines
+		// between paragraphs.
+		Text:            strings.Replace(alertStateText(a), "\n", "\n\n", -1),
+		PotentialAction: actions,
+	}
+
+	buf, err := json.Marshal(card)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewBuffer(buf), nil
+}
+
+func openURIAction(name, uri string) teamsAction {
+	return teamsAction{
+		Type:    "OpenUri",
+		Name:    name,
+		Targets: []teamsTarget{{OS: "default", URI: uri}},
+	}
+}
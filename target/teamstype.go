@@ -0,0 +1,44 @@
+package target
+
+import (
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+)
+
+type teamsType struct{}
+
+func init() {
+	registerTargetType(teamsType{})
+}
+
+func (teamsType) ID() db.TargetType {
+	return 7
+}
+
+func (teamsType) New(config types.JSONText) (Target, error) {
+	return newTeams(config)
+}
+
+func (teamsType) Alert(
+	Db *db.DB, a *Alert, toAlert map[db.TriggerID]Target, inactive []Target) []ErrorAndTriggerIDs {
+	triggerIDs := make(map[string][]db.TriggerID)
+	for id, target := range toAlert {
+		target := target.(*Teams)
+		triggerIDs[target.WebhookURL] = append(triggerIDs[target.WebhookURL], id)
+	}
+
+	notifier := teamsNotifier{alert: a}
+
+	var errs []ErrorAndTriggerIDs
+	for url, ids := range triggerIDs {
+		if err := notifier.send(url); err != nil {
+			errs = append(errs, ErrorAndTriggerIDs{
+				Err: errors.Trace(err),
+				IDs: ids,
+			})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,86 @@
+package target
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/yext/revere/db"
+)
+
+type TeamsType struct{}
+
+type TeamsTarget struct {
+	TeamsType
+	WebhookURL string
+}
+
+func init() {
+	addType(TeamsType{})
+}
+
+func (TeamsType) Id() db.TargetType {
+	return 7
+}
+
+func (TeamsType) Name() string {
+	return "Microsoft Teams"
+}
+
+func (TeamsType) loadFromParams(target string) (VM, error) {
+	var t TeamsTarget
+	err := json.Unmarshal([]byte(target), &t)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (TeamsType) loadFromDb(encodedTarget string) (VM, error) {
+	var t TeamsDBModel
+	err := json.Unmarshal([]byte(encodedTarget), &t)
+	if err != nil {
+		return nil, err
+	}
+
+	return TeamsTarget{
+		WebhookURL: t.WebhookURL,
+	}, nil
+}
+
+func (TeamsType) blank() VM {
+	return TeamsTarget{}
+}
+
+func (TeamsType) Templates() map[string]string {
+	return map[string]string{
+		"edit": "teams-edit.html",
+		"view": "teams-view.html",
+	}
+}
+
+func (TeamsType) Scripts() map[string][]string {
+	return map[string][]string{}
+}
+
+func (tt TeamsTarget) Serialize() (string, error) {
+	ttDB := TeamsDBModel{
+		WebhookURL: strings.TrimSpace(tt.WebhookURL),
+	}
+
+	ttDBJSON, err := json.Marshal(ttDB)
+	return string(ttDBJSON), err
+}
+
+func (TeamsTarget) Type() VMType {
+	return TeamsType{}
+}
+
+func (tt TeamsTarget) Validate() (errs []string) {
+	u, err := url.ParseRequestURI(strings.TrimSpace(tt.WebhookURL))
+	if err != nil || u.Scheme != "https" {
+		errs = append(errs,
+			"Invalid Webhook URL. Should be the https URL of a Teams incoming webhook.")
+	}
+	return
+}
@@ -30,3 +30,16 @@ type ErrorAndTriggerIDs struct {
 	Err error
 	IDs []db.TriggerID
 }
+
+// groupingType is implemented by target types that can send the alerts on
+// several subprobes of a monitor as one, using Alert.Grouped.
+type groupingType interface {
+	groupsAlerts()
+}
+
+// SupportsGrouping returns whether targets of the given type can send grouped
+// alerts.
+func SupportsGrouping(id db.TargetType) bool {
+	_, ok := daemonTargetTypes[id].(groupingType)
+	return ok
+}
@@ -0,0 +1,122 @@
+package target
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"text/template"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+)
+
+// WebhookSignatureHeader is the header webhook requests are signed in when
+// their target has a secret. Its value is "sha256=" followed by the hex
+// HMAC-SHA256 of the request body keyed with the secret.
+const WebhookSignatureHeader = "X-Revere-Signature"
+
+var (
+	webhookClient = &http.Client{Timeout: 30 * time.Second}
+
+	webhookFuncs = template.FuncMap{
+		"json": webhookJSON,
+	}
+)
+
+// Webhook posts alerts to a URL, with a body rendered from a template of the
+// alert.
+type Webhook struct {
+	URL     string
+	Body    *template.Template
+	Headers []WebhookHeader
+	Secret  string
+}
+
+func newWebhook(configJSON types.JSONText) (Target, error) {
+	var config WebhookDBModel
+	err := configJSON.Unmarshal(&config)
+	if err != nil {
+		return nil, errors.Maskf(err, "deserialize target config")
+	}
+
+	body, err := parseWebhookBody(config.Body)
+	if err != nil {
+		return nil, errors.Maskf(err, "parse body template")
+	}
+
+	return &Webhook{
+		URL:     config.URL,
+		Body:    body,
+		Headers: config.Headers,
+		Secret:  config.Secret,
+	}, nil
+}
+
+func (Webhook) Type() Type {
+	return webhookType{}
+}
+
+func (w *Webhook) send(a *Alert) error {
+	body, err := renderWebhookBody(w.Body, a)
+	if err != nil {
+		return errors.Maskf(err, "rendering webhook body for %s", w.URL)
+	}
+
+	req, err := http.NewRequest("POST", w.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Maskf(err, "making webhook request to %s", w.URL)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for _, h := range w.Headers {
+		req.Header.Set(h.Name, h.Value)
+	}
+	if w.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, SignWebhookBody(w.Secret, body))
+	}
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return errors.Maskf(err, "sending webhook to %s", w.URL)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf(
+			"not-OK HTTP status code: %d, when sending webhook to %s",
+			resp.StatusCode, w.URL)
+	}
+	return nil
+}
+
+// SignWebhookBody returns the value of the WebhookSignatureHeader for body.
+func SignWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func parseWebhookBody(body string) (*template.Template, error) {
+	return template.New("webhook").Funcs(webhookFuncs).Parse(body)
+}
+
+func renderWebhookBody(t *template.Template, a *Alert) ([]byte, error) {
+	var b bytes.Buffer
+	if err := t.Execute(&b, a); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return b.Bytes(), nil
+}
+
+// webhookJSON lets body templates quote values, such as strings that may
+// contain quotes or line breaks, as JSON.
+func webhookJSON(v interface{}) (string, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
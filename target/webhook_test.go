@@ -0,0 +1,138 @@
+package target_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yext/revere/db"
+	. "github.com/yext/revere/target"
+)
+
+var webhookTargetType = WebhookType{}
+
+func loadWebhookTarget(t *testing.T, params WebhookTarget) WebhookTarget {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("Unexpected error encoding webhook target: %v\n", err)
+	}
+	target, err := LoadFromParams(webhookTargetType.Id(), string(encoded))
+	if err != nil {
+		t.Fatalf("Unexpected error loading webhook target: %v\n", err)
+	}
+	return target.(WebhookTarget)
+}
+
+func TestWebhookDefaultBodyPreview(t *testing.T) {
+	blank, err := Blank(webhookTargetType.Id())
+	if err != nil {
+		t.Fatalf("Unexpected error making blank webhook target: %v\n", err)
+	}
+
+	preview, err := blank.(WebhookTarget).Preview()
+	if err != nil {
+		t.Fatalf("Unexpected error previewing default body: %v\n", err)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(preview), &body); err != nil {
+		t.Fatalf("Default body preview is not JSON: %v\n%s\n", err, preview)
+	}
+	if body["monitor"] != SampleAlert().MonitorName || body["newState"] != "ERROR" {
+		t.Errorf("Unexpected default body preview: %s\n", preview)
+	}
+}
+
+func TestWebhookValidate(t *testing.T) {
+	valid := WebhookTarget{
+		URL:     "https://example.com/hook",
+		Body:    `{"text": {{json .SubprobeName}}}`,
+		Headers: "Authorization: Bearer abc\n\nX-Team: ops",
+	}
+	if errs := loadWebhookTarget(t, valid).Validate(); errs != nil {
+		t.Errorf("Unexpected errors for valid webhook target: %v\n", errs)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(w *WebhookTarget)
+	}{
+		{"missing URL", func(w *WebhookTarget) { w.URL = "" }},
+		{"non-HTTP URL", func(w *WebhookTarget) { w.URL = "ftp://example.com" }},
+		{"missing body", func(w *WebhookTarget) { w.Body = " " }},
+		{"unparseable body", func(w *WebhookTarget) { w.Body = `{"text": {{json .SubprobeName}` }},
+		{"unknown field", func(w *WebhookTarget) { w.Body = `{"text": {{json .Nope}}}` }},
+		{"non-JSON body", func(w *WebhookTarget) { w.Body = `text: {{.SubprobeName}}` }},
+		{"header without value", func(w *WebhookTarget) { w.Headers = "Authorization" }},
+		{"invalid header name", func(w *WebhookTarget) { w.Headers = "Bad Header: x" }},
+	}
+	for _, tt := range tests {
+		invalid := valid
+		tt.modify(&invalid)
+		if errs := loadWebhookTarget(t, invalid).Validate(); errs == nil {
+			t.Errorf("Expected error for %s\n", tt.name)
+		}
+	}
+}
+
+func TestWebhookAlert(t *testing.T) {
+	var (
+		gotBody      []byte
+		gotHeader    http.Header
+		responseCode = http.StatusOK
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotBody, _ = ioutil.ReadAll(req.Body)
+		gotHeader = req.Header
+		w.WriteHeader(responseCode)
+	}))
+	defer server.Close()
+
+	vm := loadWebhookTarget(t, WebhookTarget{
+		URL:     server.URL,
+		Body:    `{"text": {{json .SubprobeName}}}`,
+		Headers: "X-Team: ops",
+		Secret:  "s3cret",
+	})
+	config, err := vm.Serialize()
+	if err != nil {
+		t.Fatalf("Unexpected error serializing webhook target: %v\n", err)
+	}
+	tgt, err := New(webhookTargetType.Id(), []byte(config))
+	if err != nil {
+		t.Fatalf("Unexpected error making webhook target: %v\n", err)
+	}
+
+	a := SampleAlert()
+	toAlert := map[db.TriggerID]Target{1: tgt}
+	if errs := tgt.Type().Alert(nil, a, toAlert, nil); errs != nil {
+		t.Fatalf("Unexpected errors sending webhook: %v\n", errs)
+	}
+
+	if want := `{"text": "sample.subprobe"}`; string(gotBody) != want {
+		t.Errorf("Expected body %s, got %s\n", want, gotBody)
+	}
+	if gotHeader.Get("X-Team") != "ops" {
+		t.Errorf("Expected custom header, got %v\n", gotHeader)
+	}
+	if got, want := gotHeader.Get(WebhookSignatureHeader), SignWebhookBody("s3cret", gotBody); got != want {
+		t.Errorf("Expected signature %s, got %s\n", want, got)
+	}
+
+	responseCode = http.StatusInternalServerError
+	errs := tgt.Type().Alert(nil, a, toAlert, nil)
+	if len(errs) != 1 || len(errs[0].IDs) != 1 || errs[0].IDs[0] != 1 {
+		t.Errorf("Expected the failed trigger to be reported, got %v\n", errs)
+	}
+}
+
+func TestSignWebhookBody(t *testing.T) {
+	// From RFC 4231, test case 2.
+	got := SignWebhookBody("Jefe", []byte("what do ya want for nothing?"))
+	want := "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+	if got != want {
+		t.Errorf("Expected %s, got %s\n", want, got)
+	}
+}
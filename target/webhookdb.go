@@ -0,0 +1,16 @@
+package target
+
+// WebhookDBModel defines the JSON serialization format for saving webhook
+// targets' settings in the database.
+type WebhookDBModel struct {
+	URL     string
+	Body    string
+	Headers []WebhookHeader
+	Secret  string
+}
+
+// WebhookHeader is a custom header sent with webhook requests.
+type WebhookHeader struct {
+	Name  string
+	Value string
+}
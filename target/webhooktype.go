@@ -0,0 +1,37 @@
+package target
+
+import (
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+)
+
+type webhookType struct{}
+
+func init() {
+	registerTargetType(webhookType{})
+}
+
+func (webhookType) ID() db.TargetType {
+	return 6
+}
+
+func (webhookType) New(config types.JSONText) (Target, error) {
+	return newWebhook(config)
+}
+
+func (webhookType) Alert(
+	Db *db.DB, a *Alert, toAlert map[db.TriggerID]Target, inactive []Target) []ErrorAndTriggerIDs {
+	var errs []ErrorAndTriggerIDs
+	for id, target := range toAlert {
+		target := target.(*Webhook)
+		if err := target.send(a); err != nil {
+			errs = append(errs, ErrorAndTriggerIDs{
+				Err: errors.Trace(err),
+				IDs: []db.TriggerID{id},
+			})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,175 @@
+package target
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+)
+
+// DefaultWebhookBody is the body template new webhook targets start with.
+const DefaultWebhookBody = `{
+  "monitor": {{json .MonitorName}},
+  "subprobe": {{json .SubprobeName}},
+  "oldState": {{json .OldState.String}},
+  "newState": {{json .NewState.String}},
+  "recorded": {{json .Recorded}},
+  "flapping": {{json .Flapping}},
+  "details": {{if .Details}}{{json .Details.Text}}{{else}}null{{end}},
+  "ackURL": {{json .AckURL}}
+}`
+
+type WebhookType struct{}
+
+type WebhookTarget struct {
+	WebhookType
+	URL  string
+	Body string
+	// Headers has one "Name: Value" header per line.
+	Headers string
+	Secret  string
+}
+
+var (
+	headerNameRegex = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+.^_`|~-]+$")
+)
+
+func init() {
+	addType(WebhookType{})
+}
+
+func (WebhookType) Id() db.TargetType {
+	return 6
+}
+
+func (WebhookType) Name() string {
+	return "Webhook"
+}
+
+func (WebhookType) loadFromParams(target string) (VM, error) {
+	var w WebhookTarget
+	err := json.Unmarshal([]byte(target), &w)
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (WebhookType) loadFromDb(encodedTarget string) (VM, error) {
+	var w WebhookDBModel
+	err := json.Unmarshal([]byte(encodedTarget), &w)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]string, len(w.Headers))
+	for i, h := range w.Headers {
+		headers[i] = fmt.Sprintf("%s: %s", h.Name, h.Value)
+	}
+
+	return WebhookTarget{
+		URL:     w.URL,
+		Body:    w.Body,
+		Headers: strings.Join(headers, "\n"),
+		Secret:  w.Secret,
+	}, nil
+}
+
+func (WebhookType) blank() VM {
+	return WebhookTarget{Body: DefaultWebhookBody}
+}
+
+func (WebhookType) Templates() map[string]string {
+	return map[string]string{
+		"edit": "webhook-edit.html",
+		"view": "webhook-view.html",
+	}
+}
+
+func (WebhookType) Scripts() map[string][]string {
+	return map[string][]string{
+		"edit": []string{
+			"webhook.js",
+		},
+	}
+}
+
+func (wt WebhookTarget) Serialize() (string, error) {
+	headers, _ := wt.headers()
+	wtDB := WebhookDBModel{
+		URL:     strings.TrimSpace(wt.URL),
+		Body:    wt.Body,
+		Headers: headers,
+		Secret:  wt.Secret,
+	}
+
+	wtDBJSON, err := json.Marshal(wtDB)
+	return string(wtDBJSON), err
+}
+
+func (WebhookTarget) Type() VMType {
+	return WebhookType{}
+}
+
+func (wt WebhookTarget) Validate() (errs []string) {
+	u, err := url.ParseRequestURI(strings.TrimSpace(wt.URL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		errs = append(errs, fmt.Sprintf("Invalid webhook URL: %s", wt.URL))
+	}
+
+	if _, err := wt.headers(); err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	if strings.TrimSpace(wt.Body) == "" {
+		errs = append(errs, "Webhook body is required.")
+	} else if _, err := wt.Preview(); err != nil {
+		errs = append(errs, err.Error())
+	}
+	return
+}
+
+// Preview renders the webhook body for a sample alert.
+func (wt WebhookTarget) Preview() (string, error) {
+	t, err := parseWebhookBody(wt.Body)
+	if err != nil {
+		return "", errors.Errorf("Invalid webhook body template: %s", err.Error())
+	}
+
+	body, err := renderWebhookBody(t, SampleAlert())
+	if err != nil {
+		return "", errors.Errorf("Unable to render webhook body: %s", errors.Cause(err).Error())
+	}
+
+	if !json.Valid(body) {
+		return string(body), errors.Errorf("Webhook body is not valid JSON: %s", body)
+	}
+	return string(body), nil
+}
+
+// headers parses the "Name: Value" lines of wt.Headers.
+func (wt WebhookTarget) headers() ([]WebhookHeader, error) {
+	var headers []WebhookHeader
+	for _, line := range strings.Split(wt.Headers, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		name := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || !headerNameRegex.MatchString(name) {
+			return headers, errors.Errorf("Invalid webhook header: %s", line)
+		}
+		headers = append(headers, WebhookHeader{
+			Name:  name,
+			Value: strings.TrimSpace(parts[1]),
+		})
+	}
+	return headers, nil
+}
@@ -14,6 +14,85 @@ const (
 		"auditPeriod": 10,
 		"auditPeriodType": "minute"
 	}`
+	DefaultHTTPProbeJson = `{
+		"URLs": ["http://foo.bar/health"],
+		"Method": "GET",
+		"ExpectedStatusCodes": "200-299",
+		"StatusState": 30,
+		"BodyRegexp": "ok",
+		"BodyState": 30,
+		"Thresholds": {"Warning": 500, "Error": 1000},
+		"CheckPeriod": 1,
+		"CheckPeriodType": "minute",
+		"Timeout": 10,
+		"TimeoutType": "second"
+	}`
+	DefaultPrometheusProbeJson = `{
+		"ResourceID": 1,
+		"Query": "sum(rate(http_requests_total{code=~\"5..\"}[5m])) by (job)",
+		"Thresholds": {"Warning": 1, "Error": 5, "Critical": 10},
+		"AuditFunction": "max",
+		"CheckPeriod": 1,
+		"CheckPeriodType": "minute",
+		"TriggerIf": ">=",
+		"AuditPeriod": 10,
+		"AuditPeriodType": "minute",
+		"Step": 15,
+		"StepType": "second"
+	}`
+	DefaultHeartbeatProbeJson = `{
+		"Token": "0123456789abcdef0123456789abcdef",
+		"Interval": 1,
+		"IntervalType": "hour",
+		"ErrorGrace": 10,
+		"ErrorGraceType": "minute",
+		"CriticalGrace": 1,
+		"CriticalGraceType": "hour",
+		"CheckPeriod": 1,
+		"CheckPeriodType": "minute"
+	}`
+	DefaultCompositeProbeJson = `{
+		"MonitorIDs": [1, 2, 3],
+		"Rule": "count",
+		"MinCount": 2,
+		"CheckPeriod": 30,
+		"CheckPeriodType": "second"
+	}`
+	DefaultAlertDeliveryProbeJson = `{
+		"Window": 1,
+		"WindowType": "hour",
+		"FailureState": 30,
+		"CheckPeriod": 1,
+		"CheckPeriodType": "minute"
+	}`
+	DefaultGraphiteAnomalyProbeJson = `{
+		"ResourceID": 1,
+		"Expression": "sumSeries(checkout.*.requests)",
+		"Thresholds": {"Warning": 2, "Error": 3, "Critical": 4},
+		"DeviationUnit": "stddev",
+		"Direction": "both",
+		"AuditFunction": "avg",
+		"CheckPeriod": 5,
+		"CheckPeriodType": "minute",
+		"AuditPeriod": 15,
+		"AuditPeriodType": "minute",
+		"BaselinePeriod": 1,
+		"BaselinePeriodType": "day"
+	}`
+	DefaultGraphiteComparisonProbeJson = `{
+		"ResourceID": 1,
+		"Expression": "sumSeries(checkout.*.requests)",
+		"Thresholds": {"Warning": 0.8, "Error": 0.6},
+		"Comparison": "ratio",
+		"TriggerIf": "<=",
+		"AuditFunction": "avg",
+		"CheckPeriod": 5,
+		"CheckPeriodType": "minute",
+		"AuditPeriod": 15,
+		"AuditPeriodType": "minute",
+		"TimeShift": 7,
+		"TimeShiftType": "day"
+	}`
 	DefaultTargetJson = `{
 		"Addresses": [
 			{"To":"test@ex.com", "ReplyTo":"test2@ex.com"}
@@ -0,0 +1,31 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/web/vm"
+	"github.com/yext/revere/web/vm/renderables"
+)
+
+func DependenciesIndex(DB *db.DB) func(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+		graph, err := vm.DependencyGraph(DB)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retrieve dependencies: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+
+		renderable := renderables.NewDependenciesIndex(graph)
+		err = render(w, renderable)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retrieve dependencies: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+	}
+}
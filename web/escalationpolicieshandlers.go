@@ -0,0 +1,183 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/juju/errors"
+	"github.com/julienschmidt/httprouter"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/web/vm"
+	"github.com/yext/revere/web/vm/renderables"
+)
+
+func EscalationPoliciesIndex(DB *db.DB) func(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+		var policies []*vm.EscalationPolicy
+		err := DB.Tx(func(tx *db.Tx) error {
+			var err error
+			policies, err = vm.AllEscalationPolicies(tx)
+			return errors.Trace(err)
+		})
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retrieve escalation policies: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+
+		renderable := renderables.NewEscalationPoliciesIndex(policies)
+		err = render(w, renderable)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retrieve escalation policies: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+func EscalationPoliciesView(DB *db.DB) func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	return func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+		id := p.ByName("id")
+
+		if id == "new" {
+			http.Redirect(w, req, "/escalations/new/edit", http.StatusMovedPermanently)
+			return
+		}
+
+		viewmodel, err := loadEscalationPolicyViewModel(DB, id)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retrieve escalation policy: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+
+		saveStatus, err := getFlash(w, req, "saveStatus")
+		if err != nil {
+			log.Errorf("Unable to load flash cookie for escalation policy: %s", err.Error())
+		}
+
+		renderable := renderables.NewEscalationPolicyView(viewmodel, saveStatus)
+		err = render(w, renderable)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retrieve escalation policy: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+func EscalationPoliciesEdit(DB *db.DB) func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	return func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+		id := p.ByName("id")
+		if id == "" {
+			http.Error(w, "Escalation policy not found", http.StatusNotFound)
+			return
+		}
+
+		viewmodel, err := loadEscalationPolicyViewModel(DB, id)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retrieve escalation policy: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+
+		renderable := renderables.NewEscalationPolicyEdit(viewmodel)
+		err = render(w, renderable)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retrieve escalation policy: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+func EscalationPoliciesSave(DB *db.DB) func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	return func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+		var ep *vm.EscalationPolicy
+		body := new(bytes.Buffer)
+		_, err := body.ReadFrom(req.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to save escalation policy: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+		err = json.Unmarshal(body.Bytes(), &ep)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to save escalation policy: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+
+		errs := ep.Validate()
+		if errs != nil {
+			errors, err := json.Marshal(map[string][]string{"errors": errs})
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Unable to save escalation policy: %s", err.Error()),
+					http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(errors)
+			return
+		}
+
+		var saveStatus string
+		if ep.IsCreate() {
+			saveStatus = "created"
+		} else {
+			saveStatus = "updated"
+		}
+
+		err = DB.Tx(func(tx *db.Tx) error {
+			err := ep.Save(tx)
+			return errors.Trace(err)
+		})
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to save escalation policy: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+		logSave(ep, body.Bytes(), req.URL.String())
+
+		redirect, err := json.Marshal(map[string]string{"redirect": fmt.Sprintf("/escalations/%d", ep.PolicyID)})
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to save escalation policy: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+
+		setFlash(w, "saveStatus", []byte(saveStatus))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(redirect)
+	}
+}
+
+func loadEscalationPolicyViewModel(DB *db.DB, unparsedId string) (*vm.EscalationPolicy, error) {
+	if unparsedId == "new" {
+		return vm.BlankEscalationPolicy(), nil
+	}
+
+	id, err := strconv.Atoi(unparsedId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var viewmodel *vm.EscalationPolicy
+	err = DB.Tx(func(tx *db.Tx) error {
+		var err error
+		viewmodel, err = vm.NewEscalationPolicy(tx, db.EscalationPolicyID(id))
+		return errors.Trace(err)
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return viewmodel, nil
+}
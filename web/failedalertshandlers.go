@@ -0,0 +1,52 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/web/vm"
+	"github.com/yext/revere/web/vm/renderables"
+)
+
+func FailedAlertsIndex(DB *db.DB) func(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+		failedAlerts, err := vm.AllFailedAlerts(DB)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retrieve failed alerts: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+
+		renderable := renderables.NewFailedAlertsIndex(failedAlerts)
+		err = render(w, renderable)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retrieve failed alerts: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+func FailedAlertsRetry(DB *db.DB) func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	return func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+		id, err := strconv.ParseInt(p.ByName("id"), 10, 64)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Alert not found: %s", p.ByName("id")),
+				http.StatusNotFound)
+			return
+		}
+
+		err = vm.RetryFailedAlert(DB, db.OutboxID(id))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retry alert: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+
+		http.Redirect(w, req, "/alerts/failed", http.StatusSeeOther)
+	}
+}
@@ -43,11 +43,35 @@ func init() {
 	tmpl.AddDefaultFunc("probeTypes", probe.AllTypes)
 }
 
+// AddTemplateFuncs adds the template functions that need the database, such
+// as those listing escalation policies and schedules for choosing targets.
+func AddTemplateFuncs(DB *db.DB) {
+	tmpl.AddDefaultFunc("escalationPolicies", func() ([]*vm.EscalationPolicy, error) {
+		var policies []*vm.EscalationPolicy
+		err := DB.Tx(func(tx *db.Tx) error {
+			var err error
+			policies, err = vm.AllEscalationPolicies(tx)
+			return errors.Trace(err)
+		})
+		return policies, errors.Trace(err)
+	})
+	tmpl.AddDefaultFunc("schedules", func() ([]*vm.Schedule, error) {
+		var schedules []*vm.Schedule
+		err := DB.Tx(func(tx *db.Tx) error {
+			var err error
+			schedules, err = vm.AllSchedules(tx)
+			return errors.Trace(err)
+		})
+		return schedules, errors.Trace(err)
+	})
+}
+
 func ActiveIssues(DB *db.DB) func(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
 	return func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
 		var (
 			err           error
 			subprobes     []*vm.Subprobe
+			pending       []*vm.Subprobe
 			monitorLabels map[db.MonitorID][]*vm.MonitorLabel
 			allLabels     []*vm.Label
 		)
@@ -68,7 +92,18 @@ func ActiveIssues(DB *db.DB) func(w http.ResponseWriter, req *http.Request, _ ht
 				return errors.Trace(err)
 			}
 
-			monitorLabels, err = vm.AllMonitorLabelsForSubprobes(tx, subprobes)
+			if labelUsed {
+				pending, err = vm.AllPendingSubprobesForLabel(tx, db.LabelID(labelID))
+			} else {
+				pending, err = vm.AllPendingSubprobes(tx)
+			}
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Unable to retrieve pending issues: %s", err.Error()),
+					http.StatusInternalServerError)
+				return errors.Trace(err)
+			}
+
+			monitorLabels, err = vm.AllMonitorLabelsForSubprobes(tx, append(subprobes, pending...))
 			if err != nil {
 				http.Error(w, fmt.Sprintf("Unable to retrieve active issues: %s", err.Error()),
 					http.StatusInternalServerError)
@@ -89,7 +124,7 @@ func ActiveIssues(DB *db.DB) func(w http.ResponseWriter, req *http.Request, _ ht
 			return
 		}
 
-		renderable := renderables.NewActiveIssues(subprobes, allLabels, monitorLabels)
+		renderable := renderables.NewActiveIssues(subprobes, pending, allLabels, monitorLabels)
 		err = render(w, renderable)
 		if err != nil {
 			http.Error(w, fmt.Sprintf("Unable to retrieve active issues: %s", err.Error()),
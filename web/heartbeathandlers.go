@@ -0,0 +1,80 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/probe"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	// defaultHeartbeatName is the subprobe name used for pings that do not
+	// specify one.
+	defaultHeartbeatName = "default"
+
+	// maxHeartbeatNameLength matches the length of subprobe names.
+	maxHeartbeatNameLength = 150
+
+	// maxHeartbeatNames is how many names may share a token, so a leaked
+	// token can't be used to fill the database.
+	maxHeartbeatNames = 100
+)
+
+// Heartbeat records a ping for the heartbeat probe(s) using the token in the
+// URL, which must belong to a monitor's heartbeat probe. The optional name form
+// value distinguishes heartbeats sharing a token, and each name becomes its own
+// subprobe.
+func Heartbeat(DB *db.DB) func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	return func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+		token := p.ByName("token")
+		if !probe.IsValidHeartbeatToken(token) {
+			http.Error(w, fmt.Sprintf("Invalid heartbeat token: %s", token),
+				http.StatusNotFound)
+			return
+		}
+
+		configured, err := probe.IsConfiguredHeartbeatToken(DB, token)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to look up heartbeat token: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+		if !configured {
+			http.Error(w, fmt.Sprintf("Unknown heartbeat token: %s", token),
+				http.StatusNotFound)
+			return
+		}
+
+		name := req.FormValue("name")
+		if name == "" {
+			name = defaultHeartbeatName
+		}
+		if name == "_" || len(name) > maxHeartbeatNameLength {
+			http.Error(w, fmt.Sprintf("Invalid heartbeat name: %s", name),
+				http.StatusBadRequest)
+			return
+		}
+
+		recorded, err := DB.RecordHeartbeat(db.Heartbeat{
+			Token:    token,
+			Name:     name,
+			LastPing: time.Now(),
+		}, maxHeartbeatNames)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to record heartbeat: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+		if !recorded {
+			http.Error(w, fmt.Sprintf("Too many heartbeat names for token: at most %d allowed", maxHeartbeatNames),
+				http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
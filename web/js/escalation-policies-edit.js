@@ -0,0 +1,63 @@
+$(document).ready(function() {
+  escalationPoliciesEdit.init();
+});
+
+var escalationPoliciesEdit = function() {
+  var epe = {};
+
+  epe.init = function() {
+    triggersEdit.init();
+    initForm();
+  };
+
+  var initForm = function() {
+    $('#js-escalation-policy-form').submit(function(e) {
+      e.preventDefault();
+      var $form = $(this);
+      var url = $form.attr('action');
+
+      data = $.extend(
+        getPolicyData(),
+        {'Steps': getStepsData()}
+      );
+
+      $.ajax({
+        url: url,
+        method: 'POST',
+        data: JSON.stringify(data),
+        contentType: 'application/json; charset=UTF-8'
+      }).success(function(response) {
+        if (response.errors) {
+          return revere.showErrors(response.errors);
+        }
+        if (response.redirect) {
+          window.location.replace(response.redirect);
+        } else {
+          window.location.replace('/escalations/' + data['PolicyID']);
+        }
+      }).fail(function(jqXHR, textStatus, errorThrown) {
+        revere.showErrors([jqXHR.responseText || textStatus]);
+      });
+    });
+  };
+
+  var getPolicyData = function() {
+    return $('#js-escalation-policy-info').find(':input').serializeObject();
+  };
+
+  var getStepsData = function() {
+    var data = [];
+    $.each($('.js-trigger').not(':first'), function() {
+      var trigger = triggerEdit.getData(this);
+      data.push({
+        Trigger: trigger,
+        PolicyID: parseInt($('input[name=PolicyID]').first().val()),
+        Delay: trigger['Delay'],
+        DelayType: trigger['DelayType']
+      });
+    });
+    return data;
+  };
+
+  return epe;
+}();
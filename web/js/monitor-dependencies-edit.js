@@ -0,0 +1,20 @@
+var monitorDependenciesEdit = function() {
+  var md = {},
+    cl = componentList('monitor-dependency');
+
+  md.init = function() {
+    cl.init();
+  };
+
+  md.getData = function() {
+    var clData = cl.getData(),
+      data = [],
+      monitorID = parseInt($('input[name=MonitorID]').val());
+    $.each(clData, function(i, monitorDependency) {
+      data.push($.extend(monitorDependency, {'MonitorID': monitorID}));
+    });
+    return data;
+  };
+
+  return md;
+}();
@@ -23,6 +23,7 @@ var monitorsEdit = function() {
   m.init = function() {
     monitorTriggersEdit.init();
     monitorLabelsEdit.init();
+    monitorDependenciesEdit.init();
     initProbe();
     initForm();
   };
@@ -54,7 +55,8 @@ var monitorsEdit = function() {
         data = $.extend(
           getMonitorData(),
           {'Triggers': monitorTriggersEdit.getData()},
-          {'Labels': monitorLabelsEdit.getData()}
+          {'Labels': monitorLabelsEdit.getData()},
+          {'Dependencies': monitorDependenciesEdit.getData()}
         );
       $.ajax({
         url: url,
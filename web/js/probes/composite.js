@@ -0,0 +1,45 @@
+$(document).ready(function() {
+  compositeProbe.init();
+});
+
+var compositeProbe = function() {
+  var c = {};
+
+  c.init = function() {
+    addSerializeFn();
+    loadMonitors();
+  };
+
+  var loadMonitors = function() {
+    $.ajax({
+      url: '/monitoroptions',
+    }).done(function(data, status, jqXHR) {
+      displayMonitors(data);
+    }).fail(function(jqXHR, status, error) {
+      revere.showErrors([error]);
+    });
+  };
+
+  var displayMonitors = function(monitors) {
+    var $selector = $('#js-composite-monitors'),
+      selected = String($selector.data('selected')).split(',');
+    $.each(monitors, function(i, monitor) {
+      var id = String(monitor.MonitorID);
+      $selector.append($('<option></option>').val(id).text(monitor.Name)
+        .attr('selected', $.inArray(id, selected) !== -1));
+    });
+  };
+
+  var addSerializeFn = function() {
+    probes.addSerializeFn($('#js-composite-probe-type').val(), function(probe) {
+      var inputs = probe.find(':input:not([name="MonitorIDs"])').serializeObject(),
+        monitorIDs = $.map(probe.find('select[name="MonitorIDs"]').val() || [], function(id) {
+          return parseInt(id);
+        });
+
+      return JSON.stringify($.extend(inputs, {"MonitorIDs": monitorIDs}));
+    });
+  };
+
+  return c;
+}();
@@ -91,6 +91,14 @@ var graphitePreview = function() {
     } else {
         targetExpression = gtFields['Expression']
     }
+    if (gtFields['AuditFunction'] === 'pct_breaching') {
+      // Thresholds are percentages of points, so show what points are
+      // compared against instead.
+      return [
+        getDataTargetExpression(targetExpression, gtFields['TriggerIf']),
+        getThresholdTargetExpression(gtFields['BreachValue'], 'breach', 'red')
+      ];
+    }
     return [
       getDataTargetExpression(targetExpression, gtFields['TriggerIf']),
       getThresholdTargetExpression(gtFields['Warning'], 'warning', 'orange'),
@@ -11,16 +11,27 @@ var graphiteThreshold = function() {
 
   var addSerializeFn = function() {
     probes.addSerializeFn($('#js-graphite-threshold-probe-type').val(), function(probe) {
-      var inputs = probe.find(':input:not(.js-threshold)').serializeObject();
+      probe.find(':input.js-breach-value').each(function() {
+          if ($(this).val() == "") {
+              $(this).remove();
+          }
+      });
+      var inputs = probe.find(':input:not(.js-threshold):not(.js-recovery-threshold)').serializeObject();
       probe.find(':input.js-threshold').each(function() {
           if ($(this).val() == "") {
               $(this).remove();
           }
       });
+      probe.find(':input.js-recovery-threshold').each(function() {
+          if ($(this).val() == "") {
+              $(this).remove();
+          }
+      });
       var thresholds = probe.find(':input.js-threshold').serializeObject(),
+        recoveryThresholds = probe.find(':input.js-recovery-threshold').serializeObject(),
         id = parseInt(probe.find('select[name="URL"] :selected').first().data('id'));
 
-      return JSON.stringify($.extend(inputs, {"Thresholds": thresholds, "ResourceID": id}));
+      return JSON.stringify($.extend(inputs, {"Thresholds": thresholds, "RecoveryThresholds": recoveryThresholds, "ResourceID": id}));
     });
   };
 
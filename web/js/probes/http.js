@@ -0,0 +1,29 @@
+$(document).ready(function() {
+  httpProbe.init();
+});
+
+var httpProbe = function() {
+  var h = {};
+
+  h.init = function() {
+    addSerializeFn();
+  };
+
+  var addSerializeFn = function() {
+    probes.addSerializeFn($('#js-http-probe-type').val(), function(probe) {
+      var inputs = probe.find(':input:not(.js-threshold):not([name="URLs"])').serializeObject();
+      probe.find(':input.js-threshold').each(function() {
+          if ($(this).val() == "") {
+              $(this).remove();
+          }
+      });
+      var thresholds = probe.find(':input.js-threshold').serializeObject(),
+        urls = $.grep($.map(probe.find('textarea[name="URLs"]').val().split('\n'), $.trim),
+          function(url) { return url !== ''; });
+
+      return JSON.stringify($.extend(inputs, {"Thresholds": thresholds, "URLs": urls}));
+    });
+  };
+
+  return h;
+}();
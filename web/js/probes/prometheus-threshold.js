@@ -0,0 +1,34 @@
+$(document).ready(function() {
+  prometheusThreshold.init();
+});
+
+var prometheusThreshold = function() {
+  var p = {};
+
+  p.init = function() {
+    addSerializeFn();
+  };
+
+  var addSerializeFn = function() {
+    probes.addSerializeFn($('#js-prometheus-threshold-probe-type').val(), function(probe) {
+      var inputs = probe.find(':input:not(.js-threshold):not(.js-recovery-threshold)').serializeObject();
+      probe.find(':input.js-threshold').each(function() {
+          if ($(this).val() == "") {
+              $(this).remove();
+          }
+      });
+      probe.find(':input.js-recovery-threshold').each(function() {
+          if ($(this).val() == "") {
+              $(this).remove();
+          }
+      });
+      var thresholds = probe.find(':input.js-threshold').serializeObject(),
+        recoveryThresholds = probe.find(':input.js-recovery-threshold').serializeObject(),
+        id = parseInt(probe.find('select[name="URL"] :selected').first().data('id'));
+
+      return JSON.stringify($.extend(inputs, {"Thresholds": thresholds, "RecoveryThresholds": recoveryThresholds, "ResourceID": id}));
+    });
+  };
+
+  return p;
+}();
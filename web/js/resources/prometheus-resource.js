@@ -0,0 +1,21 @@
+$(document).ready(function() {
+  resources.addSourceFunction(prometheusResourceHandler.getData);
+});
+
+
+var prometheusResourceHandler = function() {
+  var pdsh = {}
+
+  pdsh.getData = function() {
+    var data = [];
+    $.each($('.js-resource.prometheus'), function() {
+      var sendData = $(this).find(':input.required').serializeObject();
+      var sourceData = $(this).find(':input.source').serializeObject();
+      $.extend(sendData, {'ResourceParams': JSON.stringify(sourceData)});
+      data.push(sendData)
+    });
+    return data;
+  };
+
+  return pdsh
+}();
@@ -0,0 +1,53 @@
+$(document).ready(function() {
+  schedulesEdit.init();
+});
+
+var schedulesEdit = function() {
+  var se = {},
+    layers = componentList('schedule-layer'),
+    overrides = componentList('schedule-override');
+
+  se.init = function() {
+    layers.init();
+    overrides.init();
+    initForm();
+  };
+
+  var initForm = function() {
+    $('#js-schedule-form').submit(function(e) {
+      e.preventDefault();
+      var $form = $(this);
+      var url = $form.attr('action');
+
+      data = $.extend(
+        getScheduleData(),
+        {'Layers': layers.getData()},
+        {'Overrides': overrides.getData()}
+      );
+
+      $.ajax({
+        url: url,
+        method: 'POST',
+        data: JSON.stringify(data),
+        contentType: 'application/json; charset=UTF-8'
+      }).success(function(response) {
+        if (response.errors) {
+          return revere.showErrors(response.errors);
+        }
+        if (response.redirect) {
+          window.location.replace(response.redirect);
+        } else {
+          window.location.replace('/schedules/' + data['ScheduleID']);
+        }
+      }).fail(function(jqXHR, textStatus, errorThrown) {
+        revere.showErrors([jqXHR.responseText || textStatus]);
+      });
+    });
+  };
+
+  var getScheduleData = function() {
+    return $('#js-schedule-info').find(':input').serializeObject();
+  };
+
+  return se;
+}();
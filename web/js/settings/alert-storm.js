@@ -0,0 +1,21 @@
+$(document).ready(function() {
+  settings.addSerializeFn(alertStorm.getData);
+});
+
+
+var alertStorm = function() {
+  var as = {};
+
+  as.getData = function() {
+    var data = [];
+    $.each($('.js-alert-storm'), function() {
+      var serialized = $(this).find(':input.required').serializeObject();
+      var json = $(this).find(':input.json').serializeObject();
+      $.extend(serialized, {'SettingParams': JSON.stringify(json)});
+      data.push(serialized);
+    });
+    return data;
+  };
+
+  return as;
+}();
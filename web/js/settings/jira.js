@@ -0,0 +1,21 @@
+$(document).ready(function() {
+  settings.addSerializeFn(jira.getData);
+});
+
+
+var jira = function() {
+  var j = {};
+
+  j.getData = function() {
+    var data = [];
+    $.each($('.js-jira'), function() {
+      var serialized = $(this).find(':input.required').serializeObject();
+      var json = $(this).find(':input.json').serializeObject();
+      $.extend(serialized, {'SettingParams': JSON.stringify(json)});
+      data.push(serialized);
+    });
+    return data;
+  };
+
+  return j;
+}();
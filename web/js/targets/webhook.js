@@ -0,0 +1,38 @@
+$(document).ready(function() {
+  webhookTarget.init();
+});
+
+var webhookTarget = function() {
+  var w = {};
+
+  w.init = function() {
+    preview();
+  };
+
+  var preview = function() {
+    $(document.body).on('click', '.js-webhook-preview', function(e) {
+      e.preventDefault();
+      var $webhook = $(this).parents('.js-webhook'),
+        $output = $webhook.find('.js-webhook-preview-output'),
+        $error = $webhook.find('.js-webhook-preview-error');
+      $.ajax({
+        url: '/targets/webhook/preview',
+        method: 'POST',
+        data: JSON.stringify($webhook.find(':input').serializeObject()),
+        contentType: 'application/json; charset=UTF-8',
+        dataType: 'json'
+      }).success(function(response) {
+        $output.text(response.preview).removeClass('hidden');
+        if (response.errors) {
+          $error.text(response.errors.join(' ')).removeClass('hidden');
+        } else {
+          $error.addClass('hidden');
+        }
+      }).fail(function(jqXHR, textStatus, errorThrown) {
+        revere.showErrors([jqXHR.responseText || textStatus]);
+      });
+    });
+  };
+
+  return w;
+}();
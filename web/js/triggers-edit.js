@@ -57,6 +57,7 @@ var triggersEdit = function() {
           $that.parents('.js-trigger-options')
             .next('.js-target')
             .html(response.template);
+          loadScripts(response.scripts);
         }
       }).fail(function(jqXHR, textStatus, errorThrown) {
         revere.showErrors([jqXHR.responseText || textStatus]);
@@ -64,5 +65,18 @@ var triggersEdit = function() {
     });
   };
 
+  // Target templates loaded after the page may need scripts that the page
+  // didn't.
+  var loadScripts = function(scripts) {
+    $.each(scripts || [], function(i, script) {
+      var src = '/' + script;
+      if ($('script[src="' + src + '"]').length === 0) {
+        var el = document.createElement('script');
+        el.src = src;
+        document.body.appendChild(el);
+      }
+    });
+  };
+
   return tse;
 }();
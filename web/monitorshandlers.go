@@ -114,8 +114,9 @@ func MonitorsEdit(DB *db.DB) func(w http.ResponseWriter, req *http.Request, p ht
 		}
 
 		var (
-			monitor *vm.Monitor
-			labels  []*vm.Label
+			monitor  *vm.Monitor
+			labels   []*vm.Label
+			monitors []*vm.Monitor
 		)
 		err := DB.Tx(func(tx *db.Tx) (err error) {
 			monitor, err = loadMonitorViewModel(tx, id)
@@ -132,6 +133,13 @@ func MonitorsEdit(DB *db.DB) func(w http.ResponseWriter, req *http.Request, p ht
 				return
 			}
 
+			monitors, err = vm.AllMonitors(tx)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Unable to retrieve monitors for dependencies: %s", err.Error()),
+					http.StatusInternalServerError)
+				return
+			}
+
 			return nil
 		})
 		if err != nil {
@@ -140,7 +148,7 @@ func MonitorsEdit(DB *db.DB) func(w http.ResponseWriter, req *http.Request, p ht
 			return
 		}
 
-		renderable := renderables.NewMonitorEdit(monitor, labels)
+		renderable := renderables.NewMonitorEdit(monitor, labels, monitors)
 		err = render(w, renderable)
 
 		if err != nil {
@@ -234,3 +242,30 @@ func loadMonitorViewModel(tx *db.Tx, unparsedId string) (*vm.Monitor, error) {
 
 	return monitor, nil
 }
+
+// LoadMonitorOptions lists the active monitors as JSON, for probes that
+// derive their state from other monitors.
+func LoadMonitorOptions(DB *db.DB) func(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+		monitors, err := DB.LoadMonitors()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to load monitors: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+
+		type monitorOption struct {
+			MonitorID db.MonitorID
+			Name      string
+		}
+		options := make([]monitorOption, 0, len(monitors))
+		for _, m := range monitors {
+			if m.Archived == nil {
+				options = append(options, monitorOption{m.MonitorID, m.Name})
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(options)
+	}
+}
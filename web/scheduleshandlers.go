@@ -0,0 +1,183 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/juju/errors"
+	"github.com/julienschmidt/httprouter"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/web/vm"
+	"github.com/yext/revere/web/vm/renderables"
+)
+
+func SchedulesIndex(DB *db.DB) func(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	return func(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+		var schedules []*vm.Schedule
+		err := DB.Tx(func(tx *db.Tx) error {
+			var err error
+			schedules, err = vm.AllSchedules(tx)
+			return errors.Trace(err)
+		})
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retrieve schedules: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+
+		renderable := renderables.NewSchedulesIndex(schedules)
+		err = render(w, renderable)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retrieve schedules: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+func SchedulesView(DB *db.DB) func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	return func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+		id := p.ByName("id")
+
+		if id == "new" {
+			http.Redirect(w, req, "/schedules/new/edit", http.StatusMovedPermanently)
+			return
+		}
+
+		viewmodel, err := loadScheduleViewModel(DB, id)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retrieve schedule: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+
+		saveStatus, err := getFlash(w, req, "saveStatus")
+		if err != nil {
+			log.Errorf("Unable to load flash cookie for schedule: %s", err.Error())
+		}
+
+		renderable := renderables.NewScheduleView(viewmodel, saveStatus)
+		err = render(w, renderable)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retrieve schedule: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+func SchedulesEdit(DB *db.DB) func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	return func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+		id := p.ByName("id")
+		if id == "" {
+			http.Error(w, "Schedule not found", http.StatusNotFound)
+			return
+		}
+
+		viewmodel, err := loadScheduleViewModel(DB, id)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retrieve schedule: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+
+		renderable := renderables.NewScheduleEdit(viewmodel)
+		err = render(w, renderable)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retrieve schedule: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+func SchedulesSave(DB *db.DB) func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	return func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+		var s *vm.Schedule
+		body := new(bytes.Buffer)
+		_, err := body.ReadFrom(req.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to save schedule: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+		err = json.Unmarshal(body.Bytes(), &s)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to save schedule: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+
+		errs := s.Validate()
+		if errs != nil {
+			errors, err := json.Marshal(map[string][]string{"errors": errs})
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Unable to save schedule: %s", err.Error()),
+					http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(errors)
+			return
+		}
+
+		var saveStatus string
+		if s.IsCreate() {
+			saveStatus = "created"
+		} else {
+			saveStatus = "updated"
+		}
+
+		err = DB.Tx(func(tx *db.Tx) error {
+			err := s.Save(tx)
+			return errors.Trace(err)
+		})
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to save schedule: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+		logSave(s, body.Bytes(), req.URL.String())
+
+		redirect, err := json.Marshal(map[string]string{"redirect": fmt.Sprintf("/schedules/%d", s.ScheduleID)})
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to save schedule: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+
+		setFlash(w, "saveStatus", []byte(saveStatus))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(redirect)
+	}
+}
+
+func loadScheduleViewModel(DB *db.DB, unparsedId string) (*vm.Schedule, error) {
+	if unparsedId == "new" {
+		return vm.BlankSchedule(), nil
+	}
+
+	id, err := strconv.Atoi(unparsedId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var viewmodel *vm.Schedule
+	err = DB.Tx(func(tx *db.Tx) error {
+		var err error
+		viewmodel, err = vm.NewSchedule(tx, db.ScheduleID(id))
+		return errors.Trace(err)
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return viewmodel, nil
+}
@@ -28,6 +28,8 @@ func New(env *env.Env) *WebServer {
 	jsFiles := boxes.JS()
 	favicon := boxes.Favicon()
 
+	web.AddTemplateFuncs(env.DB)
+
 	router := httprouter.New()
 	router.GET("/", web.ActiveIssues(env.DB))
 	router.GET("/resources", web.ResourcesIndex(env.DB))
@@ -40,20 +42,36 @@ func New(env *env.Env) *WebServer {
 	router.POST("/monitors/:id/edit", web.MonitorsSave(env.DB))
 	router.GET("/monitors/:id/subprobes", web.SubprobesIndex(env.DB))
 	router.GET("/monitors/:id/subprobes/:subprobeId", web.SubprobesView(env.DB))
+	router.GET("/monitors/:id/subprobes/:subprobeId/ack", web.SubprobesAck(env.DB))
+	router.POST("/monitors/:id/subprobes/:subprobeId/ack", web.SubprobesAckSave(env.DB))
 	router.DELETE("/monitors/:id/subprobes/:subprobeId/delete", web.DeleteSubprobe(env.DB));
 	router.GET("/monitors/:id/probe/edit/:probeType", web.LoadProbeTemplate(env.DB))
 	router.GET("/monitors/:id/target/edit/:targetType", web.LoadTargetTemplate)
+	router.POST("/targets/webhook/preview", web.PreviewWebhookTarget)
+	router.GET("/monitoroptions", web.LoadMonitorOptions(env.DB))
+	router.GET("/dependencies", web.DependenciesIndex(env.DB))
 	router.GET("/silences", web.SilencesIndex(env.DB))
 	router.GET("/silences/:id", web.SilencesView(env.DB))
 	router.GET("/silences/:id/edit", web.SilencesEdit(env.DB))
 	router.POST("/silences/:id/edit", web.SilencesSave(env.DB))
+	router.GET("/alerts/failed", web.FailedAlertsIndex(env.DB))
+	router.POST("/alerts/failed/:id/retry", web.FailedAlertsRetry(env.DB))
 	router.GET("/labels", web.LabelsIndex(env.DB))
 	router.GET("/labels/:id", web.LabelsView(env.DB))
 	router.GET("/labels/:id/edit", web.LabelsEdit(env.DB))
 	router.POST("/labels/:id/edit", web.LabelsSave(env.DB))
+	router.GET("/escalations", web.EscalationPoliciesIndex(env.DB))
+	router.GET("/escalations/:id", web.EscalationPoliciesView(env.DB))
+	router.GET("/escalations/:id/edit", web.EscalationPoliciesEdit(env.DB))
+	router.POST("/escalations/:id/edit", web.EscalationPoliciesSave(env.DB))
+	router.GET("/schedules", web.SchedulesIndex(env.DB))
+	router.GET("/schedules/:id", web.SchedulesView(env.DB))
+	router.GET("/schedules/:id/edit", web.SchedulesEdit(env.DB))
+	router.POST("/schedules/:id/edit", web.SchedulesSave(env.DB))
 	router.GET("/settings", web.SettingsIndex(env.DB))
 	router.POST("/settings", web.SettingsSave(env.DB))
 	router.GET("/redirectToSilence", web.RedirectToSilence(env.DB))
+	router.POST("/heartbeat/:token", web.Heartbeat(env.DB))
 
 	router.ServeFiles("/static/css/*filepath", cssFiles.HTTPBox())
 	router.ServeFiles("/static/js/*filepath", jsFiles.HTTPBox())
@@ -124,4 +124,86 @@ func DeleteSubprobe(DB *db.DB) func(w http.ResponseWriter, req *http.Request, p
 			return
 		}
 	}
-}
\ No newline at end of file
+}
+// SubprobesAck shows the page for acknowledging a subprobe. It is linked to
+// from alerts, so it must not acknowledge anything itself.
+func SubprobesAck(DB *db.DB) func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	return func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+		subprobe, ok := loadSubprobeForMonitor(DB, w, p)
+		if !ok {
+			return
+		}
+
+		renderable := renderables.NewSubprobeAck(vm.NewSubprobeAck(subprobe, ""), nil)
+		err := render(w, renderable)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to retrieve subprobe: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+func SubprobesAckSave(DB *db.DB) func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	return func(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+		subprobe, ok := loadSubprobeForMonitor(DB, w, p)
+		if !ok {
+			return
+		}
+
+		ack := vm.NewSubprobeAck(subprobe, req.FormValue("AckedBy"))
+		if errs := ack.Validate(); len(errs) > 0 {
+			err := render(w, renderables.NewSubprobeAck(ack, errs))
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Unable to acknowledge subprobe: %s", err.Error()),
+					http.StatusInternalServerError)
+			}
+			return
+		}
+
+		err := DB.Tx(func(tx *db.Tx) error {
+			return ack.Save(tx)
+		})
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unable to acknowledge subprobe: %s", err.Error()),
+				http.StatusInternalServerError)
+			return
+		}
+
+		http.Redirect(w, req, fmt.Sprintf("/monitors/%d/subprobes/%d", subprobe.MonitorID, subprobe.SubprobeID),
+			http.StatusSeeOther)
+	}
+}
+
+// loadSubprobeForMonitor loads the subprobe named by the request's path,
+// writing an error response and returning false if it doesn't exist.
+func loadSubprobeForMonitor(DB *db.DB, w http.ResponseWriter, p httprouter.Params) (*vm.Subprobe, bool) {
+	mId, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Monitor not found: %s", p.ByName("id")),
+			http.StatusNotFound)
+		return nil, false
+	}
+
+	id, err := strconv.Atoi(p.ByName("subprobeId"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Subprobe not found: %s", p.ByName("subprobeId")),
+			http.StatusNotFound)
+		return nil, false
+	}
+
+	subprobe, err := vm.NewSubprobe(DB, db.SubprobeID(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Unable to retrieve subprobe: %s", err.Error()),
+			http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if subprobe.MonitorID != db.MonitorID(mId) {
+		http.Error(w, fmt.Sprintf("Subprobe %d does not exist for monitor: %d", id, mId),
+			http.StatusNotFound)
+		return nil, false
+	}
+
+	return subprobe, true
+}
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"html/template"
@@ -104,14 +105,14 @@ func LoadTargetTemplate(w http.ResponseWriter, req *http.Request, p httprouter.P
 
 	te := renderables.NewTargetEdit(target)
 
-	tmpl, err := renderables.RenderPartial(te)
+	tmpl, scripts, err := renderables.RenderPartialWithScripts(te)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Unable to load target: %s", err.Error()),
 			http.StatusInternalServerError)
 		return
 	}
 
-	template, err := json.Marshal(map[string]template.HTML{"template": tmpl})
+	template, err := json.Marshal(map[string]interface{}{"template": tmpl, "scripts": scripts})
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Unable to load target: %s", err.Error()),
 			http.StatusInternalServerError)
@@ -121,3 +122,37 @@ func LoadTargetTemplate(w http.ResponseWriter, req *http.Request, p httprouter.P
 	w.Header().Set("Content-Type", "application/json")
 	w.Write(template)
 }
+
+func PreviewWebhookTarget(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	body := new(bytes.Buffer)
+	_, err := body.ReadFrom(req.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Unable to preview webhook: %s", err.Error()),
+			http.StatusInternalServerError)
+		return
+	}
+
+	t, err := target.LoadFromParams(target.WebhookType{}.Id(), body.String())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Unable to preview webhook: %s", err.Error()),
+			http.StatusInternalServerError)
+		return
+	}
+
+	result := make(map[string]interface{})
+	preview, err := t.(target.WebhookTarget).Preview()
+	result["preview"] = preview
+	if err != nil {
+		result["errors"] = []string{err.Error()}
+	}
+
+	response, err := json.Marshal(result)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Unable to preview webhook: %s", err.Error()),
+			http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
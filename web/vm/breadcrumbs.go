@@ -25,6 +25,10 @@ func SubprobeViewBcs(s *Subprobe) []Breadcrumb {
 	return append(SubprobeIndexBcs(s.MonitorName, int64(s.MonitorID)), Breadcrumb{s.Name, fmt.Sprintf("/monitors/%d/subprobes/%d", s.MonitorID, s.SubprobeID)})
 }
 
+func SubprobeAckBcs(s *Subprobe) []Breadcrumb {
+	return append(SubprobeViewBcs(s), Breadcrumb{"Acknowledge", fmt.Sprintf("/monitors/%d/subprobes/%d/ack", s.MonitorID, s.SubprobeID)})
+}
+
 func SilencesIndexBcs() []Breadcrumb {
 	return []Breadcrumb{Breadcrumb{"Silences", "/silences"}}
 }
@@ -33,6 +37,14 @@ func SilencesViewBcs(id int64, mn string) []Breadcrumb {
 	return append(SilencesIndexBcs(), Breadcrumb{fmt.Sprintf("Silence for %s", mn), fmt.Sprintf("/silences/%d", id)})
 }
 
+func FailedAlertsIndexBcs() []Breadcrumb {
+	return []Breadcrumb{Breadcrumb{"Failed Alerts", "/alerts/failed"}}
+}
+
+func DependenciesIndexBcs() []Breadcrumb {
+	return []Breadcrumb{Breadcrumb{"Dependencies", "/dependencies"}}
+}
+
 func LabelIndexBcs() []Breadcrumb {
 	return []Breadcrumb{Breadcrumb{"Labels", "/labels"}}
 }
@@ -41,6 +53,22 @@ func LabelViewBcs(mn string, id int64) []Breadcrumb {
 	return append(LabelIndexBcs(), Breadcrumb{mn, fmt.Sprintf("/labels/%d", id)})
 }
 
+func EscalationPolicyIndexBcs() []Breadcrumb {
+	return []Breadcrumb{Breadcrumb{"Escalation Policies", "/escalations"}}
+}
+
+func EscalationPolicyViewBcs(pn string, id int64) []Breadcrumb {
+	return append(EscalationPolicyIndexBcs(), Breadcrumb{pn, fmt.Sprintf("/escalations/%d", id)})
+}
+
+func ScheduleIndexBcs() []Breadcrumb {
+	return []Breadcrumb{Breadcrumb{"Schedules", "/schedules"}}
+}
+
+func ScheduleViewBcs(sn string, id int64) []Breadcrumb {
+	return append(ScheduleIndexBcs(), Breadcrumb{sn, fmt.Sprintf("/schedules/%d", id)})
+}
+
 func IsLastBc(a []Breadcrumb, i int) bool {
 	return i == len(a)-1
 }
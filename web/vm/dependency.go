@@ -0,0 +1,77 @@
+package vm
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+)
+
+// DependencyNode is a monitor in the graph of dependencies between monitors.
+// Its children are the monitors depending on it.
+type DependencyNode struct {
+	MonitorID db.MonitorID
+	Name      string
+
+	// Subprobes and Level are those of the dependency on the node's parent,
+	// if it has one.
+	Subprobes string
+	Level     state.State
+
+	Children []*DependencyNode
+}
+
+// DependencyGraph loads the monitors that other monitors depend on but that
+// don't depend on any themselves, each with the tree of monitors depending on
+// it.
+func DependencyGraph(DB *db.DB) ([]*DependencyNode, error) {
+	dependencies, err := DB.LoadMonitorDependencies()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	names := make(map[db.MonitorID]string)
+	children := make(map[db.MonitorID][]*db.MonitorDependency)
+	hasParent := make(map[db.MonitorID]bool)
+	for _, d := range dependencies {
+		names[d.ParentID] = d.ParentName
+		children[d.ParentID] = append(children[d.ParentID], d)
+		hasParent[d.MonitorID] = true
+	}
+
+	var roots []*DependencyNode
+	for id, name := range names {
+		if !hasParent[id] {
+			root := &DependencyNode{MonitorID: id, Name: name}
+			root.addChildren(children, map[db.MonitorID]bool{id: true})
+			roots = append(roots, root)
+		}
+	}
+	sort.Slice(roots, func(i, j int) bool {
+		return roots[i].Name < roots[j].Name
+	})
+	return roots, nil
+}
+
+// addChildren adds the monitors depending on n, and those depending on them,
+// skipping any already on the path from the root so a cycle can't recurse
+// forever.
+func (n *DependencyNode) addChildren(children map[db.MonitorID][]*db.MonitorDependency, path map[db.MonitorID]bool) {
+	for _, d := range children[n.MonitorID] {
+		if path[d.MonitorID] {
+			continue
+		}
+		child := &DependencyNode{
+			MonitorID: d.MonitorID,
+			Name:      d.MonitorName,
+			Subprobes: d.Subprobes,
+			Level:     d.Level,
+		}
+		path[d.MonitorID] = true
+		child.addChildren(children, path)
+		delete(path, d.MonitorID)
+		n.Children = append(n.Children, child)
+	}
+}
@@ -0,0 +1,140 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/target"
+)
+
+type EscalationPolicy struct {
+	PolicyID    db.EscalationPolicyID
+	Name        string
+	Description string
+	Steps       []*EscalationStep
+}
+
+func (*EscalationPolicy) ComponentName() string {
+	return "Escalation Policy"
+}
+
+func (ep *EscalationPolicy) Id() int64 {
+	return int64(ep.PolicyID)
+}
+
+func NewEscalationPolicy(tx *db.Tx, id db.EscalationPolicyID) (*EscalationPolicy, error) {
+	policy, err := tx.LoadEscalationPolicy(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if policy == nil {
+		return nil, errors.Errorf("Escalation policy not found: %d", id)
+	}
+
+	ep := newEscalationPolicyFromDB(policy)
+
+	ep.Steps, err = newEscalationSteps(tx, ep.PolicyID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return ep, nil
+}
+
+func newEscalationPolicyFromDB(policy *db.EscalationPolicy) *EscalationPolicy {
+	return &EscalationPolicy{
+		PolicyID:    policy.PolicyID,
+		Name:        policy.Name,
+		Description: policy.Description,
+		Steps:       nil,
+	}
+}
+
+func newEscalationPoliciesFromDB(policies []*db.EscalationPolicy) []*EscalationPolicy {
+	eps := make([]*EscalationPolicy, len(policies))
+	for i, policy := range policies {
+		eps[i] = newEscalationPolicyFromDB(policy)
+	}
+	return eps
+}
+
+func BlankEscalationPolicy() *EscalationPolicy {
+	return &EscalationPolicy{
+		Steps: blankEscalationSteps(),
+	}
+}
+
+func AllEscalationPolicies(tx *db.Tx) ([]*EscalationPolicy, error) {
+	eps, err := tx.LoadEscalationPolicies()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return newEscalationPoliciesFromDB(eps), nil
+}
+
+func (ep *EscalationPolicy) Validate() (errs []string) {
+	if ep.Name == "" {
+		errs = append(errs, fmt.Sprintf("Escalation policy name is required"))
+	} else if len(ep.Name) > 30 {
+		errs = append(errs, fmt.Sprintf("Escalation policy name must be at most 30 characters"))
+	}
+
+	steps := 0
+	for _, es := range ep.Steps {
+		if isDelete(es) {
+			continue
+		}
+		steps++
+		errs = append(errs, es.validate()...)
+	}
+	if steps == 0 {
+		errs = append(errs, "Escalation policy must have at least one step")
+	}
+	return
+}
+
+func (ep *EscalationPolicy) IsCreate() bool {
+	return ep.Id() == 0
+}
+
+// Save saves the policy and its steps, and marks the monitors using it as
+// changed so the daemon picks up the new steps.
+func (ep *EscalationPolicy) Save(tx *db.Tx) error {
+	policy := ep.toDBEscalationPolicy()
+
+	var err error
+	if isCreate(ep) {
+		ep.PolicyID, err = tx.CreateEscalationPolicy(policy)
+	} else {
+		err = tx.UpdateEscalationPolicy(policy)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, es := range ep.Steps {
+		es.setPolicyID(ep.PolicyID)
+		err = es.save(tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	targetJSON, err := target.EscalationPolicyTargetJSON(ep.PolicyID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = tx.TouchMonitorsWithTriggerTarget(target.EscalationPolicyType{}.Id(), targetJSON)
+	return errors.Trace(err)
+}
+
+func (ep *EscalationPolicy) toDBEscalationPolicy() *db.EscalationPolicy {
+	return &db.EscalationPolicy{
+		PolicyID:    ep.PolicyID,
+		Name:        ep.Name,
+		Description: ep.Description,
+	}
+}
@@ -0,0 +1,66 @@
+package vm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yext/revere/target"
+)
+
+func validEscalationStep() *EscalationStep {
+	es := new(EscalationStep)
+	es.Trigger = new(Trigger)
+	es.Trigger.TargetType = targetType.Id()
+	es.Trigger.TargetParams = targetJson
+	es.Delay = 10
+	es.DelayType = "minute"
+	return es
+}
+
+func validEscalationPolicy() *EscalationPolicy {
+	ep := new(EscalationPolicy)
+	ep.Name = "team-oncall"
+	immediately := validEscalationStep()
+	immediately.Delay = 0
+	immediately.DelayType = ""
+	ep.Steps = []*EscalationStep{immediately, validEscalationStep()}
+	return ep
+}
+
+func TestValidEscalationPolicy(t *testing.T) {
+	ep := validEscalationPolicy()
+	if errs := ep.Validate(); errs != nil {
+		t.Errorf("Unexpected errors for valid escalation policy: %v\n", errs)
+	}
+}
+
+func TestInvalidEscalationPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(ep *EscalationPolicy)
+	}{
+		{"blank name", func(ep *EscalationPolicy) { ep.Name = "" }},
+		{"long name", func(ep *EscalationPolicy) { ep.Name = strings.Repeat("a", 31) }},
+		{"no steps", func(ep *EscalationPolicy) { ep.Steps = nil }},
+		{"only deleted steps", func(ep *EscalationPolicy) {
+			for _, es := range ep.Steps {
+				es.Trigger.Delete = true
+			}
+		}},
+		{"negative delay", func(ep *EscalationPolicy) { ep.Steps[1].Delay = -1 }},
+		{"invalid delay type", func(ep *EscalationPolicy) { ep.Steps[1].DelayType = "fortnight" }},
+		{"invalid target", func(ep *EscalationPolicy) { ep.Steps[1].Trigger.TargetParams = "{" }},
+		{"nested escalation policy", func(ep *EscalationPolicy) {
+			ep.Steps[1].Trigger.TargetType = target.EscalationPolicyType{}.Id()
+			ep.Steps[1].Trigger.TargetParams = `{"PolicyID":1}`
+		}},
+	}
+
+	for _, tt := range tests {
+		ep := validEscalationPolicy()
+		tt.modify(ep)
+		if errs := ep.Validate(); errs == nil {
+			t.Errorf("Expected error for %s\n", tt.name)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+	"github.com/yext/revere/target"
+	"github.com/yext/revere/util"
+)
+
+// EscalationStep alerts its trigger's target once an incident has gone
+// unacknowledged for its delay. Only the trigger's target is used.
+type EscalationStep struct {
+	Trigger   *Trigger
+	PolicyID  db.EscalationPolicyID
+	Delay     int64
+	DelayType string
+}
+
+func newEscalationSteps(tx *db.Tx, id db.EscalationPolicyID) ([]*EscalationStep, error) {
+	steps, err := tx.LoadEscalationSteps(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	ess := make([]*EscalationStep, len(steps))
+	for i, step := range steps {
+		t, err := newTriggerFromModel(step.Trigger)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		delay, delayType := util.GetPeriodAndType(step.DelayMilli)
+		ess[i] = &EscalationStep{
+			Trigger:   t,
+			PolicyID:  step.PolicyID,
+			Delay:     delay,
+			DelayType: delayType,
+		}
+	}
+
+	return ess, nil
+}
+
+func BlankEscalationStep() *EscalationStep {
+	return &EscalationStep{
+		Trigger:   BlankTrigger(),
+		DelayType: "minute",
+	}
+}
+
+func blankEscalationSteps() []*EscalationStep {
+	return []*EscalationStep{}
+}
+
+func (es *EscalationStep) Id() int64 {
+	return es.Trigger.Id()
+}
+
+func (es *EscalationStep) IsCreate() bool {
+	return es.Id() == 0
+}
+
+func (es *EscalationStep) IsDelete() bool {
+	return es.Trigger.Delete
+}
+
+func (es *EscalationStep) validate() (errs []string) {
+	if es.Delay < 0 {
+		errs = append(errs, fmt.Sprintf("Invalid delay for escalation step: %d", es.Delay))
+	} else if es.Delay > 0 && util.GetMs(es.Delay, es.DelayType) == 0 {
+		errs = append(errs, fmt.Sprintf("Invalid delay for escalation step: %d %s", es.Delay, es.DelayType))
+	}
+
+	if es.Trigger.TargetType == (target.EscalationPolicyType{}).Id() {
+		errs = append(errs, "Escalation steps cannot target escalation policies")
+		return
+	}
+	return append(errs, es.Trigger.validateTarget()...)
+}
+
+func (es *EscalationStep) save(tx *db.Tx) error {
+	var err error
+	step, err := es.toDBEscalationStep()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isCreate(es) {
+		var id db.TriggerID
+		id, err = tx.CreateEscalationStep(step)
+		es.Trigger.setId(id)
+	} else if isDelete(es) {
+		err = tx.DeleteEscalationStep(step.TriggerID)
+	} else {
+		err = tx.UpdateEscalationStep(step)
+	}
+
+	return errors.Trace(err)
+}
+
+// toDBEscalationStep stores the step's target as a trigger. The policy's own
+// trigger decides when the step applies, so the rest of it is left blank.
+func (es *EscalationStep) toDBEscalationStep() (db.EscalationStep, error) {
+	targetJSON, err := es.Trigger.Target.Serialize()
+	if err != nil {
+		return db.EscalationStep{}, errors.Trace(err)
+	}
+
+	return db.EscalationStep{
+		PolicyID:   es.PolicyID,
+		DelayMilli: util.GetMs(es.Delay, es.DelayType),
+		Trigger: &db.Trigger{
+			TriggerID:  es.Trigger.TriggerID,
+			Level:      state.Normal,
+			TargetType: es.Trigger.TargetType,
+			Target:     types.JSONText(targetJSON),
+		},
+	}, nil
+}
+
+func (es *EscalationStep) setPolicyID(id db.EscalationPolicyID) {
+	es.PolicyID = id
+}
@@ -0,0 +1,73 @@
+package vm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+	"github.com/yext/revere/target"
+)
+
+// FailedAlert is an alert Revere gave up delivering.
+type FailedAlert struct {
+	OutboxID     db.OutboxID
+	MonitorID    db.MonitorID
+	MonitorName  string
+	SubprobeID   db.SubprobeID
+	SubprobeName string
+	NewState     state.State
+	TargetType   string
+	Attempts     int32
+	LastError    string
+	Created      time.Time
+	Failed       time.Time
+}
+
+func AllFailedAlerts(DB *db.DB) ([]*FailedAlert, error) {
+	outboxAlerts, err := DB.LoadFailedOutboxAlerts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	targetTypes := make(map[db.TargetType]string)
+	for _, tt := range target.AllTargets() {
+		targetTypes[tt.Id()] = tt.Name()
+	}
+
+	fas := make([]*FailedAlert, len(outboxAlerts))
+	for i, o := range outboxAlerts {
+		fa := &FailedAlert{
+			OutboxID:   o.OutboxID,
+			MonitorID:  o.MonitorID,
+			SubprobeID: o.SubprobeID,
+			TargetType: targetTypes[o.TargetType],
+			Attempts:   o.Attempts,
+			LastError:  o.LastError,
+			Created:    o.Created,
+			Failed:     *o.Failed,
+		}
+		if fa.TargetType == "" {
+			fa.TargetType = fmt.Sprintf("Unknown (%d)", o.TargetType)
+		}
+
+		// The alert is shown as it was sent, even if the monitor has
+		// been renamed since.
+		if a, err := target.DecodeAlert(o.Alert); err == nil {
+			fa.MonitorName = a.MonitorName
+			fa.SubprobeName = a.SubprobeName
+			fa.NewState = a.NewState
+		}
+		fas[i] = fa
+	}
+
+	return fas, nil
+}
+
+// RetryFailedAlert puts a failed alert back in the outbox, to be delivered by
+// the daemon as though it were new.
+func RetryFailedAlert(DB *db.DB, id db.OutboxID) error {
+	return errors.Trace(DB.RetryOutboxAlert(id, time.Now()))
+}
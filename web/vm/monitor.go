@@ -8,6 +8,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/yext/revere/db"
 	"github.com/yext/revere/probe"
+	"github.com/yext/revere/util"
 )
 
 type Monitor struct {
@@ -25,6 +26,23 @@ type Monitor struct {
 	Probe    probe.VM
 	Triggers []*MonitorTrigger
 	Labels   []*MonitorLabel
+
+	// Dependencies are the monitor's dependencies on its parents, and
+	// Dependents are other monitors' dependencies on it.
+	Dependencies []*MonitorDependency
+	Dependents   []*MonitorDependency
+
+	// ConfirmReadings, ConfirmPeriod and ConfirmPeriodType are how long
+	// subprobes must read a worse state before it is confirmed.
+	ConfirmReadings   int16
+	ConfirmPeriod     int64
+	ConfirmPeriodType string
+
+	// FlapChanges, FlapPeriod and FlapPeriodType are how many state changes
+	// within how long mark a subprobe as flapping.
+	FlapChanges    int16
+	FlapPeriod     int64
+	FlapPeriodType string
 }
 
 func (*Monitor) ComponentName() string {
@@ -77,7 +95,12 @@ func newMonitorFromDB(monitor *db.Monitor, tx *db.Tx) (*Monitor, error) {
 		Probe:       nil,
 		Triggers:    nil,
 		Labels:      nil,
+
+		ConfirmReadings: monitor.ConfirmReadings,
+		FlapChanges:     monitor.FlapChanges,
 	}
+	m.ConfirmPeriod, m.ConfirmPeriodType = util.GetPeriodAndType(monitor.ConfirmMilli)
+	m.FlapPeriod, m.FlapPeriodType = util.GetPeriodAndType(monitor.FlapMilli)
 	m.Probe, err = probe.LoadFromDB(monitor.ProbeType, string(monitor.Probe), tx)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -103,6 +126,8 @@ func BlankMonitor() (*Monitor, error) {
 	m := &Monitor{}
 	m.Triggers = blankMonitorTriggers()
 	m.Labels = blankMonitorLabels()
+	m.Dependencies = blankMonitorDependencies()
+	m.Dependents = blankMonitorDependencies()
 	m.Probe, err = probe.Default()
 	m.ProbeType = m.Probe.Id()
 
@@ -178,6 +203,14 @@ func (m *Monitor) loadComponents(tx *db.Tx) error {
 		return errors.Trace(err)
 	}
 	m.Labels, err = newMonitorLabels(tx, m.MonitorID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m.Dependencies, err = newMonitorDependencies(tx, m.MonitorID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m.Dependents, err = newMonitorDependents(tx, m.MonitorID)
 	return errors.Trace(err)
 }
 
@@ -193,6 +226,40 @@ func (m *Monitor) Validate(DB *db.DB) (errs []string) {
 	}
 	errs = append(errs, m.Probe.Validate()...)
 
+	if c, ok := m.Probe.(probe.CompositeProbe); ok {
+		selfReference := false
+		for _, id := range c.MonitorIDs {
+			if id == m.MonitorID {
+				selfReference = true
+				errs = append(errs, "A composite monitor cannot depend on itself")
+			} else if !DB.IsExistingMonitor(id) {
+				errs = append(errs, fmt.Sprintf("Monitor %d does not exist", id))
+			}
+		}
+		if !selfReference && m.MonitorID != 0 {
+			cycle, err := probe.IsCompositeCycle(DB, m.MonitorID, c.MonitorIDs)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("Unable to check composite monitors: %s", err.Error()))
+			} else if cycle {
+				errs = append(errs, "A composite monitor cannot depend on itself through other composite monitors")
+			}
+		}
+	}
+
+	if m.ConfirmReadings < 0 {
+		errs = append(errs, "Readings to confirm a state must not be negative")
+	}
+	if m.ConfirmPeriod < 0 {
+		errs = append(errs, "Time to confirm a state must not be negative")
+	}
+
+	if m.FlapChanges < 0 {
+		errs = append(errs, "State changes to mark a subprobe flapping must not be negative")
+	}
+	if m.FlapChanges > 0 && util.GetMs(m.FlapPeriod, m.FlapPeriodType) <= 0 {
+		errs = append(errs, "Flap detection requires a positive window")
+	}
+
 	for _, mt := range m.Triggers {
 		errs = append(errs, mt.validate(DB)...)
 	}
@@ -201,6 +268,18 @@ func (m *Monitor) Validate(DB *db.DB) (errs []string) {
 	for _, ml := range m.Labels {
 		errs = append(errs, ml.validate(DB)...)
 	}
+
+	for _, md := range m.Dependencies {
+		errs = append(errs, md.validate(DB)...)
+	}
+	if !m.IsCreate() && len(m.Dependencies) > 0 {
+		existing, err := DB.LoadMonitorDependencies()
+		if err != nil {
+			errs = append(errs, "Unable to load monitor dependencies")
+		} else if dependencyCycle(m.MonitorID, m.Dependencies, existing) {
+			errs = append(errs, "Monitor dependencies cannot form a cycle")
+		}
+	}
 	return
 }
 
@@ -223,6 +302,9 @@ func (m *Monitor) Save(tx *db.Tx) error {
 		for _, label := range m.Labels {
 			label.setMonitorID(m.MonitorID)
 		}
+		for _, dependency := range m.Dependencies {
+			dependency.setMonitorID(m.MonitorID)
+		}
 	} else {
 		err = tx.UpdateMonitor(monitor)
 	}
@@ -243,6 +325,13 @@ func (m *Monitor) Save(tx *db.Tx) error {
 			return errors.Trace(err)
 		}
 	}
+
+	for _, d := range m.Dependencies {
+		err = d.save(tx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return nil
 }
 
@@ -265,5 +354,10 @@ func (m *Monitor) toDBMonitor() (*db.Monitor, error) {
 		Changed:  m.Changed,
 		Version:  m.Version,
 		Archived: m.Archived,
+
+		ConfirmReadings: m.ConfirmReadings,
+		ConfirmMilli:    util.GetMs(m.ConfirmPeriod, m.ConfirmPeriodType),
+		FlapChanges:     m.FlapChanges,
+		FlapMilli:       util.GetMs(m.FlapPeriod, m.FlapPeriodType),
 	}, nil
 }
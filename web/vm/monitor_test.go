@@ -61,3 +61,47 @@ func TestValidTriggerSubprobe(t *testing.T) {
 		}
 	}
 }
+
+func TestInvalidMonitorConfirmation(t *testing.T) {
+	monitor := validMonitor()
+	testDB := new(db.DB)
+	monitor.ConfirmReadings = -1
+	monitor.ConfirmPeriod = -5
+	monitor.ConfirmPeriodType = "minute"
+
+	errs := monitor.Validate(testDB)
+	for _, expected := range []string{
+		"Readings to confirm a state must not be negative",
+		"Time to confirm a state must not be negative",
+	} {
+		if !containsError(errs, expected) {
+			t.Errorf("Expected error: %s\n", expected)
+		}
+	}
+}
+
+func TestInvalidMonitorFlapDetection(t *testing.T) {
+	monitor := validMonitor()
+	testDB := new(db.DB)
+	monitor.FlapChanges = 5
+
+	expected := "Flap detection requires a positive window"
+	if errs := monitor.Validate(testDB); !containsError(errs, expected) {
+		t.Errorf("Expected error: %s\n", expected)
+	}
+
+	monitor.FlapPeriod = 1
+	monitor.FlapPeriodType = "hour"
+	if errs := monitor.Validate(testDB); containsError(errs, expected) {
+		t.Errorf("Unexpected error: %s\n", expected)
+	}
+}
+
+func containsError(errs []string, expected string) bool {
+	for _, err := range errs {
+		if err == expected {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+)
+
+// MonitorDependency is a monitor's dependency on a parent monitor. While any
+// of the parent's subprobes matching Subprobes is at or above Level, the
+// monitor's alerts are suppressed.
+type MonitorDependency struct {
+	MonitorID   db.MonitorID
+	MonitorName string
+	ParentID    db.MonitorID
+	ParentName  string
+	Subprobes   string
+	Level       state.State
+	LevelText   string
+	Create      bool
+	Delete      bool
+}
+
+func (md *MonitorDependency) Id() int64 {
+	return int64(md.ParentID)
+}
+
+func newMonitorDependencies(tx *db.Tx, id db.MonitorID) ([]*MonitorDependency, error) {
+	dependencies, err := tx.LoadDependenciesForMonitor(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newMonitorDependenciesFromDB(dependencies), nil
+}
+
+func newMonitorDependents(tx *db.Tx, id db.MonitorID) ([]*MonitorDependency, error) {
+	dependents, err := tx.LoadDependentsOfMonitor(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newMonitorDependenciesFromDB(dependents), nil
+}
+
+func newMonitorDependencyFromDB(dependency *db.MonitorDependency) *MonitorDependency {
+	return &MonitorDependency{
+		MonitorID:   dependency.MonitorID,
+		MonitorName: dependency.MonitorName,
+		ParentID:    dependency.ParentID,
+		ParentName:  dependency.ParentName,
+		Subprobes:   dependency.Subprobes,
+		Level:       dependency.Level,
+		LevelText:   dependency.Level.String(),
+	}
+}
+
+func newMonitorDependenciesFromDB(dependencies []*db.MonitorDependency) []*MonitorDependency {
+	mds := make([]*MonitorDependency, len(dependencies))
+	for i, dependency := range dependencies {
+		mds[i] = newMonitorDependencyFromDB(dependency)
+	}
+	return mds
+}
+
+func blankMonitorDependencies() []*MonitorDependency {
+	return []*MonitorDependency{}
+}
+
+func (md *MonitorDependency) IsCreate() bool {
+	return md.Create
+}
+
+func (md *MonitorDependency) IsDelete() bool {
+	return md.Delete
+}
+
+func (md *MonitorDependency) validate(DB *db.DB) (errs []string) {
+	if isDelete(md) {
+		return
+	}
+	if md.ParentID == 0 {
+		errs = append(errs, "A dependency requires a monitor to depend on")
+	} else if md.ParentID == md.MonitorID {
+		errs = append(errs, "A monitor cannot depend on itself")
+	} else if !DB.IsExistingMonitor(md.ParentID) {
+		errs = append(errs, fmt.Sprintf("Monitor %d does not exist", md.ParentID))
+	}
+	if err := validateSubprobeRegex(md.Subprobes); err != nil {
+		errs = append(errs, err.Error())
+	}
+	level, err := state.FromString(md.LevelText)
+	if err != nil || level == state.Normal {
+		errs = append(errs, fmt.Sprintf("Invalid state for dependency: %s", md.LevelText))
+	}
+	md.Level = level
+	return
+}
+
+func (md *MonitorDependency) save(tx *db.Tx) error {
+	dependency := db.MonitorDependency{
+		MonitorID: md.MonitorID,
+		ParentID:  md.ParentID,
+		Subprobes: md.Subprobes,
+		Level:     md.Level,
+	}
+	var err error
+	if isCreate(md) {
+		err = tx.CreateMonitorDependency(dependency)
+	} else if isDelete(md) {
+		err = tx.DeleteMonitorDependency(dependency)
+	} else {
+		err = tx.UpdateMonitorDependency(dependency)
+	}
+
+	return errors.Trace(err)
+}
+
+func (md *MonitorDependency) setMonitorID(id db.MonitorID) {
+	md.MonitorID = id
+}
+
+// dependencyCycle returns whether giving monitor id the parents in
+// dependencies would make it depend on itself, given the existing
+// dependencies between all monitors.
+func dependencyCycle(id db.MonitorID, dependencies []*MonitorDependency, existing []*db.MonitorDependency) bool {
+	parents := make(map[db.MonitorID][]db.MonitorID)
+	for _, d := range existing {
+		if d.MonitorID != id {
+			parents[d.MonitorID] = append(parents[d.MonitorID], d.ParentID)
+		}
+	}
+	for _, d := range dependencies {
+		if !isDelete(d) {
+			parents[id] = append(parents[id], d.ParentID)
+		}
+	}
+
+	visited := make(map[db.MonitorID]bool)
+	var reaches func(from db.MonitorID) bool
+	reaches = func(from db.MonitorID) bool {
+		for _, parent := range parents[from] {
+			if parent == id {
+				return true
+			}
+			if visited[parent] {
+				continue
+			}
+			visited[parent] = true
+			if reaches(parent) {
+				return true
+			}
+		}
+		return false
+	}
+	return reaches(id)
+}
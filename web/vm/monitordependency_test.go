@@ -0,0 +1,54 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/yext/revere/db"
+)
+
+func TestMonitorDependencyCycle(t *testing.T) {
+	// 2 depends on 1, and 3 depends on 2.
+	existing := []*db.MonitorDependency{
+		{MonitorID: 2, ParentID: 1},
+		{MonitorID: 3, ParentID: 2},
+	}
+
+	for _, c := range []struct {
+		id           db.MonitorID
+		dependencies []*MonitorDependency
+		cycle        bool
+	}{
+		{1, []*MonitorDependency{{ParentID: 3}}, true},
+		{1, []*MonitorDependency{{ParentID: 2}}, true},
+		{1, []*MonitorDependency{{ParentID: 4}}, false},
+		{1, []*MonitorDependency{{ParentID: 3, Delete: true}}, false},
+		{3, []*MonitorDependency{{ParentID: 1}}, false},
+		// 2's saved dependency on 1 is replaced by the edited ones.
+		{2, []*MonitorDependency{{ParentID: 3}}, true},
+	} {
+		if cycle := dependencyCycle(c.id, c.dependencies, existing); cycle != c.cycle {
+			t.Errorf("Expected cycle %t for monitor %d depending on %d, got %t\n",
+				c.cycle, c.id, c.dependencies[0].ParentID, cycle)
+		}
+	}
+}
+
+func TestInvalidMonitorDependency(t *testing.T) {
+	testDB := new(db.DB)
+	md := &MonitorDependency{MonitorID: 1, ParentID: 1, LevelText: "Normal"}
+
+	errs := md.validate(testDB)
+	for _, expected := range []string{
+		"A monitor cannot depend on itself",
+		"Invalid state for dependency: Normal",
+	} {
+		if !containsError(errs, expected) {
+			t.Errorf("Expected error: %s\n", expected)
+		}
+	}
+
+	md.Delete = true
+	if errs := md.validate(testDB); errs != nil {
+		t.Errorf("Unexpected errors for deleted dependency: %v\n", errs)
+	}
+}
@@ -8,12 +8,13 @@ import (
 type ActiveIssues struct {
 	labels        []*vm.Label
 	subprobes     []*vm.Subprobe
+	pending       []*vm.Subprobe
 	monitorLabels map[db.MonitorID][]*vm.MonitorLabel
 	subs          []Renderable
 }
 
-func NewActiveIssues(ss []*vm.Subprobe, ls []*vm.Label, mls map[db.MonitorID][]*vm.MonitorLabel) *ActiveIssues {
-	return &ActiveIssues{ls, ss, mls, nil}
+func NewActiveIssues(ss []*vm.Subprobe, pending []*vm.Subprobe, ls []*vm.Label, mls map[db.MonitorID][]*vm.MonitorLabel) *ActiveIssues {
+	return &ActiveIssues{ls, ss, pending, mls, nil}
 }
 
 func (ai *ActiveIssues) name() string {
@@ -28,6 +29,7 @@ func (ai *ActiveIssues) data() interface{} {
 	return map[string]interface{}{
 		"Labels":        ai.labels,
 		"Subprobes":     ai.subprobes,
+		"Pending":       ai.pending,
 		"MonitorLabels": ai.monitorLabels,
 	}
 }
@@ -0,0 +1,49 @@
+package renderables
+
+import (
+	"github.com/yext/revere/web/vm"
+)
+
+type DependenciesIndex struct {
+	graph []*vm.DependencyNode
+	subs  []Renderable
+}
+
+func NewDependenciesIndex(graph []*vm.DependencyNode) *DependenciesIndex {
+	di := new(DependenciesIndex)
+	di.graph = graph
+
+	return di
+}
+
+func (di *DependenciesIndex) name() string {
+	return "DependenciesIndex"
+}
+
+func (di *DependenciesIndex) template() string {
+	return "dependencies-index.html"
+}
+
+func (di *DependenciesIndex) data() interface{} {
+	return di.graph
+}
+
+func (di *DependenciesIndex) scripts() []string {
+	return nil
+}
+
+func (di *DependenciesIndex) breadcrumbs() []vm.Breadcrumb {
+	return vm.DependenciesIndexBcs()
+}
+
+func (di *DependenciesIndex) subRenderables() []Renderable {
+	return nil
+}
+
+func (di *DependenciesIndex) renderPropagate() (*renderResult, error) {
+	return renderPropagate(di)
+}
+
+func (di *DependenciesIndex) aggregatePipelineData(parent *renderResult, child *renderResult) {
+	aggregatePipelineDataArray(parent, child)
+}
@@ -0,0 +1,53 @@
+package renderables
+
+import (
+	"github.com/yext/revere/web/vm"
+)
+
+type EscalationPolicyEdit struct {
+	viewmodel *vm.EscalationPolicy
+	subs      []Renderable
+}
+
+func NewEscalationPolicyEdit(ep *vm.EscalationPolicy) *EscalationPolicyEdit {
+	epe := EscalationPolicyEdit{}
+	epe.viewmodel = ep
+	epe.subs = []Renderable{
+		NewEscalationStepsEdit(ep.Steps),
+	}
+	return &epe
+}
+
+func (epe *EscalationPolicyEdit) name() string {
+	return "EscalationPolicy"
+}
+
+func (epe *EscalationPolicyEdit) template() string {
+	return "escalation-policies-edit.html"
+}
+
+func (epe *EscalationPolicyEdit) data() interface{} {
+	return epe.viewmodel
+}
+
+func (epe *EscalationPolicyEdit) scripts() []string {
+	return []string{
+		"escalation-policies-edit.js",
+	}
+}
+
+func (epe *EscalationPolicyEdit) breadcrumbs() []vm.Breadcrumb {
+	return []vm.Breadcrumb{}
+}
+
+func (epe *EscalationPolicyEdit) subRenderables() []Renderable {
+	return epe.subs
+}
+
+func (epe *EscalationPolicyEdit) renderPropagate() (*renderResult, error) {
+	return renderPropagate(epe)
+}
+
+func (epe *EscalationPolicyEdit) aggregatePipelineData(parent *renderResult, child *renderResult) {
+	aggregatePipelineDataMap(parent, child)
+}
@@ -0,0 +1,56 @@
+package renderables
+
+import (
+	"github.com/yext/revere/web/vm"
+)
+
+type EscalationPolicyView struct {
+	policy     *vm.EscalationPolicy
+	subs       []Renderable
+	saveStatus string
+}
+
+func NewEscalationPolicyView(ep *vm.EscalationPolicy, saveStatus []byte) *EscalationPolicyView {
+	epv := EscalationPolicyView{}
+	epv.policy = ep
+	epv.subs = []Renderable{
+		NewEscalationStepsView(ep.Steps),
+	}
+	epv.saveStatus = string(saveStatus)
+	return &epv
+}
+
+func (epv *EscalationPolicyView) name() string {
+	return "EscalationPolicy"
+}
+
+func (epv *EscalationPolicyView) template() string {
+	return "escalation-policies-view.html"
+}
+
+func (epv *EscalationPolicyView) data() interface{} {
+	return map[string]interface{}{
+		"EscalationPolicy": epv.policy,
+		"SaveStatus":       epv.saveStatus,
+	}
+}
+
+func (epv *EscalationPolicyView) scripts() []string {
+	return nil
+}
+
+func (epv *EscalationPolicyView) breadcrumbs() []vm.Breadcrumb {
+	return vm.EscalationPolicyViewBcs(epv.policy.Name, epv.policy.Id())
+}
+
+func (epv *EscalationPolicyView) subRenderables() []Renderable {
+	return epv.subs
+}
+
+func (epv *EscalationPolicyView) renderPropagate() (*renderResult, error) {
+	return renderPropagate(epv)
+}
+
+func (epv *EscalationPolicyView) aggregatePipelineData(parent *renderResult, child *renderResult) {
+	aggregatePipelineDataMap(parent, child)
+}
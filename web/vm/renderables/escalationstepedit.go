@@ -0,0 +1,53 @@
+package renderables
+
+import (
+	"github.com/yext/revere/web/vm"
+)
+
+type EscalationStepEdit struct {
+	step *vm.EscalationStep
+	subs []Renderable
+}
+
+func NewEscalationStepEdit(es *vm.EscalationStep) *EscalationStepEdit {
+	ese := EscalationStepEdit{}
+	ese.step = es
+	ese.subs = []Renderable{
+		NewTargetEdit(es.Trigger.Target),
+	}
+	return &ese
+}
+
+func (ese *EscalationStepEdit) name() string {
+	return "Step"
+}
+
+func (ese *EscalationStepEdit) template() string {
+	return "partials/escalation-step-edit.html"
+}
+
+func (ese *EscalationStepEdit) data() interface{} {
+	return ese.step
+}
+
+func (ese *EscalationStepEdit) scripts() []string {
+	return []string{
+		"trigger-edit.js",
+	}
+}
+
+func (ese *EscalationStepEdit) breadcrumbs() []vm.Breadcrumb {
+	return nil
+}
+
+func (ese *EscalationStepEdit) subRenderables() []Renderable {
+	return ese.subs
+}
+
+func (ese *EscalationStepEdit) renderPropagate() (*renderResult, error) {
+	return renderPropagate(ese)
+}
+
+func (ese *EscalationStepEdit) aggregatePipelineData(parent *renderResult, child *renderResult) {
+	aggregatePipelineDataMap(parent, child)
+}
@@ -0,0 +1,54 @@
+package renderables
+
+import (
+	"github.com/yext/revere/web/vm"
+)
+
+type EscalationStepsEdit struct {
+	subs []Renderable
+}
+
+func NewEscalationStepsEdit(ess []*vm.EscalationStep) *EscalationStepsEdit {
+	esse := EscalationStepsEdit{}
+	esse.subs = []Renderable{
+		NewEscalationStepEdit(vm.BlankEscalationStep()),
+	}
+	for _, step := range ess {
+		esse.subs = append(esse.subs, NewEscalationStepEdit(step))
+	}
+	return &esse
+}
+
+func (esse *EscalationStepsEdit) name() string {
+	return "Steps"
+}
+
+func (esse *EscalationStepsEdit) template() string {
+	return "partials/escalation-steps-edit.html"
+}
+
+func (esse *EscalationStepsEdit) data() interface{} {
+	return nil
+}
+
+func (esse *EscalationStepsEdit) scripts() []string {
+	return []string{
+		"triggers-edit.js",
+	}
+}
+
+func (esse *EscalationStepsEdit) breadcrumbs() []vm.Breadcrumb {
+	return nil
+}
+
+func (esse *EscalationStepsEdit) subRenderables() []Renderable {
+	return esse.subs
+}
+
+func (esse *EscalationStepsEdit) renderPropagate() (*renderResult, error) {
+	return renderPropagate(esse)
+}
+
+func (esse *EscalationStepsEdit) aggregatePipelineData(parent *renderResult, child *renderResult) {
+	aggregatePipelineDataArray(parent, child)
+}
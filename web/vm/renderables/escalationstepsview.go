@@ -0,0 +1,49 @@
+package renderables
+
+import (
+	"github.com/yext/revere/web/vm"
+)
+
+type EscalationStepsView struct {
+	subs []Renderable
+}
+
+func NewEscalationStepsView(ess []*vm.EscalationStep) *EscalationStepsView {
+	essv := EscalationStepsView{}
+	for _, step := range ess {
+		essv.subs = append(essv.subs, NewEscalationStepView(step))
+	}
+	return &essv
+}
+
+func (essv *EscalationStepsView) name() string {
+	return "Steps"
+}
+
+func (essv *EscalationStepsView) template() string {
+	return "partials/escalation-steps-view.html"
+}
+
+func (essv *EscalationStepsView) data() interface{} {
+	return nil
+}
+
+func (essv *EscalationStepsView) scripts() []string {
+	return nil
+}
+
+func (essv *EscalationStepsView) breadcrumbs() []vm.Breadcrumb {
+	return nil
+}
+
+func (essv *EscalationStepsView) subRenderables() []Renderable {
+	return essv.subs
+}
+
+func (essv *EscalationStepsView) renderPropagate() (*renderResult, error) {
+	return renderPropagate(essv)
+}
+
+func (essv *EscalationStepsView) aggregatePipelineData(parent *renderResult, child *renderResult) {
+	aggregatePipelineDataArray(parent, child)
+}
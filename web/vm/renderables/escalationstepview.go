@@ -0,0 +1,51 @@
+package renderables
+
+import (
+	"github.com/yext/revere/web/vm"
+)
+
+type EscalationStepView struct {
+	step *vm.EscalationStep
+	subs []Renderable
+}
+
+func NewEscalationStepView(es *vm.EscalationStep) *EscalationStepView {
+	esv := EscalationStepView{}
+	esv.step = es
+	esv.subs = []Renderable{
+		NewTargetView(es.Trigger.Target),
+	}
+	return &esv
+}
+
+func (esv *EscalationStepView) name() string {
+	return "Step"
+}
+
+func (esv *EscalationStepView) template() string {
+	return "partials/escalation-step-view.html"
+}
+
+func (esv *EscalationStepView) data() interface{} {
+	return esv.step
+}
+
+func (esv *EscalationStepView) scripts() []string {
+	return nil
+}
+
+func (esv *EscalationStepView) breadcrumbs() []vm.Breadcrumb {
+	return nil
+}
+
+func (esv *EscalationStepView) subRenderables() []Renderable {
+	return esv.subs
+}
+
+func (esv *EscalationStepView) renderPropagate() (*renderResult, error) {
+	return renderPropagate(esv)
+}
+
+func (esv *EscalationStepView) aggregatePipelineData(parent *renderResult, child *renderResult) {
+	aggregatePipelineDataMap(parent, child)
+}
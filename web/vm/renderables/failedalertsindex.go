@@ -0,0 +1,49 @@
+package renderables
+
+import (
+	"github.com/yext/revere/web/vm"
+)
+
+type FailedAlertsIndex struct {
+	failedAlerts []*vm.FailedAlert
+	subs         []Renderable
+}
+
+func NewFailedAlertsIndex(fas []*vm.FailedAlert) *FailedAlertsIndex {
+	fai := new(FailedAlertsIndex)
+	fai.failedAlerts = fas
+
+	return fai
+}
+
+func (fai *FailedAlertsIndex) name() string {
+	return "FailedAlertsIndex"
+}
+
+func (fai *FailedAlertsIndex) template() string {
+	return "failed-alerts-index.html"
+}
+
+func (fai *FailedAlertsIndex) data() interface{} {
+	return fai.failedAlerts
+}
+
+func (fai *FailedAlertsIndex) scripts() []string {
+	return nil
+}
+
+func (fai *FailedAlertsIndex) breadcrumbs() []vm.Breadcrumb {
+	return vm.FailedAlertsIndexBcs()
+}
+
+func (fai *FailedAlertsIndex) subRenderables() []Renderable {
+	return nil
+}
+
+func (fai *FailedAlertsIndex) renderPropagate() (*renderResult, error) {
+	return renderPropagate(fai)
+}
+
+func (fai *FailedAlertsIndex) aggregatePipelineData(parent *renderResult, child *renderResult) {
+	aggregatePipelineDataArray(parent, child)
+}
@@ -0,0 +1,60 @@
+package renderables
+
+import (
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/web/vm"
+)
+
+type MonitorDependenciesEdit struct {
+	monitorID    db.MonitorID
+	dependencies []*vm.MonitorDependency
+	allMonitors  []*vm.Monitor
+	subs         []Renderable
+}
+
+func NewMonitorDependenciesEdit(id db.MonitorID, mds []*vm.MonitorDependency, ms []*vm.Monitor) *MonitorDependenciesEdit {
+	mde := new(MonitorDependenciesEdit)
+	mde.monitorID = id
+	mde.dependencies = mds
+	mde.allMonitors = ms
+	return mde
+}
+
+func (mde *MonitorDependenciesEdit) name() string {
+	return "MonitorDependencies"
+}
+
+func (mde *MonitorDependenciesEdit) template() string {
+	return "partials/monitor-dependencies-edit.html"
+}
+
+func (mde *MonitorDependenciesEdit) data() interface{} {
+	return map[string]interface{}{
+		"MonitorID":    mde.monitorID,
+		"Dependencies": mde.dependencies,
+		"AllMonitors":  mde.allMonitors,
+	}
+}
+
+func (mde *MonitorDependenciesEdit) scripts() []string {
+	return []string{
+		"component-list-edit.js",
+		"monitor-dependencies-edit.js",
+	}
+}
+
+func (mde *MonitorDependenciesEdit) breadcrumbs() []vm.Breadcrumb {
+	return nil
+}
+
+func (mde *MonitorDependenciesEdit) subRenderables() []Renderable {
+	return mde.subs
+}
+
+func (mde *MonitorDependenciesEdit) renderPropagate() (*renderResult, error) {
+	return renderPropagate(mde)
+}
+
+func (mde *MonitorDependenciesEdit) aggregatePipelineData(parent *renderResult, child *renderResult) {
+	aggregatePipelineDataMap(parent, child)
+}
@@ -0,0 +1,53 @@
+package renderables
+
+import (
+	"github.com/yext/revere/web/vm"
+)
+
+type MonitorDependenciesView struct {
+	dependencies []*vm.MonitorDependency
+	dependents   []*vm.MonitorDependency
+	subs         []Renderable
+}
+
+func NewMonitorDependenciesView(mds []*vm.MonitorDependency, dependents []*vm.MonitorDependency) *MonitorDependenciesView {
+	mdv := new(MonitorDependenciesView)
+	mdv.dependencies = mds
+	mdv.dependents = dependents
+	return mdv
+}
+
+func (mdv *MonitorDependenciesView) name() string {
+	return "MonitorDependencies"
+}
+
+func (mdv *MonitorDependenciesView) template() string {
+	return "partials/monitor-dependencies-view.html"
+}
+
+func (mdv *MonitorDependenciesView) data() interface{} {
+	return map[string]interface{}{
+		"Dependencies": mdv.dependencies,
+		"Dependents":   mdv.dependents,
+	}
+}
+
+func (mdv *MonitorDependenciesView) scripts() []string {
+	return nil
+}
+
+func (mdv *MonitorDependenciesView) breadcrumbs() []vm.Breadcrumb {
+	return nil
+}
+
+func (mdv *MonitorDependenciesView) subRenderables() []Renderable {
+	return mdv.subs
+}
+
+func (mdv *MonitorDependenciesView) renderPropagate() (*renderResult, error) {
+	return renderPropagate(mdv)
+}
+
+func (mdv *MonitorDependenciesView) aggregatePipelineData(parent *renderResult, child *renderResult) {
+	aggregatePipelineDataMap(parent, child)
+}
@@ -7,13 +7,14 @@ type MonitorEdit struct {
 	subs      []Renderable
 }
 
-func NewMonitorEdit(m *vm.Monitor, ls []*vm.Label) *MonitorEdit {
+func NewMonitorEdit(m *vm.Monitor, ls []*vm.Label, ms []*vm.Monitor) *MonitorEdit {
 	me := MonitorEdit{}
 	me.viewmodel = m
 	me.subs = []Renderable{
 		NewProbeEdit(m.Probe),
 		NewMonitorTriggersEdit(m.Triggers),
 		NewMonitorLabelsEdit(m.Labels, ls),
+		NewMonitorDependenciesEdit(m.MonitorID, m.Dependencies, ms),
 	}
 	return &me
 }
@@ -17,6 +17,7 @@ func NewMonitorView(m *vm.Monitor, saveStatus []byte) *MonitorView {
 		NewProbeView(m.Probe),
 		NewMonitorTriggersView(m.Triggers),
 		NewMonitorLabelsView(m.Labels),
+		NewMonitorDependenciesView(m.Dependencies, m.Dependents),
 	}
 	mv.saveStatus = string(saveStatus)
 	return &mv
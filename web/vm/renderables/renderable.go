@@ -95,6 +95,17 @@ func RenderPartial(r Renderable) (template.HTML, error) {
 	return template.HTML(fmt.Sprintf("%s", result.data["_Render"])), nil
 }
 
+// RenderPartialWithScripts is like RenderPartial, but also returns the paths of
+// the scripts the HTML uses, for pages that load it after they are rendered.
+func RenderPartialWithScripts(r Renderable) (template.HTML, []string, error) {
+	result, err := renderPropagateImmediate(r)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+
+	return template.HTML(fmt.Sprintf("%s", result.data["_Render"])), prepareScripts(result.scripts), nil
+}
+
 func renderPropagate(r Renderable) (*renderResult, error) {
 	parent := newRenderResult(r)
 
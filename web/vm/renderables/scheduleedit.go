@@ -0,0 +1,51 @@
+package renderables
+
+import (
+	"github.com/yext/revere/web/vm"
+)
+
+type ScheduleEdit struct {
+	viewmodel *vm.Schedule
+	subs      []Renderable
+}
+
+func NewScheduleEdit(s *vm.Schedule) *ScheduleEdit {
+	se := ScheduleEdit{}
+	se.viewmodel = s
+	return &se
+}
+
+func (se *ScheduleEdit) name() string {
+	return "Schedule"
+}
+
+func (se *ScheduleEdit) template() string {
+	return "schedules-edit.html"
+}
+
+func (se *ScheduleEdit) data() interface{} {
+	return se.viewmodel
+}
+
+func (se *ScheduleEdit) scripts() []string {
+	return []string{
+		"component-list-edit.js",
+		"schedules-edit.js",
+	}
+}
+
+func (se *ScheduleEdit) breadcrumbs() []vm.Breadcrumb {
+	return []vm.Breadcrumb{}
+}
+
+func (se *ScheduleEdit) subRenderables() []Renderable {
+	return se.subs
+}
+
+func (se *ScheduleEdit) renderPropagate() (*renderResult, error) {
+	return renderPropagate(se)
+}
+
+func (se *ScheduleEdit) aggregatePipelineData(parent *renderResult, child *renderResult) {
+	aggregatePipelineDataMap(parent, child)
+}
@@ -0,0 +1,48 @@
+package renderables
+
+import (
+	"github.com/yext/revere/web/vm"
+)
+
+type SchedulesIndex struct {
+	schedules []*vm.Schedule
+	subs      []Renderable
+}
+
+func NewSchedulesIndex(ss []*vm.Schedule) *SchedulesIndex {
+	si := new(SchedulesIndex)
+	si.schedules = ss
+	return si
+}
+
+func (si *SchedulesIndex) name() string {
+	return "SchedulesIndex"
+}
+
+func (si *SchedulesIndex) template() string {
+	return "schedules-index.html"
+}
+
+func (si *SchedulesIndex) data() interface{} {
+	return si.schedules
+}
+
+func (si *SchedulesIndex) scripts() []string {
+	return nil
+}
+
+func (si *SchedulesIndex) breadcrumbs() []vm.Breadcrumb {
+	return vm.ScheduleIndexBcs()
+}
+
+func (si *SchedulesIndex) subRenderables() []Renderable {
+	return nil
+}
+
+func (si *SchedulesIndex) renderPropagate() (*renderResult, error) {
+	return renderPropagate(si)
+}
+
+func (si *SchedulesIndex) aggregatePipelineData(parent *renderResult, child *renderResult) {
+	aggregatePipelineDataArray(parent, child)
+}
@@ -0,0 +1,53 @@
+package renderables
+
+import (
+	"github.com/yext/revere/web/vm"
+)
+
+type ScheduleView struct {
+	schedule   *vm.Schedule
+	subs       []Renderable
+	saveStatus string
+}
+
+func NewScheduleView(s *vm.Schedule, saveStatus []byte) *ScheduleView {
+	sv := ScheduleView{}
+	sv.schedule = s
+	sv.saveStatus = string(saveStatus)
+	return &sv
+}
+
+func (sv *ScheduleView) name() string {
+	return "Schedule"
+}
+
+func (sv *ScheduleView) template() string {
+	return "schedules-view.html"
+}
+
+func (sv *ScheduleView) data() interface{} {
+	return map[string]interface{}{
+		"Schedule":   sv.schedule,
+		"SaveStatus": sv.saveStatus,
+	}
+}
+
+func (sv *ScheduleView) scripts() []string {
+	return nil
+}
+
+func (sv *ScheduleView) breadcrumbs() []vm.Breadcrumb {
+	return vm.ScheduleViewBcs(sv.schedule.Name, sv.schedule.Id())
+}
+
+func (sv *ScheduleView) subRenderables() []Renderable {
+	return sv.subs
+}
+
+func (sv *ScheduleView) renderPropagate() (*renderResult, error) {
+	return renderPropagate(sv)
+}
+
+func (sv *ScheduleView) aggregatePipelineData(parent *renderResult, child *renderResult) {
+	aggregatePipelineDataMap(parent, child)
+}
@@ -0,0 +1,54 @@
+package renderables
+
+import (
+	"github.com/yext/revere/web/vm"
+)
+
+type SubprobeAck struct {
+	ack    *vm.SubprobeAck
+	errors []string
+	subs   []Renderable
+}
+
+func NewSubprobeAck(a *vm.SubprobeAck, errs []string) *SubprobeAck {
+	sa := SubprobeAck{}
+	sa.ack = a
+	sa.errors = errs
+	return &sa
+}
+
+func (sa *SubprobeAck) name() string {
+	return "SubprobeAck"
+}
+
+func (sa *SubprobeAck) template() string {
+	return "subprobes-ack.html"
+}
+
+func (sa *SubprobeAck) data() interface{} {
+	return map[string]interface{}{
+		"Subprobe": sa.ack.Subprobe,
+		"AckedBy":  sa.ack.AckedBy,
+		"Errors":   sa.errors,
+	}
+}
+
+func (sa *SubprobeAck) scripts() []string {
+	return nil
+}
+
+func (sa *SubprobeAck) breadcrumbs() []vm.Breadcrumb {
+	return vm.SubprobeAckBcs(sa.ack.Subprobe)
+}
+
+func (sa *SubprobeAck) subRenderables() []Renderable {
+	return nil
+}
+
+func (sa *SubprobeAck) renderPropagate() (*renderResult, error) {
+	return renderPropagate(sa)
+}
+
+func (sa *SubprobeAck) aggregatePipelineData(parent *renderResult, child *renderResult) {
+	aggregatePipelineDataMap(parent, child)
+}
@@ -0,0 +1,180 @@
+package vm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/target"
+)
+
+// scheduleTimeFormat is how schedule handoff and override times are entered
+// and shown, in UTC.
+const scheduleTimeFormat = "2006-01-02 15:04"
+
+type Schedule struct {
+	ScheduleID  db.ScheduleID
+	Name        string
+	Description string
+	Layers      []*ScheduleLayer
+	Overrides   []*ScheduleOverride
+
+	// OnCall is who is on call for the schedule as of when it was loaded.
+	OnCall []string
+}
+
+func (*Schedule) ComponentName() string {
+	return "Schedule"
+}
+
+func (s *Schedule) Id() int64 {
+	return int64(s.ScheduleID)
+}
+
+func NewSchedule(tx *db.Tx, id db.ScheduleID) (*Schedule, error) {
+	schedule, err := tx.LoadSchedule(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if schedule == nil {
+		return nil, errors.Errorf("Schedule not found: %d", id)
+	}
+
+	s := newScheduleFromDB(schedule)
+
+	now := time.Now()
+	layers, err := tx.LoadScheduleLayers(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s.Layers, err = newScheduleLayersFromDB(layers)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	overrides, err := tx.LoadScheduleOverrides(id, now)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s.Overrides = newScheduleOverridesFromDB(overrides)
+
+	s.OnCall, err = target.OnCall(layers, overrides, now)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s, nil
+}
+
+func newScheduleFromDB(schedule *db.Schedule) *Schedule {
+	return &Schedule{
+		ScheduleID:  schedule.ScheduleID,
+		Name:        schedule.Name,
+		Description: schedule.Description,
+	}
+}
+
+func BlankSchedule() *Schedule {
+	return &Schedule{
+		Layers:    []*ScheduleLayer{},
+		Overrides: []*ScheduleOverride{},
+	}
+}
+
+// BlankLayer returns a layer for the edit page to base new layers on.
+func (*Schedule) BlankLayer() *ScheduleLayer {
+	return &ScheduleLayer{RotationType: "day"}
+}
+
+// BlankOverride returns an override for the edit page to base new overrides
+// on.
+func (*Schedule) BlankOverride() *ScheduleOverride {
+	return &ScheduleOverride{}
+}
+
+func AllSchedules(tx *db.Tx) ([]*Schedule, error) {
+	schedules, err := tx.LoadSchedules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	ss := make([]*Schedule, len(schedules))
+	for i, schedule := range schedules {
+		ss[i] = newScheduleFromDB(schedule)
+	}
+	return ss, nil
+}
+
+func (s *Schedule) Validate() (errs []string) {
+	if s.Name == "" {
+		errs = append(errs, fmt.Sprintf("Schedule name is required"))
+	} else if len(s.Name) > 30 {
+		errs = append(errs, fmt.Sprintf("Schedule name must be at most 30 characters"))
+	}
+
+	for _, l := range s.Layers {
+		if !isDelete(l) {
+			errs = append(errs, l.validate()...)
+		}
+	}
+
+	for _, o := range s.Overrides {
+		if !isDelete(o) {
+			errs = append(errs, o.validate()...)
+		}
+	}
+	return
+}
+
+func (s *Schedule) IsCreate() bool {
+	return s.Id() == 0
+}
+
+func (s *Schedule) Save(tx *db.Tx) error {
+	schedule := s.toDBSchedule()
+
+	var err error
+	if isCreate(s) {
+		s.ScheduleID, err = tx.CreateSchedule(schedule)
+	} else {
+		err = tx.UpdateSchedule(schedule)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, l := range s.Layers {
+		l.ScheduleID = s.ScheduleID
+		err = l.save(tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, o := range s.Overrides {
+		o.ScheduleID = s.ScheduleID
+		err = o.save(tx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Schedule) toDBSchedule() *db.Schedule {
+	return &db.Schedule{
+		ScheduleID:  s.ScheduleID,
+		Name:        s.Name,
+		Description: s.Description,
+	}
+}
+
+func parseScheduleTime(t string) (time.Time, error) {
+	return time.ParseInLocation(scheduleTimeFormat, t, time.UTC)
+}
+
+func formatScheduleTime(t time.Time) string {
+	return t.UTC().Format(scheduleTimeFormat)
+}
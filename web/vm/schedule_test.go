@@ -0,0 +1,67 @@
+package vm
+
+import (
+	"testing"
+)
+
+func validSchedule() *Schedule {
+	s := BlankSchedule()
+	s.Name = "team-oncall"
+	s.Layers = []*ScheduleLayer{{
+		Start:        "2016-03-07 09:00",
+		Rotation:     7,
+		RotationType: "day",
+		Members:      "a@ex.com, b@ex.com\nc@ex.com",
+	}}
+	s.Overrides = []*ScheduleOverride{{
+		Email: "d@ex.com",
+		Start: "2016-03-08 09:00",
+		End:   "2016-03-09 09:00",
+	}}
+	return s
+}
+
+func TestValidSchedule(t *testing.T) {
+	s := validSchedule()
+	if errs := s.Validate(); errs != nil {
+		t.Errorf("Unexpected errors for valid schedule: %v\n", errs)
+	}
+
+	members := s.Layers[0].members()
+	if len(members) != 3 || members[2] != "c@ex.com" {
+		t.Errorf("Expected three members, got %v\n", members)
+	}
+}
+
+func TestInvalidSchedule(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(s *Schedule)
+	}{
+		{"blank name", func(s *Schedule) { s.Name = "" }},
+		{"invalid handoff", func(s *Schedule) { s.Layers[0].Start = "tomorrow" }},
+		{"invalid rotation", func(s *Schedule) { s.Layers[0].Rotation = 0 }},
+		{"invalid rotation type", func(s *Schedule) { s.Layers[0].RotationType = "" }},
+		{"no members", func(s *Schedule) { s.Layers[0].Members = " , " }},
+		{"invalid member", func(s *Schedule) { s.Layers[0].Members = "a@ex.com, b" }},
+		{"invalid override email", func(s *Schedule) { s.Overrides[0].Email = "d" }},
+		{"override ending before start", func(s *Schedule) { s.Overrides[0].End = s.Overrides[0].Start }},
+	}
+
+	for _, tt := range tests {
+		s := validSchedule()
+		tt.modify(s)
+		if errs := s.Validate(); errs == nil {
+			t.Errorf("Expected error for %s\n", tt.name)
+		}
+	}
+}
+
+func TestDeletedScheduleLayerNotValidated(t *testing.T) {
+	s := validSchedule()
+	s.Layers[0].Members = ""
+	s.Layers[0].Delete = true
+	if errs := s.Validate(); errs != nil {
+		t.Errorf("Unexpected errors for deleted layer: %v\n", errs)
+	}
+}
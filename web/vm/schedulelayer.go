@@ -0,0 +1,127 @@
+package vm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/target"
+	"github.com/yext/revere/util"
+)
+
+// ScheduleLayer is a rotation of a schedule. Members is a comma separated list
+// of email addresses, in the order they take over.
+type ScheduleLayer struct {
+	LayerID      db.ScheduleLayerID
+	ScheduleID   db.ScheduleID
+	Start        string
+	Rotation     int64
+	RotationType string
+	Members      string
+	Delete       bool
+}
+
+func newScheduleLayersFromDB(layers []*db.ScheduleLayer) ([]*ScheduleLayer, error) {
+	sls := make([]*ScheduleLayer, len(layers))
+	for i, layer := range layers {
+		var members []string
+		if err := layer.Members.Unmarshal(&members); err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		rotation, rotationType := util.GetPeriodAndType(layer.RotationMilli)
+		sls[i] = &ScheduleLayer{
+			LayerID:      layer.LayerID,
+			ScheduleID:   layer.ScheduleID,
+			Start:        formatScheduleTime(layer.Start),
+			Rotation:     rotation,
+			RotationType: rotationType,
+			Members:      strings.Join(members, ", "),
+		}
+	}
+	return sls, nil
+}
+
+func (sl *ScheduleLayer) Id() int64 {
+	return int64(sl.LayerID)
+}
+
+func (sl *ScheduleLayer) IsCreate() bool {
+	return sl.Id() == 0
+}
+
+func (sl *ScheduleLayer) IsDelete() bool {
+	return sl.Delete
+}
+
+func (sl *ScheduleLayer) members() []string {
+	return strings.FieldsFunc(sl.Members, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
+func (sl *ScheduleLayer) validate() (errs []string) {
+	if _, err := parseScheduleTime(sl.Start); err != nil {
+		errs = append(errs, fmt.Sprintf("Invalid handoff time for schedule layer: %s", sl.Start))
+	}
+
+	if sl.Rotation <= 0 || util.GetMs(sl.Rotation, sl.RotationType) == 0 {
+		errs = append(errs, fmt.Sprintf("Invalid rotation for schedule layer: %d %s", sl.Rotation, sl.RotationType))
+	}
+
+	members := sl.members()
+	if len(members) == 0 {
+		errs = append(errs, "Schedule layers must have at least one member")
+	}
+	for _, m := range members {
+		if !target.IsValidEmail(m) {
+			errs = append(errs, fmt.Sprintf("Invalid email for schedule layer member: %s", m))
+		}
+	}
+	return
+}
+
+func (sl *ScheduleLayer) save(tx *db.Tx) error {
+	if isDelete(sl) {
+		if isCreate(sl) {
+			return nil
+		}
+		return errors.Trace(tx.DeleteScheduleLayer(sl.LayerID))
+	}
+
+	layer, err := sl.toDBScheduleLayer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isCreate(sl) {
+		sl.LayerID, err = tx.CreateScheduleLayer(layer)
+	} else {
+		err = tx.UpdateScheduleLayer(layer)
+	}
+	return errors.Trace(err)
+}
+
+func (sl *ScheduleLayer) toDBScheduleLayer() (*db.ScheduleLayer, error) {
+	start, err := parseScheduleTime(sl.Start)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	members, err := json.Marshal(sl.members())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return &db.ScheduleLayer{
+		LayerID:       sl.LayerID,
+		ScheduleID:    sl.ScheduleID,
+		Start:         start,
+		RotationMilli: util.GetMs(sl.Rotation, sl.RotationType),
+		Members:       types.JSONText(members),
+	}, nil
+}
@@ -0,0 +1,104 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/target"
+)
+
+// ScheduleOverride puts Email on call from Start until End, both in UTC.
+type ScheduleOverride struct {
+	OverrideID db.ScheduleOverrideID
+	ScheduleID db.ScheduleID
+	Email      string
+	Start      string
+	End        string
+	Delete     bool
+}
+
+func newScheduleOverridesFromDB(overrides []*db.ScheduleOverride) []*ScheduleOverride {
+	sos := make([]*ScheduleOverride, len(overrides))
+	for i, override := range overrides {
+		sos[i] = &ScheduleOverride{
+			OverrideID: override.OverrideID,
+			ScheduleID: override.ScheduleID,
+			Email:      override.Email,
+			Start:      formatScheduleTime(override.Start),
+			End:        formatScheduleTime(override.End),
+		}
+	}
+	return sos
+}
+
+func (so *ScheduleOverride) Id() int64 {
+	return int64(so.OverrideID)
+}
+
+func (so *ScheduleOverride) IsCreate() bool {
+	return so.Id() == 0
+}
+
+func (so *ScheduleOverride) IsDelete() bool {
+	return so.Delete
+}
+
+func (so *ScheduleOverride) validate() (errs []string) {
+	if !target.IsValidEmail(so.Email) {
+		errs = append(errs, fmt.Sprintf("Invalid email for schedule override: %s", so.Email))
+	}
+
+	start, err := parseScheduleTime(so.Start)
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("Invalid start for schedule override: %s", so.Start))
+	}
+	end, err := parseScheduleTime(so.End)
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("Invalid end for schedule override: %s", so.End))
+	}
+	if len(errs) == 0 && !start.Before(end) {
+		errs = append(errs, "Schedule overrides must start before they end")
+	}
+	return
+}
+
+func (so *ScheduleOverride) save(tx *db.Tx) error {
+	if isDelete(so) {
+		if isCreate(so) {
+			return nil
+		}
+		return errors.Trace(tx.DeleteScheduleOverride(so.OverrideID))
+	}
+
+	override, err := so.toDBScheduleOverride()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isCreate(so) {
+		so.OverrideID, err = tx.CreateScheduleOverride(override)
+	} else {
+		err = tx.UpdateScheduleOverride(override)
+	}
+	return errors.Trace(err)
+}
+
+func (so *ScheduleOverride) toDBScheduleOverride() (*db.ScheduleOverride, error) {
+	start, err := parseScheduleTime(so.Start)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	end, err := parseScheduleTime(so.End)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return &db.ScheduleOverride{
+		OverrideID: so.OverrideID,
+		ScheduleID: so.ScheduleID,
+		Email:      so.Email,
+		Start:      start,
+		End:        end,
+	}, nil
+}
@@ -19,6 +19,21 @@ type SubprobeStatus struct {
 	EnteredState    time.Time
 	FmtEnteredState string
 	LastNormal      time.Time
+	PendingState    state.State
+	FmtPendingSince string
+	PendingReadings int16
+	Flapping        bool
+
+	// SuppressedBy and SuppressedByName are the monitor depended on whose
+	// state suppressed the subprobe's alerts, if any.
+	SuppressedBy     db.MonitorID
+	SuppressedByName string
+
+	// AckedBy and Acked are set while the subprobe's current state is
+	// acknowledged.
+	AckedBy  string
+	Acked    time.Time
+	FmtAcked string
 }
 
 type Subprobe struct {
@@ -74,9 +89,28 @@ func newSubprobeWithStatusFromDB(s *db.SubprobeWithStatusInfo) *Subprobe {
 		Silenced:     s.Silenced,
 		EnteredState: s.EnteredState,
 		LastNormal:   s.LastNormal,
+		Flapping:     s.Flapping,
 		FmtEnteredState: durationfmt.MostSigUnit().Format(
 			time.Now().UTC().Sub(s.EnteredState)),
 	}
+	if s.PendingState > s.State && s.PendingSince != nil {
+		subprobeStatus.PendingState = s.PendingState
+		subprobeStatus.FmtPendingSince = durationfmt.MostSigUnit().Format(
+			time.Now().UTC().Sub(*s.PendingSince))
+		subprobeStatus.PendingReadings = s.PendingReadings
+	}
+
+	if s.SuppressedBy != nil && s.SuppressedByName != nil {
+		subprobeStatus.SuppressedBy = *s.SuppressedBy
+		subprobeStatus.SuppressedByName = *s.SuppressedByName
+	}
+
+	if s.AckedState != nil && *s.AckedState == s.State && s.State != state.Normal {
+		subprobeStatus.AckedBy = *s.AckedBy
+		subprobeStatus.Acked = *s.Acked
+		subprobeStatus.FmtAcked = durationfmt.MostSigUnit().Format(
+			time.Now().UTC().Sub(*s.Acked))
+	}
 
 	return &Subprobe{
 		SubprobeID:  s.SubprobeID,
@@ -131,6 +165,24 @@ func AllAbnormalSubprobesForLabel(tx *db.Tx, id db.LabelID) ([]*Subprobe, error)
 	return newSubprobesWithStatusFromDB(ss), nil
 }
 
+func AllPendingSubprobes(tx *db.Tx) ([]*Subprobe, error) {
+	ss, err := tx.LoadPendingSubprobes()
+	if err != nil {
+		return nil, err
+	}
+
+	return newSubprobesWithStatusFromDB(ss), nil
+}
+
+func AllPendingSubprobesForLabel(tx *db.Tx, id db.LabelID) ([]*Subprobe, error) {
+	ss, err := tx.LoadPendingSubprobesForLabel(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSubprobesWithStatusFromDB(ss), nil
+}
+
 func AllMonitorLabelsForSubprobes(tx *db.Tx, subprobes []*Subprobe) (map[db.MonitorID][]*MonitorLabel, error) {
 	mIds := make([]db.MonitorID, len(subprobes))
 	for i, subprobe := range subprobes {
@@ -0,0 +1,50 @@
+package vm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/yext/revere/db"
+	"github.com/yext/revere/state"
+)
+
+const maxAckedByLength = 100
+
+// SubprobeAck acknowledges a subprobe's current problem on behalf of
+// AckedBy. Repeat alerts are suppressed until the subprobe's state changes.
+type SubprobeAck struct {
+	Subprobe *Subprobe
+	AckedBy  string
+}
+
+func NewSubprobeAck(s *Subprobe, ackedBy string) *SubprobeAck {
+	return &SubprobeAck{
+		Subprobe: s,
+		AckedBy:  strings.TrimSpace(ackedBy),
+	}
+}
+
+func (a *SubprobeAck) Validate() (errs []string) {
+	if a.AckedBy == "" {
+		errs = append(errs, "Name of who is acknowledging is required.")
+	}
+	if len(a.AckedBy) > maxAckedByLength {
+		errs = append(errs, fmt.Sprintf("Name must be at most %d characters.", maxAckedByLength))
+	}
+	if a.Subprobe.Status.State == state.Normal {
+		errs = append(errs, "Only subprobes that are not Normal can be acknowledged.")
+	}
+	return
+}
+
+func (a *SubprobeAck) Save(tx *db.Tx) error {
+	return errors.Trace(tx.SaveSubprobeAck(db.SubprobeAck{
+		SubprobeID: a.Subprobe.SubprobeID,
+		State:      a.Subprobe.Status.State,
+		AckedBy:    a.AckedBy,
+		Acked:      time.Now().UTC(),
+	}))
+}
@@ -0,0 +1,47 @@
+package vm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yext/revere/state"
+)
+
+func erroringSubprobe() *Subprobe {
+	s := new(Subprobe)
+	s.SubprobeID = 1
+	s.MonitorID = 1
+	s.Name = "test.example"
+	s.Status.State = state.Error
+	return s
+}
+
+func TestValidSubprobeAck(t *testing.T) {
+	a := NewSubprobeAck(erroringSubprobe(), "  jdoe  ")
+	if errs := a.Validate(); errs != nil {
+		t.Errorf("Unexpected errors for valid acknowledgement: %v\n", errs)
+	}
+	if a.AckedBy != "jdoe" {
+		t.Errorf("Expected acknowledger jdoe, got %q\n", a.AckedBy)
+	}
+}
+
+func TestInvalidSubprobeAck(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   state.State
+		ackedBy string
+	}{
+		{"blank name", state.Error, " "},
+		{"long name", state.Error, strings.Repeat("a", maxAckedByLength+1)},
+		{"normal subprobe", state.Normal, "jdoe"},
+	}
+
+	for _, tt := range tests {
+		s := erroringSubprobe()
+		s.Status.State = tt.state
+		if errs := NewSubprobeAck(s, tt.ackedBy).Validate(); errs == nil {
+			t.Errorf("Expected error for %s\n", tt.name)
+		}
+	}
+}
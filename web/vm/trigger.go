@@ -13,16 +13,18 @@ import (
 )
 
 type Trigger struct {
-	TriggerID     db.TriggerID
-	Level         state.State
-	LevelText     string
-	Period        int32
-	PeriodType    string
-	TargetType    db.TargetType
-	TargetParams  string
-	TriggerOnExit bool
-	Target        target.VM
-	Delete        bool
+	TriggerID       db.TriggerID
+	Level           state.State
+	LevelText       string
+	Period          int32
+	PeriodType      string
+	GroupWindow     int64
+	GroupWindowType string
+	TargetType      db.TargetType
+	TargetParams    string
+	TriggerOnExit   bool
+	Target          target.VM
+	Delete          bool
 }
 
 func newTriggerFromModel(trigger *db.Trigger) (*Trigger, error) {
@@ -32,16 +34,19 @@ func newTriggerFromModel(trigger *db.Trigger) (*Trigger, error) {
 	}
 
 	period, periodType := util.GetPeriodAndType(int64(trigger.PeriodMilli))
+	groupWindow, groupWindowType := util.GetPeriodAndType(trigger.GroupMilli)
 
 	return &Trigger{
-		TriggerID:     trigger.TriggerID,
-		Level:         trigger.Level,
-		Period:        int32(period),
-		PeriodType:    periodType,
-		TargetType:    trigger.TargetType,
-		TargetParams:  "",
-		TriggerOnExit: trigger.TriggerOnExit,
-		Target:        target,
+		TriggerID:       trigger.TriggerID,
+		Level:           trigger.Level,
+		Period:          int32(period),
+		PeriodType:      periodType,
+		GroupWindow:     groupWindow,
+		GroupWindowType: groupWindowType,
+		TargetType:      trigger.TargetType,
+		TargetParams:    "",
+		TriggerOnExit:   trigger.TriggerOnExit,
+		Target:          target,
 	}, nil
 }
 
@@ -56,16 +61,9 @@ func (t *Trigger) Id() int64 {
 }
 
 func (t *Trigger) validate() (errs []string) {
-	var err error
-	target, err := target.LoadFromParams(t.TargetType, t.TargetParams)
-	if err == nil {
-		t.Target = target
-		errs = append(errs, target.Validate()...)
-	} else {
-		errs = append(errs, fmt.Sprintf("Unable to load target for trigger: %s", t.TargetParams))
-	}
+	errs = append(errs, t.validateTarget()...)
 
-	if err = t.Level.Validate(); err != nil {
+	if err := t.Level.Validate(); err != nil {
 		errs = append(errs, fmt.Sprintf("Invalid state for trigger: %d", t.Level))
 	}
 
@@ -73,9 +71,24 @@ func (t *Trigger) validate() (errs []string) {
 		errs = append(errs, fmt.Sprintf("Invalid period for trigger: %d %s", t.Period, t.PeriodType))
 	}
 
+	if t.GroupWindow < 0 || (t.GroupWindow > 0 && util.GetMs(t.GroupWindow, t.GroupWindowType) == 0) {
+		errs = append(errs, fmt.Sprintf("Invalid grouping window for trigger: %d %s", t.GroupWindow, t.GroupWindowType))
+	} else if t.GroupWindow > 0 && t.Target != nil && !target.SupportsGrouping(t.TargetType) {
+		errs = append(errs, fmt.Sprintf("%s targets can't group alerts.", t.Target.Type().Name()))
+	}
+
 	return
 }
 
+func (t *Trigger) validateTarget() (errs []string) {
+	target, err := target.LoadFromParams(t.TargetType, t.TargetParams)
+	if err != nil {
+		return append(errs, fmt.Sprintf("Unable to load target for trigger: %s", t.TargetParams))
+	}
+	t.Target = target
+	return append(errs, target.Validate()...)
+}
+
 func (t *Trigger) setId(id db.TriggerID) {
 	t.TriggerID = id
 }
@@ -96,6 +109,7 @@ func (t *Trigger) toDBTrigger() (*db.Trigger, error) {
 		Level:         level,
 		TriggerOnExit: t.TriggerOnExit,
 		PeriodMilli:   int32(util.GetMs(int64(t.Period), t.PeriodType)),
+		GroupMilli:    util.GetMs(t.GroupWindow, t.GroupWindowType),
 		TargetType:    t.TargetType,
 		Target:        types.JSONText(triggerJSON),
 	}, nil
@@ -63,3 +63,38 @@ func TestValidTriggerPeriod(t *testing.T) {
 		}
 	}
 }
+
+func TestTriggerGroupWindow(t *testing.T) {
+	trigger := validTrigger()
+	trigger.GroupWindow = 2
+	trigger.GroupWindowType = "minute"
+	if errs := trigger.validate(); errs != nil {
+		t.Errorf("Unexpected errors for grouping email alerts: %v", errs)
+	}
+
+	trigger.GroupWindow = -1
+	if errs := trigger.validate(); errs == nil {
+		t.Error("Expected error for negative grouping window")
+	}
+
+	trigger.GroupWindow = 2
+	trigger.GroupWindowType = ""
+	if errs := trigger.validate(); errs == nil {
+		t.Error("Expected error for invalid grouping window type")
+	}
+}
+
+func TestTriggerGroupWindowUnsupportedTarget(t *testing.T) {
+	trigger := validTrigger()
+	trigger.TargetType = target.PagerDutyType{}.Id()
+	trigger.TargetParams = `{"RoutingKey":"0123456789abcdef0123456789abcdef"}`
+	if errs := trigger.validate(); errs != nil {
+		t.Fatalf("Unexpected errors for PagerDuty trigger: %v", errs)
+	}
+
+	trigger.GroupWindow = 2
+	trigger.GroupWindowType = "minute"
+	if errs := trigger.validate(); errs == nil {
+		t.Error("Expected error for grouping PagerDuty alerts")
+	}
+}